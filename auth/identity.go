@@ -0,0 +1,41 @@
+package auth
+
+import "context"
+
+// Identity is the caller proven by a verified token
+type Identity struct {
+	Subject string
+	Role    string
+	// UserID and StaffID are the customer and the staff member orders are
+	// created for. Both default to the subject when the token doesn't name them.
+	UserID  string
+	StaffID string
+	// TenantID is the shop the token is limited to, empty when it names none
+	TenantID string
+}
+
+type identityKey struct{}
+
+func WithIdentity(ctx context.Context, identity Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, identity)
+}
+
+// FromContext returns the identity of an authenticated request
+func FromContext(ctx context.Context) (Identity, bool) {
+	identity, ok := ctx.Value(identityKey{}).(Identity)
+	return identity, ok
+}
+
+type tokenKey struct{}
+
+// WithToken keeps the bearer token of a request so calls to other services
+// can be made on behalf of the caller
+func WithToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, tokenKey{}, token)
+}
+
+// TokenFromContext returns the bearer token the identity was verified from
+func TokenFromContext(ctx context.Context) (string, bool) {
+	token, ok := ctx.Value(tokenKey{}).(string)
+	return token, ok
+}
@@ -0,0 +1,132 @@
+package auth
+
+type Role string
+
+const (
+	RoleCustomer Role = "customer"
+	RoleStaff    Role = "staff"
+	RoleAdmin    Role = "admin"
+)
+
+// Policy lists the roles allowed to perform each operation. Operations are
+// gRPC full method names or REST routes written as "METHOD /route" with gin
+// route parameters. Operations missing from the policy are denied.
+type Policy map[string][]Role
+
+// Allows reports whether role may perform operation
+func (p Policy) Allows(operation string, role string) bool {
+	for _, allowed := range p[operation] {
+		if string(allowed) == role {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	everyone = []Role{RoleCustomer, RoleStaff, RoleAdmin}
+	staff    = []Role{RoleStaff, RoleAdmin}
+	admins   = []Role{RoleAdmin}
+)
+
+// DefaultPolicy lets customers place orders and read them, the services only
+// show customers their own orders and customer record. Staff run orders and
+// admins also manage the catalogue, promotions, taxes and shipping methods.
+var DefaultPolicy = Policy{
+	// orders
+	"POST /api/SAGA/order/create":                 everyone,
+	"POST /api/TCC/order/create":                  everyone,
+	"GET /api/orders":                             everyone,
+	"GET /api/orders/:id":                         everyone,
+	"GET /api/orders/:id/products":                everyone,
+	"GET /api/orders/:id/history":                 everyone,
+	"GET /api/orders/:id/invoice":                 everyone,
+	"PATCH /api/orders/:id":                       staff,
+	"DELETE /api/orders/:id":                      staff,
+	"POST /api/orders/:id/products":               staff,
+	"PATCH /api/orders/:id/products/:product_id":  staff,
+	"DELETE /api/orders/:id/products/:product_id": staff,
+	"POST /api/orders/:id/status":                 staff,
+	"POST /api/orders/:id/cancel":                 staff,
+	"GET /api/orders/watch":                       staff,
+	"GET /api/stock-returns":                      staff,
+	"GET /api/orders/:id/payments":                staff,
+	"POST /api/orders/:id/payments/authorize":     staff,
+	"POST /api/orders/:id/payments/capture":       staff,
+	"POST /api/orders/:id/payments/void":          staff,
+	"GET /api/orders/:id/shipments":               staff,
+	"POST /api/orders/:id/shipments":              staff,
+	"GET /api/shipments/:id":                      staff,
+	"POST /api/shipments/:id/status":              staff,
+	"POST /api/orders/:id/returns":                staff,
+	"GET /api/returns":                            staff,
+	"GET /api/returns/:id":                        staff,
+	"POST /api/returns/:id/status":                staff,
+	"/oms.OrderService/Create":                    everyone,
+	"/oms.OrderService/TCCCreateOrder":            everyone,
+	"/oms.OrderService/Get":                       everyone,
+	"/oms.OrderService/List":                      everyone,
+	"/oms.OrderService/GetProducts":               everyone,
+	"/oms.OrderService/Update":                    staff,
+	"/oms.OrderService/Delete":                    staff,
+	"/oms_ext.OrderStatusService/ChangeStatus":    staff,
+	"/oms_ext.OrderStatusService/GetHistory":      everyone,
+	"/oms_ext.OrderWatchService/WatchOrders":      staff,
+	"/oms_ext.InvoiceService/GetInvoice":          everyone,
+
+	// customers
+	"GET /api/customers":                              staff,
+	"POST /api/customers":                             everyone,
+	"GET /api/customers/:id":                          everyone,
+	"PATCH /api/customers/:id":                        everyone,
+	"GET /api/customers/:id/orders":                   everyone,
+	"GET /api/customers/:id/stats":                    everyone,
+	"POST /api/customers/:id/addresses":               everyone,
+	"PUT /api/customers/:id/addresses/:address_id":    everyone,
+	"DELETE /api/customers/:id/addresses/:address_id": everyone,
+
+	// products
+	"GET /api/products":                    everyone,
+	"GET /api/products/:id":                everyone,
+	"GET /api/products/by-code/:code":      everyone,
+	"GET /api/products/:id/price":          everyone,
+	"GET /api/products/:id/prices":         everyone,
+	"GET /api/products/by-order/:order_id": staff,
+	"POST /api/products":                   admins,
+	"PATCH /api/products/:id":              admins,
+	"DELETE /api/products/:id":             admins,
+	"GET /api/price-changes":               staff,
+	"GET /api/price-changes/:id":           staff,
+	"POST /api/price-changes":              admins,
+	"POST /api/price-changes/:id/cancel":   admins,
+	"/oms.ProductService/Get":              everyone,
+	"/oms.ProductService/List":             everyone,
+	"/oms.ProductService/GetByOrder":       staff,
+	"/oms.ProductService/Create":           admins,
+	"/oms.ProductService/Update":           admins,
+	"/oms.ProductService/Delete":           admins,
+
+	// promotions and taxes
+	"GET /api/promotions":        staff,
+	"GET /api/promotions/:id":    staff,
+	"POST /api/promotions":       admins,
+	"PATCH /api/promotions/:id":  admins,
+	"DELETE /api/promotions/:id": admins,
+	"GET /api/tax-rules":         staff,
+	"GET /api/tax-rules/:id":     staff,
+	"POST /api/tax-rules":        admins,
+	"PATCH /api/tax-rules/:id":   admins,
+	"DELETE /api/tax-rules/:id":  admins,
+
+	// shipping methods
+	"GET /api/shipping-methods":        everyone,
+	"GET /api/shipping-methods/:id":    everyone,
+	"POST /api/shipping-methods":       admins,
+	"PATCH /api/shipping-methods/:id":  admins,
+	"DELETE /api/shipping-methods/:id": admins,
+	"/oms_ext.PromotionService/Get":    staff,
+	"/oms_ext.PromotionService/List":   staff,
+	"/oms_ext.PromotionService/Create": admins,
+	"/oms_ext.PromotionService/Update": admins,
+	"/oms_ext.PromotionService/Delete": admins,
+}
@@ -0,0 +1,61 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"regexp"
+)
+
+// AllTenants is the tenant of internal callers like workers, they see the
+// data of every shop. Requests can never act for it.
+const AllTenants = "*"
+
+var (
+	ErrMissingTenant = errors.New("missing tenant")
+	ErrInvalidTenant = errors.New("invalid tenant")
+)
+
+var tenantPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+
+// ValidTenant reports whether id can name a shop
+func ValidTenant(id string) bool {
+	return tenantPattern.MatchString(id)
+}
+
+// ResolveTenant picks the shop a request acts for. The tenant of the token
+// wins, a requested tenant must match it. Only admin tokens without a tenant
+// may pick the shop, other tokens without one act for fallback and are
+// rejected when there is none.
+func ResolveTenant(identity Identity, requested, fallback string) (string, error) {
+	if requested != "" && !ValidTenant(requested) {
+		return "", ErrInvalidTenant
+	}
+
+	switch {
+	case identity.TenantID != "":
+		if requested != "" && requested != identity.TenantID {
+			return "", ErrPermissionDenied
+		}
+		return identity.TenantID, nil
+	case requested != "" && identity.Role == string(RoleAdmin):
+		return requested, nil
+	case requested != "" && requested != fallback:
+		return "", ErrPermissionDenied
+	case fallback != "":
+		return fallback, nil
+	default:
+		return "", ErrMissingTenant
+	}
+}
+
+type tenantKey struct{}
+
+func WithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenant)
+}
+
+// TenantFromContext returns the shop the caller acts for
+func TenantFromContext(ctx context.Context) (string, bool) {
+	tenant, ok := ctx.Value(tenantKey{}).(string)
+	return tenant, ok
+}
@@ -0,0 +1,48 @@
+package auth
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestResolveTenant(t *testing.T) {
+	customer := Identity{Subject: "alice", Role: string(RoleCustomer)}
+	admin := Identity{Subject: "root", Role: string(RoleAdmin)}
+	withTenant := func(identity Identity, tenant string) Identity {
+		identity.TenantID = tenant
+		return identity
+	}
+
+	tests := []struct {
+		name      string
+		identity  Identity
+		requested string
+		fallback  string
+		want      string
+		wantErr   error
+	}{
+		{name: "token tenant", identity: withTenant(customer, "shop-1"), fallback: "default", want: "shop-1"},
+		{name: "token tenant requested", identity: withTenant(customer, "shop-1"), requested: "shop-1", want: "shop-1"},
+		{name: "other shop than the token", identity: withTenant(admin, "shop-1"), requested: "shop-2", wantErr: ErrPermissionDenied},
+		{name: "admin picks the shop", identity: admin, requested: "shop-2", fallback: "default", want: "shop-2"},
+		{name: "admin without a pick", identity: admin, fallback: "default", want: "default"},
+		{name: "fallback", identity: customer, fallback: "default", want: "default"},
+		{name: "fallback requested", identity: customer, requested: "default", fallback: "default", want: "default"},
+		{name: "customer picks a shop", identity: customer, requested: "shop-2", fallback: "default", wantErr: ErrPermissionDenied},
+		{name: "no tenant", identity: customer, wantErr: ErrMissingTenant},
+		{name: "all tenants", identity: admin, requested: AllTenants, wantErr: ErrInvalidTenant},
+		{name: "invalid tenant", identity: admin, requested: "shop 1", wantErr: ErrInvalidTenant},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveTenant(tt.identity, tt.requested, tt.fallback)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ResolveTenant() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ResolveTenant() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
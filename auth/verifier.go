@@ -0,0 +1,136 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"github.com/golang-jwt/jwt/v5"
+	"time"
+)
+
+var (
+	ErrMissingToken     = errors.New("missing bearer token")
+	ErrInvalidToken     = errors.New("invalid token")
+	ErrPermissionDenied = errors.New("permission denied")
+)
+
+const (
+	// clockSkew is how far token times may be off from our clock
+	clockSkew = 30 * time.Second
+	// maxIDLength is the longest subject, user or staff id, they are stored with orders
+	maxIDLength = 64
+)
+
+type Verifier interface {
+	// Verify checks the token signature and claims and returns the identity it
+	// carries
+	Verify(token string) (Identity, error)
+}
+
+type verifier struct {
+	keys        KeySet
+	parser      *jwt.Parser
+	roleClaim   string
+	tenantClaim string
+}
+
+// NewVerifier creates a verifier for tokens signed with one of keys. issuer and
+// audience are only checked when set. The role is read from roleClaim and the
+// tenant from tenantClaim.
+func NewVerifier(keys KeySet, issuer, audience, roleClaim, tenantClaim string) Verifier {
+	options := []jwt.ParserOption{
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(clockSkew),
+	}
+	if issuer != "" {
+		options = append(options, jwt.WithIssuer(issuer))
+	}
+	if audience != "" {
+		options = append(options, jwt.WithAudience(audience))
+	}
+
+	return &verifier{
+		keys:        keys,
+		parser:      jwt.NewParser(options...),
+		roleClaim:   roleClaim,
+		tenantClaim: tenantClaim,
+	}
+}
+
+func (v *verifier) Verify(token string) (Identity, error) {
+	if token == "" {
+		return Identity{}, ErrMissingToken
+	}
+
+	claims := jwt.MapClaims{}
+	_, err := v.parser.ParseWithClaims(token, claims, v.key)
+	if err != nil {
+		return Identity{}, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+
+	subject, err := claims.GetSubject()
+	if err != nil || subject == "" {
+		return Identity{}, fmt.Errorf("%w: no subject", ErrInvalidToken)
+	}
+
+	identity := Identity{
+		Subject:  subject,
+		Role:     stringClaim(claims, v.roleClaim),
+		UserID:   stringClaim(claims, "user_id"),
+		StaffID:  stringClaim(claims, "staff_id"),
+		TenantID: stringClaim(claims, v.tenantClaim),
+	}
+	if identity.UserID == "" {
+		identity.UserID = subject
+	}
+	if identity.StaffID == "" {
+		identity.StaffID = subject
+	}
+
+	if len(identity.Subject) > maxIDLength || len(identity.UserID) > maxIDLength || len(identity.StaffID) > maxIDLength {
+		return Identity{}, fmt.Errorf("%w: ids are limited to %d characters", ErrInvalidToken, maxIDLength)
+	}
+	if identity.TenantID != "" && !ValidTenant(identity.TenantID) {
+		return Identity{}, fmt.Errorf("%w: %w", ErrInvalidToken, ErrInvalidTenant)
+	}
+
+	return identity, nil
+}
+
+// key finds the key named by the token header. Tokens without a key id are
+// accepted when there is only one key. The key type must match the algorithm.
+func (v *verifier) key(token *jwt.Token) (any, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	key, ok := v.keys[kid]
+	if !ok && kid == "" && len(v.keys) == 1 {
+		for _, k := range v.keys {
+			key, ok = k, true
+		}
+	}
+	if !ok {
+		return nil, fmt.Errorf("unknown key %q", kid)
+	}
+
+	switch token.Method.(type) {
+	case *jwt.SigningMethodRSA, *jwt.SigningMethodRSAPSS:
+		_, ok = key.(*rsa.PublicKey)
+	case *jwt.SigningMethodECDSA:
+		_, ok = key.(*ecdsa.PublicKey)
+	case *jwt.SigningMethodHMAC:
+		_, ok = key.([]byte)
+	default:
+		ok = false
+	}
+	if !ok {
+		return nil, fmt.Errorf("key %q can't verify %s", kid, token.Method.Alg())
+	}
+
+	return key, nil
+}
+
+func stringClaim(claims jwt.MapClaims, name string) string {
+	value, _ := claims[name].(string)
+	return value
+}
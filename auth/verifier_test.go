@@ -0,0 +1,202 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"github.com/golang-jwt/jwt/v5"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestVerify(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secret := []byte("secret")
+
+	keys := KeySet{"hmac": secret, "rsa": &rsaKey.PublicKey}
+	v := NewVerifier(keys, "issuer", "oms", "role", "tenant")
+
+	now := time.Now()
+	// claims are valid for v, each case changes some of them
+	claims := func(change func(c jwt.MapClaims)) jwt.MapClaims {
+		c := jwt.MapClaims{
+			"sub":  "alice",
+			"iss":  "issuer",
+			"aud":  "oms",
+			"exp":  now.Add(time.Hour).Unix(),
+			"role": "staff",
+		}
+		if change != nil {
+			change(c)
+		}
+		return c
+	}
+	sign := func(method jwt.SigningMethod, kid string, key any, c jwt.MapClaims) string {
+		token := jwt.NewWithClaims(method, c)
+		if kid != "" {
+			token.Header["kid"] = kid
+		}
+		signed, err := token.SignedString(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return signed
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		want    Identity
+		wantErr error
+	}{
+		{
+			name:  "hmac",
+			token: sign(jwt.SigningMethodHS256, "hmac", secret, claims(nil)),
+			want:  Identity{Subject: "alice", Role: "staff", UserID: "alice", StaffID: "alice"},
+		},
+		{
+			name:  "rsa",
+			token: sign(jwt.SigningMethodRS256, "rsa", rsaKey, claims(nil)),
+			want:  Identity{Subject: "alice", Role: "staff", UserID: "alice", StaffID: "alice"},
+		},
+		{
+			name: "named ids and tenant",
+			token: sign(jwt.SigningMethodHS256, "hmac", secret, claims(func(c jwt.MapClaims) {
+				c["user_id"] = "customer-1"
+				c["staff_id"] = "staff-1"
+				c["tenant"] = "shop-1"
+			})),
+			want: Identity{Subject: "alice", Role: "staff", UserID: "customer-1", StaffID: "staff-1", TenantID: "shop-1"},
+		},
+		{
+			name: "expired within the clock skew",
+			token: sign(jwt.SigningMethodHS256, "hmac", secret, claims(func(c jwt.MapClaims) {
+				c["exp"] = now.Add(-clockSkew / 2).Unix()
+			})),
+			want: Identity{Subject: "alice", Role: "staff", UserID: "alice", StaffID: "alice"},
+		},
+		{
+			name:    "empty",
+			token:   "",
+			wantErr: ErrMissingToken,
+		},
+		{
+			name:    "garbage",
+			token:   "not.a.token",
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name: "expired",
+			token: sign(jwt.SigningMethodHS256, "hmac", secret, claims(func(c jwt.MapClaims) {
+				c["exp"] = now.Add(-time.Hour).Unix()
+			})),
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name: "no expiry",
+			token: sign(jwt.SigningMethodHS256, "hmac", secret, claims(func(c jwt.MapClaims) {
+				delete(c, "exp")
+			})),
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:    "wrong secret",
+			token:   sign(jwt.SigningMethodHS256, "hmac", []byte("other"), claims(nil)),
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:    "unknown key",
+			token:   sign(jwt.SigningMethodHS256, "other", secret, claims(nil)),
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:    "no key id with several keys",
+			token:   sign(jwt.SigningMethodHS256, "", secret, claims(nil)),
+			wantErr: ErrInvalidToken,
+		},
+		{
+			// an HMAC token keyed with the public RSA key must not pass
+			name:    "algorithm of another key type",
+			token:   sign(jwt.SigningMethodHS256, "rsa", secret, claims(nil)),
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:    "unsigned",
+			token:   sign(jwt.SigningMethodNone, "hmac", jwt.UnsafeAllowNoneSignatureType, claims(nil)),
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name: "wrong issuer",
+			token: sign(jwt.SigningMethodHS256, "hmac", secret, claims(func(c jwt.MapClaims) {
+				c["iss"] = "other"
+			})),
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name: "wrong audience",
+			token: sign(jwt.SigningMethodHS256, "hmac", secret, claims(func(c jwt.MapClaims) {
+				c["aud"] = "other"
+			})),
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name: "no subject",
+			token: sign(jwt.SigningMethodHS256, "hmac", secret, claims(func(c jwt.MapClaims) {
+				delete(c, "sub")
+			})),
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name: "subject too long",
+			token: sign(jwt.SigningMethodHS256, "hmac", secret, claims(func(c jwt.MapClaims) {
+				c["sub"] = strings.Repeat("a", maxIDLength+1)
+			})),
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name: "invalid tenant",
+			token: sign(jwt.SigningMethodHS256, "hmac", secret, claims(func(c jwt.MapClaims) {
+				c["tenant"] = AllTenants
+			})),
+			wantErr: ErrInvalidTenant,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := v.Verify(tt.token)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Verify() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Verify() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerifySingleKey(t *testing.T) {
+	secret := []byte("secret")
+	v := NewVerifier(KeySet{"only": secret}, "", "", "role", "tenant")
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub": "alice",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}).SignedString(secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// tokens without a key id are checked against the only key
+	got, err := v.Verify(token)
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if got.Subject != "alice" {
+		t.Errorf("Verify() subject = %q, want alice", got.Subject)
+	}
+}
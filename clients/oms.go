@@ -2,8 +2,12 @@ package clients
 
 import (
 	"context"
+	"encoding/json"
 	"github.com/igntnk/stocky-2pc-controller/protobufs/oms_pb"
+	"github.com/igntnk/stocky-oms/auth"
+	"github.com/igntnk/stocky-oms/models"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 )
 
 type OMSClient interface {
@@ -21,7 +25,7 @@ type OMSClient interface {
 		comment, userID, staffID string,
 		products []*OrderProductInput,
 	) (*oms_pb.Order, error)
-	TCCOrderCreation(ctx context.Context, order *oms_pb.CreateOrderRequest) (*oms_pb.Order, error)
+	TCCOrderCreation(ctx context.Context, order *oms_pb.CreateOrderRequest, extras OrderExtras) (*oms_pb.Order, error)
 	GetOrder(ctx context.Context, uuid string) (*oms_pb.Order, error)
 	ListOrders(ctx context.Context, limit, offset int32, status oms_pb.OrderStatus) ([]*oms_pb.Order, error)
 	UpdateOrder(ctx context.Context, uuid string, comment *string, status *oms_pb.OrderStatus) (*oms_pb.Order, error)
@@ -29,6 +33,18 @@ type OMSClient interface {
 	GetOrderProducts(ctx context.Context, orderUUID string) ([]*oms_pb.Product, error)
 }
 
+// OrderExtras are order fields oms_pb has no place for. They are sent in
+// metadata and the server reads them from there.
+type OrderExtras struct {
+	Currency string
+	Region   string
+	Coupons  []string
+
+	ShippingMethod  string
+	ShippingAddress *models.Address
+	BillingAddress  *models.Address
+}
+
 // OrderProductInput represents input for order product
 type OrderProductInput struct {
 	ProductUUID string
@@ -50,7 +66,39 @@ type omsClient struct {
 	productClient oms_pb.ProductServiceClient
 }
 
-func (c *omsClient) TCCOrderCreation(ctx context.Context, order *oms_pb.CreateOrderRequest) (*oms_pb.Order, error) {
+// TCCOrderCreation creates the order on behalf of the caller of ctx, whose
+// token and shop are forwarded. The server takes the customer and the staff
+// member from that token.
+func (c *omsClient) TCCOrderCreation(ctx context.Context, order *oms_pb.CreateOrderRequest, extras OrderExtras) (*oms_pb.Order, error) {
+	ctx = callerMetadata(ctx)
+	if extras.Currency != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "currency", extras.Currency)
+	}
+	if extras.Region != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "region", extras.Region)
+	}
+	for _, coupon := range extras.Coupons {
+		ctx = metadata.AppendToOutgoingContext(ctx, "coupon", coupon)
+	}
+	if extras.ShippingMethod != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "shipping-method", extras.ShippingMethod)
+	}
+	// addresses may hold any text, binary metadata carries it unchanged
+	if extras.ShippingAddress != nil {
+		address, err := json.Marshal(extras.ShippingAddress)
+		if err != nil {
+			return nil, err
+		}
+		ctx = metadata.AppendToOutgoingContext(ctx, "shipping-address-bin", string(address))
+	}
+	if extras.BillingAddress != nil {
+		address, err := json.Marshal(extras.BillingAddress)
+		if err != nil {
+			return nil, err
+		}
+		ctx = metadata.AppendToOutgoingContext(ctx, "billing-address-bin", string(address))
+	}
+
 	stream, err := c.orderClient.TCCCreateOrder(ctx)
 	if err != nil {
 		return nil, err
@@ -67,7 +115,24 @@ func (c *omsClient) TCCOrderCreation(ctx context.Context, order *oms_pb.CreateOr
 		return nil, err
 	}
 
-	return stream.Recv()
+	// Pending order reserved by the Try phase
+	_, err = stream.Recv()
+	if err != nil {
+		return nil, err
+	}
+
+	// Empty message for confirm
+	err = stream.Send(&oms_pb.CreateOrderRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	confirmed, err := stream.Recv()
+	if err != nil {
+		return nil, err
+	}
+
+	return confirmed, stream.CloseSend()
 }
 
 // Product methods implementation
@@ -222,3 +287,14 @@ func (c *omsClient) GetOrderProducts(ctx context.Context, orderUUID string) ([]*
 	}
 	return resp.Products, nil
 }
+
+// callerMetadata forwards the bearer token and the shop of the caller of ctx
+func callerMetadata(ctx context.Context) context.Context {
+	if token, ok := auth.TokenFromContext(ctx); ok && token != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+	}
+	if tenant, ok := auth.TenantFromContext(ctx); ok && tenant != auth.AllTenants {
+		ctx = metadata.AppendToOutgoingContext(ctx, "x-tenant-id", tenant)
+	}
+	return ctx
+}
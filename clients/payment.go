@@ -0,0 +1,33 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"github.com/igntnk/stocky-oms/models"
+)
+
+var (
+	ErrPaymentDeclined      = errors.New("payment declined")
+	ErrPaymentInvalidAmount = errors.New("payment amount exceeds what is available")
+	ErrPaymentInvalidState  = errors.New("payment is in the wrong state for this operation")
+	ErrPaymentNotFound      = errors.New("payment reference is unknown to the provider")
+)
+
+// PaymentProvider is a payment gateway. Money is first authorized, then
+// captured or voided. Captured money can be refunded in parts.
+type PaymentProvider interface {
+	// Name identifies the provider in the payment records
+	Name() string
+	// Authorize holds amount on the customer account and returns the
+	// reference used by the other calls
+	Authorize(ctx context.Context, req PaymentAuthorization) (string, error)
+	Capture(ctx context.Context, reference string, amount models.Money) error
+	Void(ctx context.Context, reference string) error
+	Refund(ctx context.Context, reference string, amount models.Money) error
+}
+
+type PaymentAuthorization struct {
+	OrderID  string
+	Amount   models.Money
+	Currency string
+}
@@ -0,0 +1,116 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"github.com/igntnk/stocky-oms/models"
+	"sync"
+)
+
+type fakePaymentState string
+
+const (
+	fakePaymentAuthorized fakePaymentState = "authorized"
+	fakePaymentCaptured   fakePaymentState = "captured"
+	fakePaymentVoided     fakePaymentState = "voided"
+)
+
+type fakePayment struct {
+	state    fakePaymentState
+	amount   models.Money
+	captured models.Money
+	refunded models.Money
+}
+
+type fakePaymentProvider struct {
+	mu       sync.Mutex
+	limit    models.Money
+	next     int
+	payments map[string]*fakePayment
+}
+
+// NewFakePaymentProvider keeps payments in memory. References are numbered
+// in call order and authorizations above limit are declined, a zero limit
+// accepts any amount, so the same calls always give the same results.
+func NewFakePaymentProvider(limit models.Money) PaymentProvider {
+	return &fakePaymentProvider{
+		limit:    limit,
+		payments: make(map[string]*fakePayment),
+	}
+}
+
+func (p *fakePaymentProvider) Name() string {
+	return "fake"
+}
+
+func (p *fakePaymentProvider) Authorize(ctx context.Context, req PaymentAuthorization) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if req.Amount.Sign() <= 0 || !p.limit.IsZero() && req.Amount.Cmp(p.limit) > 0 {
+		return "", ErrPaymentDeclined
+	}
+
+	p.next++
+	reference := fmt.Sprintf("fake-%06d", p.next)
+	p.payments[reference] = &fakePayment{
+		state:  fakePaymentAuthorized,
+		amount: req.Amount,
+	}
+	return reference, nil
+}
+
+func (p *fakePaymentProvider) Capture(ctx context.Context, reference string, amount models.Money) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	payment, ok := p.payments[reference]
+	if !ok {
+		return ErrPaymentNotFound
+	}
+	if payment.state != fakePaymentAuthorized {
+		return ErrPaymentInvalidState
+	}
+	if amount.Sign() <= 0 || amount.Cmp(payment.amount) > 0 {
+		return ErrPaymentInvalidAmount
+	}
+
+	payment.state = fakePaymentCaptured
+	payment.captured = amount
+	return nil
+}
+
+func (p *fakePaymentProvider) Void(ctx context.Context, reference string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	payment, ok := p.payments[reference]
+	if !ok {
+		return ErrPaymentNotFound
+	}
+	if payment.state != fakePaymentAuthorized {
+		return ErrPaymentInvalidState
+	}
+
+	payment.state = fakePaymentVoided
+	return nil
+}
+
+func (p *fakePaymentProvider) Refund(ctx context.Context, reference string, amount models.Money) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	payment, ok := p.payments[reference]
+	if !ok {
+		return ErrPaymentNotFound
+	}
+	if payment.state != fakePaymentCaptured {
+		return ErrPaymentInvalidState
+	}
+	if amount.Sign() <= 0 || payment.refunded.Add(amount).Cmp(payment.captured) > 0 {
+		return ErrPaymentInvalidAmount
+	}
+
+	payment.refunded = payment.refunded.Add(amount)
+	return nil
+}
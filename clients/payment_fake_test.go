@@ -0,0 +1,143 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"github.com/igntnk/stocky-oms/models"
+	"testing"
+)
+
+func TestFakePaymentProvider(t *testing.T) {
+	type step struct {
+		op      string
+		amount  int64
+		wantErr error
+	}
+
+	tests := []struct {
+		name  string
+		limit int64
+		steps []step
+	}{
+		{
+			name: "authorize and capture",
+			steps: []step{
+				{op: "authorize", amount: 1000},
+				{op: "capture", amount: 1000},
+			},
+		},
+		{
+			name: "partial capture and refunds",
+			steps: []step{
+				{op: "authorize", amount: 1000},
+				{op: "capture", amount: 800},
+				{op: "refund", amount: 500},
+				{op: "refund", amount: 300},
+				{op: "refund", amount: 1, wantErr: ErrPaymentInvalidAmount},
+			},
+		},
+		{
+			name: "capture more than authorized",
+			steps: []step{
+				{op: "authorize", amount: 1000},
+				{op: "capture", amount: 1001, wantErr: ErrPaymentInvalidAmount},
+			},
+		},
+		{
+			name: "void releases the authorization",
+			steps: []step{
+				{op: "authorize", amount: 1000},
+				{op: "void"},
+				{op: "capture", amount: 1000, wantErr: ErrPaymentInvalidState},
+				{op: "void", wantErr: ErrPaymentInvalidState},
+			},
+		},
+		{
+			name: "captured payments can't be voided",
+			steps: []step{
+				{op: "authorize", amount: 1000},
+				{op: "capture", amount: 1000},
+				{op: "void", wantErr: ErrPaymentInvalidState},
+			},
+		},
+		{
+			name: "refund before capture",
+			steps: []step{
+				{op: "authorize", amount: 1000},
+				{op: "refund", amount: 100, wantErr: ErrPaymentInvalidState},
+			},
+		},
+		{
+			name:  "decline above limit",
+			limit: 5000,
+			steps: []step{
+				{op: "authorize", amount: 5001, wantErr: ErrPaymentDeclined},
+			},
+		},
+		{
+			name:  "accept at limit",
+			limit: 5000,
+			steps: []step{
+				{op: "authorize", amount: 5000},
+			},
+		},
+		{
+			name: "decline nothing to pay",
+			steps: []step{
+				{op: "authorize", amount: 0, wantErr: ErrPaymentDeclined},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			provider := NewFakePaymentProvider(models.MoneyFromCents(tt.limit))
+
+			var reference string
+			for i, s := range tt.steps {
+				amount := models.MoneyFromCents(s.amount)
+
+				var err error
+				switch s.op {
+				case "authorize":
+					reference, err = provider.Authorize(ctx, PaymentAuthorization{
+						OrderID:  "order",
+						Amount:   amount,
+						Currency: "RUB",
+					})
+				case "capture":
+					err = provider.Capture(ctx, reference, amount)
+				case "void":
+					err = provider.Void(ctx, reference)
+				case "refund":
+					err = provider.Refund(ctx, reference, amount)
+				}
+
+				if !errors.Is(err, s.wantErr) {
+					t.Fatalf("step %d %s: error = %v, want %v", i, s.op, err, s.wantErr)
+				}
+			}
+		})
+	}
+}
+
+func TestFakePaymentProviderReferences(t *testing.T) {
+	ctx := context.Background()
+	provider := NewFakePaymentProvider(models.Money{})
+
+	for _, want := range []string{"fake-000001", "fake-000002"} {
+		reference, err := provider.Authorize(ctx, PaymentAuthorization{Amount: models.MoneyFromCents(100)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if reference != want {
+			t.Errorf("reference = %q, want %q", reference, want)
+		}
+	}
+
+	err := provider.Capture(ctx, "fake-999999", models.MoneyFromCents(100))
+	if !errors.Is(err, ErrPaymentNotFound) {
+		t.Errorf("capture of unknown payment: error = %v, want ErrPaymentNotFound", err)
+	}
+}
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE order_status_history (
+                                      uuid UUID PRIMARY KEY,
+                                      order_uuid UUID NOT NULL REFERENCES orders(uuid) ON DELETE CASCADE,
+                                      from_status order_status NOT NULL,
+                                      to_status order_status NOT NULL,
+                                      actor varchar(24) NOT NULL,
+                                      reason TEXT,
+                                      changed_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX order_status_history_order_uuid_idx ON order_status_history (order_uuid, changed_at);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE order_status_history;
+
+-- +goose StatementEnd
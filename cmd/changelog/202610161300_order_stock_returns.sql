@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TYPE stock_return_status AS ENUM ('pending', 'in_progress', 'completed', 'failed');
+
+CREATE TABLE order_stock_returns (
+                                     order_uuid UUID PRIMARY KEY REFERENCES orders(uuid) ON DELETE CASCADE,
+                                     status stock_return_status NOT NULL DEFAULT 'pending',
+                                     attempts INTEGER NOT NULL DEFAULT 0,
+                                     last_error TEXT,
+                                     created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                                     updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE order_stock_returns;
+DROP TYPE stock_return_status;
+
+-- +goose StatementEnd
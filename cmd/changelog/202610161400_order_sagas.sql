@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TYPE saga_step AS ENUM ('started', 'stock_removed', 'order_persisted', 'compensated', 'failed');
+
+CREATE TABLE order_sagas (
+                             uuid UUID PRIMARY KEY,
+                             order_uuid UUID NOT NULL,
+                             step saga_step NOT NULL DEFAULT 'started',
+                             products JSONB NOT NULL,
+                             last_error TEXT,
+                             created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                             updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX order_sagas_unfinished_idx ON order_sagas (updated_at) WHERE step IN ('started', 'stock_removed');
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE order_sagas;
+DROP TYPE saga_step;
+
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE order_outbox (
+                              id BIGSERIAL PRIMARY KEY,
+                              event_uuid UUID NOT NULL UNIQUE,
+                              event_type varchar(64) NOT NULL,
+                              order_uuid UUID NOT NULL,
+                              payload JSONB NOT NULL,
+                              created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                              published_at TIMESTAMP,
+                              attempts INTEGER NOT NULL DEFAULT 0,
+                              last_error TEXT
+);
+
+CREATE INDEX order_outbox_pending_idx ON order_outbox (id) WHERE published_at IS NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE order_outbox;
+
+-- +goose StatementEnd
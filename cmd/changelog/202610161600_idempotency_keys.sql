@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE idempotency_keys (
+                                  scope varchar(64) NOT NULL,
+                                  key varchar(255) NOT NULL,
+                                  fingerprint varchar(64) NOT NULL,
+                                  response JSONB,
+                                  created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                                  expires_at TIMESTAMP NOT NULL,
+                                  PRIMARY KEY (scope, key)
+);
+
+CREATE INDEX idempotency_keys_expires_at_idx ON idempotency_keys (expires_at);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE idempotency_keys;
+
+-- +goose StatementEnd
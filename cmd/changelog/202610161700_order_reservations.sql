@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TYPE order_status ADD VALUE IF NOT EXISTS 'pending';
+
+CREATE TYPE reservation_status AS ENUM ('reserved', 'confirmed', 'released');
+
+CREATE TABLE order_reservations (
+                                    uuid UUID PRIMARY KEY,
+                                    order_uuid UUID NOT NULL REFERENCES orders(uuid) ON DELETE CASCADE,
+                                    product_code UUID NOT NULL,
+                                    amount INTEGER NOT NULL,
+                                    status reservation_status NOT NULL DEFAULT 'reserved',
+                                    stock_held BOOLEAN NOT NULL DEFAULT FALSE,
+                                    expires_at TIMESTAMP NOT NULL,
+                                    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                                    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX order_reservations_order_uuid_idx ON order_reservations (order_uuid);
+CREATE INDEX order_reservations_expires_at_idx ON order_reservations (expires_at) WHERE status = 'reserved';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+-- enum values cannot be dropped, 'pending' stays in order_status
+DROP TABLE order_reservations;
+DROP TYPE reservation_status;
+
+-- +goose StatementEnd
@@ -0,0 +1,58 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TYPE promotion_kind AS ENUM ('percentage', 'fixed', 'buy_x_get_y');
+CREATE TYPE promotion_scope AS ENUM ('order', 'product');
+
+CREATE TABLE promotions (
+                            uuid UUID PRIMARY KEY,
+                            code varchar(64) UNIQUE,
+                            name varchar(120) NOT NULL,
+                            kind promotion_kind NOT NULL,
+                            scope promotion_scope NOT NULL,
+                            product_code UUID,
+                            percent DECIMAL(5, 2),
+                            amount DECIMAL(10, 2),
+                            currency CHAR(3),
+                            buy_quantity INTEGER,
+                            free_quantity INTEGER,
+                            usage_limit INTEGER,
+                            usage_count INTEGER NOT NULL DEFAULT 0,
+                            valid_from TIMESTAMP,
+                            valid_to TIMESTAMP,
+                            active BOOLEAN NOT NULL DEFAULT TRUE,
+                            created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE orders
+    ADD COLUMN discount DECIMAL(10, 2) NOT NULL DEFAULT 0,
+    ADD COLUMN promotion_uuids UUID[] NOT NULL DEFAULT '{}';
+
+ALTER TABLE order_products
+    ADD COLUMN list_price DECIMAL(10, 2),
+    ADD COLUMN discount DECIMAL(10, 2) NOT NULL DEFAULT 0,
+    ADD COLUMN promotion_uuids UUID[] NOT NULL DEFAULT '{}';
+
+UPDATE order_products SET list_price = result_price;
+
+ALTER TABLE order_products ALTER COLUMN list_price SET NOT NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE order_products
+    DROP COLUMN promotion_uuids,
+    DROP COLUMN discount,
+    DROP COLUMN list_price;
+
+ALTER TABLE orders
+    DROP COLUMN promotion_uuids,
+    DROP COLUMN discount;
+
+DROP TABLE promotions;
+DROP TYPE promotion_scope;
+DROP TYPE promotion_kind;
+
+-- +goose StatementEnd
@@ -0,0 +1,49 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE product
+    ADD COLUMN tax_class varchar(32) NOT NULL DEFAULT 'standard';
+
+CREATE TABLE tax_rules (
+                           uuid UUID PRIMARY KEY,
+                           tax_class varchar(32) NOT NULL,
+                           region varchar(16) NOT NULL DEFAULT '',
+                           rate DECIMAL(5, 2) NOT NULL,
+                           inclusive BOOLEAN NOT NULL DEFAULT FALSE,
+                           created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                           UNIQUE (tax_class, region)
+);
+
+ALTER TABLE orders
+    ADD COLUMN region varchar(16) NOT NULL DEFAULT '',
+    ADD COLUMN net_amount DECIMAL(10, 2) NOT NULL DEFAULT 0,
+    ADD COLUMN tax_amount DECIMAL(10, 2) NOT NULL DEFAULT 0;
+
+UPDATE orders SET net_amount = order_cost;
+
+ALTER TABLE order_products
+    ADD COLUMN tax_rate DECIMAL(5, 2) NOT NULL DEFAULT 0,
+    ADD COLUMN tax_amount DECIMAL(10, 2) NOT NULL DEFAULT 0,
+    ADD COLUMN tax_inclusive BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE order_products
+    DROP COLUMN tax_inclusive,
+    DROP COLUMN tax_amount,
+    DROP COLUMN tax_rate;
+
+ALTER TABLE orders
+    DROP COLUMN tax_amount,
+    DROP COLUMN net_amount,
+    DROP COLUMN region;
+
+DROP TABLE tax_rules;
+
+ALTER TABLE product
+    DROP COLUMN tax_class;
+
+-- +goose StatementEnd
@@ -0,0 +1,37 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE product_price_history (
+                                       uuid UUID PRIMARY KEY,
+                                       product_uuid UUID NOT NULL REFERENCES product(uuid) ON DELETE CASCADE,
+                                       customer_cost DECIMAL(10, 2) NOT NULL,
+                                       currency CHAR(3) NOT NULL,
+                                       effective_from TIMESTAMP NOT NULL DEFAULT NOW(),
+                                       effective_to TIMESTAMP,
+                                       changed_by varchar(64) NOT NULL DEFAULT '',
+                                       reason TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX product_price_history_product_idx ON product_price_history (product_uuid, effective_from);
+
+-- only one price of a product is in effect
+CREATE UNIQUE INDEX product_price_history_current_idx ON product_price_history (product_uuid) WHERE effective_to IS NULL;
+
+-- the price history of existing products starts now
+INSERT INTO product_price_history (uuid, product_uuid, customer_cost, currency, reason)
+SELECT gen_random_uuid(), uuid, customer_cost, currency, 'initial price' FROM product;
+
+ALTER TABLE order_products
+    ADD COLUMN price_history_uuid UUID REFERENCES product_price_history(uuid) ON DELETE SET NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE order_products
+    DROP COLUMN price_history_uuid;
+
+DROP TABLE product_price_history;
+
+-- +goose StatementEnd
@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TYPE scheduled_price_status AS ENUM ('pending', 'applied', 'cancelled', 'failed');
+
+CREATE TABLE scheduled_price_changes (
+                                         uuid UUID PRIMARY KEY,
+                                         product_uuid UUID NOT NULL REFERENCES product(uuid) ON DELETE CASCADE,
+                                         -- a revert has no price of its own, it restores the price replaced by revert_of
+                                         customer_cost DECIMAL(10, 2),
+                                         currency CHAR(3),
+                                         revert_of UUID REFERENCES scheduled_price_changes(uuid) ON DELETE CASCADE,
+                                         run_at TIMESTAMP NOT NULL,
+                                         status scheduled_price_status NOT NULL DEFAULT 'pending',
+                                         previous_cost DECIMAL(10, 2),
+                                         previous_currency CHAR(3),
+                                         created_by varchar(64) NOT NULL DEFAULT '',
+                                         reason TEXT NOT NULL DEFAULT '',
+                                         error TEXT NOT NULL DEFAULT '',
+                                         created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                                         applied_at TIMESTAMP
+);
+
+CREATE INDEX scheduled_price_changes_due_idx ON scheduled_price_changes (run_at) WHERE status = 'pending';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE scheduled_price_changes;
+DROP TYPE scheduled_price_status;
+
+-- +goose StatementEnd
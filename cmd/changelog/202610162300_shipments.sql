@@ -0,0 +1,43 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TYPE order_status ADD VALUE IF NOT EXISTS 'partially_shipped';
+ALTER TYPE order_status ADD VALUE IF NOT EXISTS 'shipped';
+
+CREATE TYPE shipment_status AS ENUM ('pending', 'shipped', 'delivered', 'cancelled');
+
+CREATE TABLE shipments (
+                           uuid UUID PRIMARY KEY,
+                           order_uuid UUID NOT NULL REFERENCES orders(uuid) ON DELETE CASCADE,
+                           carrier varchar(64) NOT NULL DEFAULT '',
+                           tracking_number varchar(128) NOT NULL DEFAULT '',
+                           status shipment_status NOT NULL DEFAULT 'pending',
+                           created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                           shipped_at TIMESTAMP,
+                           delivered_at TIMESTAMP
+);
+
+CREATE INDEX shipments_order_uuid_idx ON shipments (order_uuid);
+
+CREATE TABLE shipment_lines (
+                                shipment_uuid UUID NOT NULL REFERENCES shipments(uuid) ON DELETE CASCADE,
+                                product_uuid UUID NOT NULL,
+                                order_uuid UUID NOT NULL,
+                                amount INTEGER NOT NULL CHECK (amount > 0),
+                                PRIMARY KEY (shipment_uuid, product_uuid),
+                                FOREIGN KEY (product_uuid, order_uuid) REFERENCES order_products(product_uuid, order_uuid) ON DELETE CASCADE
+);
+
+CREATE INDEX shipment_lines_order_uuid_idx ON shipment_lines (order_uuid);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+-- enum values cannot be dropped, 'partially_shipped' and 'shipped' stay in order_status
+DROP TABLE shipment_lines;
+DROP TABLE shipments;
+DROP TYPE shipment_status;
+
+-- +goose StatementEnd
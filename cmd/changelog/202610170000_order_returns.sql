@@ -0,0 +1,44 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TYPE return_status AS ENUM ('requested', 'approved', 'rejected', 'received', 'refunded');
+
+CREATE TABLE order_returns (
+                               uuid UUID PRIMARY KEY,
+                               order_uuid UUID NOT NULL REFERENCES orders(uuid) ON DELETE CASCADE,
+                               status return_status NOT NULL DEFAULT 'requested',
+                               refund_amount DECIMAL(10, 2) NOT NULL,
+                               currency CHAR(3) NOT NULL,
+                               -- set while the products are written back to SMS and after they were
+                               stock_returned BOOLEAN NOT NULL DEFAULT FALSE,
+                               created_by varchar(64) NOT NULL DEFAULT '',
+                               reason TEXT NOT NULL DEFAULT '',
+                               created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                               updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                               refunded_at TIMESTAMP
+);
+
+CREATE INDEX order_returns_order_uuid_idx ON order_returns (order_uuid);
+
+CREATE TABLE order_return_lines (
+                                    return_uuid UUID NOT NULL REFERENCES order_returns(uuid) ON DELETE CASCADE,
+                                    product_uuid UUID NOT NULL,
+                                    order_uuid UUID NOT NULL,
+                                    amount INTEGER NOT NULL CHECK (amount > 0),
+                                    refund_amount DECIMAL(10, 2) NOT NULL,
+                                    PRIMARY KEY (return_uuid, product_uuid),
+                                    FOREIGN KEY (product_uuid, order_uuid) REFERENCES order_products(product_uuid, order_uuid) ON DELETE CASCADE
+);
+
+CREATE INDEX order_return_lines_order_uuid_idx ON order_return_lines (order_uuid);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE order_return_lines;
+DROP TABLE order_returns;
+DROP TYPE return_status;
+
+-- +goose StatementEnd
@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TYPE payment_status AS ENUM ('authorized', 'captured', 'voided', 'refunded', 'failed');
+
+CREATE TABLE payments (
+                          uuid UUID PRIMARY KEY,
+                          order_uuid UUID NOT NULL REFERENCES orders(uuid) ON DELETE CASCADE,
+                          provider varchar(32) NOT NULL,
+                          reference varchar(128) NOT NULL DEFAULT '',
+                          status payment_status NOT NULL,
+                          amount DECIMAL(10, 2) NOT NULL,
+                          currency CHAR(3) NOT NULL,
+                          captured_amount DECIMAL(10, 2) NOT NULL DEFAULT 0,
+                          refunded_amount DECIMAL(10, 2) NOT NULL DEFAULT 0,
+                          error TEXT NOT NULL DEFAULT '',
+                          created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                          updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX payments_order_uuid_idx ON payments (order_uuid, created_at);
+-- an order has at most one payment holding or having taken money
+CREATE UNIQUE INDEX payments_order_active_idx ON payments (order_uuid) WHERE status IN ('authorized', 'captured', 'refunded');
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE payments;
+DROP TYPE payment_status;
+
+-- +goose StatementEnd
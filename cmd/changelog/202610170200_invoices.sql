@@ -0,0 +1,39 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- invoice numbers are taken from a single counter row so they have no gaps
+CREATE TABLE invoice_numbers (
+                                 id BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (id),
+                                 last_number BIGINT NOT NULL
+);
+
+INSERT INTO invoice_numbers (last_number) VALUES (0);
+
+CREATE TABLE invoices (
+                          uuid UUID PRIMARY KEY,
+                          order_uuid UUID NOT NULL UNIQUE REFERENCES orders(uuid) ON DELETE RESTRICT,
+                          number BIGINT NOT NULL UNIQUE,
+                          snapshot JSONB NOT NULL,
+                          issued_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE FUNCTION invoices_immutable() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'invoices can not be changed';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER invoices_immutable
+    BEFORE UPDATE OR DELETE ON invoices
+    FOR EACH ROW EXECUTE FUNCTION invoices_immutable();
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE invoices;
+DROP FUNCTION invoices_immutable;
+DROP TABLE invoice_numbers;
+
+-- +goose StatementEnd
@@ -0,0 +1,55 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- order changes are announced on the order_changes channel once they are
+-- committed, the payload has the shape of an order event
+CREATE FUNCTION notify_order_change() RETURNS TRIGGER AS $$
+DECLARE
+    changed orders%ROWTYPE;
+    event_type TEXT;
+    previous_status order_status;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        changed := OLD;
+        event_type := 'OrderDeleted';
+    ELSIF TG_OP = 'INSERT' THEN
+        changed := NEW;
+        event_type := 'OrderCreated';
+    ELSE
+        IF OLD IS NOT DISTINCT FROM NEW THEN
+            RETURN NULL;
+        END IF;
+        changed := NEW;
+        event_type := 'OrderUpdated';
+        IF OLD.status <> NEW.status THEN
+            previous_status := OLD.status;
+        END IF;
+    END IF;
+
+    PERFORM pg_notify('order_changes', json_build_object(
+        'type', event_type,
+        'order_id', changed.uuid,
+        'user_id', changed.user_id,
+        'staff_id', changed.staff_id,
+        'status', changed.status,
+        'previous_status', previous_status,
+        'occurred_at', NOW()
+    )::text);
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER orders_notify_change
+    AFTER INSERT OR UPDATE OR DELETE ON orders
+    FOR EACH ROW EXECUTE FUNCTION notify_order_change();
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TRIGGER orders_notify_change ON orders;
+DROP FUNCTION notify_order_change;
+
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- user and staff ids are token subjects now and no longer fit 24 characters
+ALTER TABLE orders
+    ALTER COLUMN user_id TYPE varchar(64),
+    ALTER COLUMN staff_id TYPE varchar(64);
+
+ALTER TABLE order_status_history
+    ALTER COLUMN actor TYPE varchar(64);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE order_status_history
+    ALTER COLUMN actor TYPE varchar(24);
+
+ALTER TABLE orders
+    ALTER COLUMN user_id TYPE varchar(24),
+    ALTER COLUMN staff_id TYPE varchar(24);
+
+-- +goose StatementEnd
@@ -0,0 +1,175 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- every shop is a tenant, rows written before tenants existed belong to the
+-- default one. New rows must name their tenant.
+ALTER TABLE product
+    ADD COLUMN tenant_id varchar(64) NOT NULL DEFAULT 'default';
+ALTER TABLE orders
+    ADD COLUMN tenant_id varchar(64) NOT NULL DEFAULT 'default';
+ALTER TABLE order_products
+    ADD COLUMN tenant_id varchar(64) NOT NULL DEFAULT 'default';
+
+ALTER TABLE product ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE orders ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE order_products ALTER COLUMN tenant_id DROP DEFAULT;
+
+CREATE INDEX product_tenant_idx ON product (tenant_id, name);
+CREATE INDEX orders_tenant_idx ON orders (tenant_id, creation_date DESC);
+
+-- order lines belong to the shop of their order and of their product
+ALTER TABLE product
+    ADD CONSTRAINT product_tenant_key UNIQUE (uuid, tenant_id);
+ALTER TABLE orders
+    ADD CONSTRAINT orders_tenant_key UNIQUE (uuid, tenant_id);
+ALTER TABLE order_products
+    ADD CONSTRAINT order_products_order_tenant_fkey
+        FOREIGN KEY (order_uuid, tenant_id) REFERENCES orders (uuid, tenant_id),
+    ADD CONSTRAINT order_products_product_tenant_fkey
+        FOREIGN KEY (product_uuid, tenant_id) REFERENCES product (uuid, tenant_id);
+
+-- app.tenant_id is set on every connection to the tenant of the caller, '*'
+-- is set for internal workers and sees every shop. Connections without it see
+-- nothing. Superusers and BYPASSRLS roles are never restricted, the service
+-- must not connect as one.
+CREATE FUNCTION tenant_visible(tenant varchar) RETURNS boolean AS $$
+    SELECT current_setting('app.tenant_id', true) IN (tenant, '*')
+$$ LANGUAGE sql STABLE;
+
+ALTER TABLE product ENABLE ROW LEVEL SECURITY;
+ALTER TABLE product FORCE ROW LEVEL SECURITY;
+CREATE POLICY product_tenant ON product
+    USING (tenant_visible(tenant_id))
+    WITH CHECK (tenant_visible(tenant_id) AND tenant_id <> '*');
+
+ALTER TABLE orders ENABLE ROW LEVEL SECURITY;
+ALTER TABLE orders FORCE ROW LEVEL SECURITY;
+CREATE POLICY orders_tenant ON orders
+    USING (tenant_visible(tenant_id))
+    WITH CHECK (tenant_visible(tenant_id) AND tenant_id <> '*');
+
+ALTER TABLE order_products ENABLE ROW LEVEL SECURITY;
+ALTER TABLE order_products FORCE ROW LEVEL SECURITY;
+CREATE POLICY order_products_tenant ON order_products
+    USING (tenant_visible(tenant_id))
+    WITH CHECK (tenant_visible(tenant_id) AND tenant_id <> '*');
+
+-- idempotency scopes are prefixed with the tenant so shops can't replay the
+-- responses of each other
+ALTER TABLE idempotency_keys
+    ALTER COLUMN scope TYPE varchar(160);
+
+-- watchers only get the changes of their own shop
+CREATE OR REPLACE FUNCTION notify_order_change() RETURNS TRIGGER AS $$
+DECLARE
+    changed orders%ROWTYPE;
+    event_type TEXT;
+    previous_status order_status;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        changed := OLD;
+        event_type := 'OrderDeleted';
+    ELSIF TG_OP = 'INSERT' THEN
+        changed := NEW;
+        event_type := 'OrderCreated';
+    ELSE
+        IF OLD IS NOT DISTINCT FROM NEW THEN
+            RETURN NULL;
+        END IF;
+        changed := NEW;
+        event_type := 'OrderUpdated';
+        IF OLD.status <> NEW.status THEN
+            previous_status := OLD.status;
+        END IF;
+    END IF;
+
+    PERFORM pg_notify('order_changes', json_build_object(
+        'type', event_type,
+        'tenant_id', changed.tenant_id,
+        'order_id', changed.uuid,
+        'user_id', changed.user_id,
+        'staff_id', changed.staff_id,
+        'status', changed.status,
+        'previous_status', previous_status,
+        'occurred_at', NOW()
+    )::text);
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+CREATE OR REPLACE FUNCTION notify_order_change() RETURNS TRIGGER AS $$
+DECLARE
+    changed orders%ROWTYPE;
+    event_type TEXT;
+    previous_status order_status;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        changed := OLD;
+        event_type := 'OrderDeleted';
+    ELSIF TG_OP = 'INSERT' THEN
+        changed := NEW;
+        event_type := 'OrderCreated';
+    ELSE
+        IF OLD IS NOT DISTINCT FROM NEW THEN
+            RETURN NULL;
+        END IF;
+        changed := NEW;
+        event_type := 'OrderUpdated';
+        IF OLD.status <> NEW.status THEN
+            previous_status := OLD.status;
+        END IF;
+    END IF;
+
+    PERFORM pg_notify('order_changes', json_build_object(
+        'type', event_type,
+        'order_id', changed.uuid,
+        'user_id', changed.user_id,
+        'staff_id', changed.staff_id,
+        'status', changed.status,
+        'previous_status', previous_status,
+        'occurred_at', NOW()
+    )::text);
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+ALTER TABLE idempotency_keys
+    ALTER COLUMN scope TYPE varchar(64);
+
+DROP POLICY order_products_tenant ON order_products;
+ALTER TABLE order_products NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE order_products DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY orders_tenant ON orders;
+ALTER TABLE orders NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE orders DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY product_tenant ON product;
+ALTER TABLE product NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE product DISABLE ROW LEVEL SECURITY;
+
+DROP FUNCTION tenant_visible;
+
+ALTER TABLE order_products
+    DROP CONSTRAINT order_products_product_tenant_fkey,
+    DROP CONSTRAINT order_products_order_tenant_fkey;
+ALTER TABLE orders
+    DROP CONSTRAINT orders_tenant_key;
+ALTER TABLE product
+    DROP CONSTRAINT product_tenant_key;
+
+DROP INDEX orders_tenant_idx;
+DROP INDEX product_tenant_idx;
+
+ALTER TABLE order_products DROP COLUMN tenant_id;
+ALTER TABLE orders DROP COLUMN tenant_id;
+ALTER TABLE product DROP COLUMN tenant_id;
+
+-- +goose StatementEnd
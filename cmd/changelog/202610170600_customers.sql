@@ -0,0 +1,70 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE customers (
+                           uuid UUID PRIMARY KEY,
+                           tenant_id varchar(64) NOT NULL,
+                           -- user_id is the id orders are placed for
+                           user_id varchar(64) NOT NULL,
+                           name varchar(120) NOT NULL DEFAULT '',
+                           email varchar(254) NOT NULL DEFAULT '',
+                           phone varchar(32) NOT NULL DEFAULT '',
+                           created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                           updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                           UNIQUE (tenant_id, user_id)
+);
+
+CREATE TABLE customer_addresses (
+                                    uuid UUID PRIMARY KEY,
+                                    customer_uuid UUID NOT NULL REFERENCES customers(uuid) ON DELETE CASCADE,
+                                    label varchar(64) NOT NULL DEFAULT '',
+                                    recipient varchar(120) NOT NULL,
+                                    line1 varchar(200) NOT NULL,
+                                    line2 varchar(200) NOT NULL DEFAULT '',
+                                    city varchar(100) NOT NULL,
+                                    region varchar(100) NOT NULL DEFAULT '',
+                                    postal_code varchar(20) NOT NULL DEFAULT '',
+                                    country CHAR(2) NOT NULL,
+                                    phone varchar(32) NOT NULL DEFAULT '',
+                                    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX customer_addresses_customer_idx ON customer_addresses (customer_uuid);
+
+ALTER TABLE customers
+    ADD COLUMN default_shipping_address_uuid UUID REFERENCES customer_addresses(uuid) ON DELETE SET NULL;
+
+-- everyone orders were placed for becomes a customer, the backfill has to see
+-- the orders of every shop
+SELECT set_config('app.tenant_id', '*', true);
+
+INSERT INTO customers (uuid, tenant_id, user_id)
+SELECT uuid_generate_v4(), tenant_id, user_id
+FROM orders
+GROUP BY tenant_id, user_id;
+
+ALTER TABLE orders
+    ADD CONSTRAINT orders_customer_fkey
+        FOREIGN KEY (tenant_id, user_id) REFERENCES customers (tenant_id, user_id);
+
+CREATE INDEX orders_customer_idx ON orders (tenant_id, user_id, creation_date DESC);
+
+ALTER TABLE customers ENABLE ROW LEVEL SECURITY;
+ALTER TABLE customers FORCE ROW LEVEL SECURITY;
+CREATE POLICY customers_tenant ON customers
+    USING (tenant_visible(tenant_id))
+    WITH CHECK (tenant_visible(tenant_id) AND tenant_id <> '*');
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX orders_customer_idx;
+ALTER TABLE orders DROP CONSTRAINT orders_customer_fkey;
+
+ALTER TABLE customers DROP COLUMN default_shipping_address_uuid;
+DROP TABLE customer_addresses;
+DROP TABLE customers;
+
+-- +goose StatementEnd
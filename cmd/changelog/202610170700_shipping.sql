@@ -0,0 +1,63 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- flat costs the same for every order, weight adds cost_per_kg for every
+-- kilogram of the order products and free_over is free from free_threshold on
+CREATE TYPE shipping_rule AS ENUM ('flat', 'weight', 'free_over');
+
+CREATE TABLE shipping_methods (
+                                  uuid UUID PRIMARY KEY,
+                                  tenant_id varchar(64) NOT NULL,
+                                  code varchar(32) NOT NULL,
+                                  name varchar(80) NOT NULL,
+                                  rule shipping_rule NOT NULL,
+                                  currency CHAR(3) NOT NULL,
+                                  cost DECIMAL(10, 2) NOT NULL DEFAULT 0 CHECK (cost >= 0),
+                                  cost_per_kg DECIMAL(10, 2) CHECK (cost_per_kg >= 0),
+                                  free_threshold DECIMAL(10, 2) CHECK (free_threshold > 0),
+                                  active BOOLEAN NOT NULL DEFAULT TRUE,
+                                  created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                                  UNIQUE (tenant_id, code),
+                                  UNIQUE (uuid, tenant_id),
+                                  CHECK (rule <> 'weight' OR cost_per_kg IS NOT NULL),
+                                  CHECK (rule <> 'free_over' OR free_threshold IS NOT NULL)
+);
+
+ALTER TABLE shipping_methods ENABLE ROW LEVEL SECURITY;
+ALTER TABLE shipping_methods FORCE ROW LEVEL SECURITY;
+CREATE POLICY shipping_methods_tenant ON shipping_methods
+    USING (tenant_visible(tenant_id))
+    WITH CHECK (tenant_visible(tenant_id) AND tenant_id <> '*');
+
+-- weight based shipping needs the weight of the products
+ALTER TABLE product
+    ADD COLUMN weight_grams INTEGER NOT NULL DEFAULT 0 CHECK (weight_grams >= 0);
+
+-- addresses are copied into the order as JSON, later changes of the customer
+-- addresses don't move placed orders. shipping_cost is part of order_cost.
+ALTER TABLE orders
+    ADD COLUMN shipping_method_uuid UUID,
+    ADD COLUMN shipping_cost DECIMAL(10, 2) NOT NULL DEFAULT 0,
+    ADD COLUMN shipping_address JSONB,
+    ADD COLUMN billing_address JSONB,
+    ADD CONSTRAINT orders_shipping_method_fkey
+        FOREIGN KEY (shipping_method_uuid, tenant_id) REFERENCES shipping_methods (uuid, tenant_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE orders
+    DROP CONSTRAINT orders_shipping_method_fkey,
+    DROP COLUMN billing_address,
+    DROP COLUMN shipping_address,
+    DROP COLUMN shipping_cost,
+    DROP COLUMN shipping_method_uuid;
+
+ALTER TABLE product DROP COLUMN weight_grams;
+
+DROP TABLE shipping_methods;
+DROP TYPE shipping_rule;
+
+-- +goose StatementEnd
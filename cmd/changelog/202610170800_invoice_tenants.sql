@@ -0,0 +1,78 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- invoices belong to the shop of their order, the backfill has to see the
+-- orders of every shop
+SELECT set_config('app.tenant_id', '*', true);
+
+ALTER TABLE invoices
+    ADD COLUMN tenant_id varchar(64);
+
+UPDATE invoices
+SET tenant_id = orders.tenant_id
+FROM orders
+WHERE orders.uuid = invoices.order_uuid;
+
+ALTER TABLE invoices
+    ALTER COLUMN tenant_id SET NOT NULL,
+    ADD CONSTRAINT invoices_order_tenant_fkey
+        FOREIGN KEY (order_uuid, tenant_id) REFERENCES orders (uuid, tenant_id),
+    DROP CONSTRAINT invoices_number_key,
+    ADD CONSTRAINT invoices_tenant_number_key UNIQUE (tenant_id, number);
+
+-- every shop numbers its invoices on its own counter. Shops continue after
+-- the highest number they were given by the shared counter.
+DROP TABLE invoice_numbers;
+
+CREATE TABLE invoice_numbers (
+                                 tenant_id varchar(64) PRIMARY KEY,
+                                 last_number BIGINT NOT NULL
+);
+
+INSERT INTO invoice_numbers (tenant_id, last_number)
+SELECT tenant_id, MAX(number)
+FROM invoices
+GROUP BY tenant_id;
+
+ALTER TABLE invoices ENABLE ROW LEVEL SECURITY;
+ALTER TABLE invoices FORCE ROW LEVEL SECURITY;
+CREATE POLICY invoices_tenant ON invoices
+    USING (tenant_visible(tenant_id))
+    WITH CHECK (tenant_visible(tenant_id) AND tenant_id <> '*');
+
+ALTER TABLE invoice_numbers ENABLE ROW LEVEL SECURITY;
+ALTER TABLE invoice_numbers FORCE ROW LEVEL SECURITY;
+CREATE POLICY invoice_numbers_tenant ON invoice_numbers
+    USING (tenant_visible(tenant_id))
+    WITH CHECK (tenant_visible(tenant_id) AND tenant_id <> '*');
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+-- numbers are only unique per shop now, going back fails while two shops
+-- share one
+SELECT set_config('app.tenant_id', '*', true);
+
+DROP POLICY invoices_tenant ON invoices;
+ALTER TABLE invoices NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE invoices DISABLE ROW LEVEL SECURITY;
+
+DROP TABLE invoice_numbers;
+
+CREATE TABLE invoice_numbers (
+                                 id BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (id),
+                                 last_number BIGINT NOT NULL
+);
+
+INSERT INTO invoice_numbers (last_number)
+SELECT COALESCE(MAX(number), 0) FROM invoices;
+
+ALTER TABLE invoices
+    DROP CONSTRAINT invoices_tenant_number_key,
+    ADD CONSTRAINT invoices_number_key UNIQUE (number),
+    DROP CONSTRAINT invoices_order_tenant_fkey,
+    DROP COLUMN tenant_id;
+
+-- +goose StatementEnd
@@ -0,0 +1,41 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- promotions belong to the shop that created them, promotions created before
+-- belong to the default one. Coupon codes are unique per shop.
+ALTER TABLE promotions
+    ADD COLUMN tenant_id varchar(64) NOT NULL DEFAULT 'default';
+
+ALTER TABLE promotions ALTER COLUMN tenant_id DROP DEFAULT;
+
+ALTER TABLE promotions
+    DROP CONSTRAINT promotions_code_key,
+    ADD CONSTRAINT promotions_tenant_code_key UNIQUE (tenant_id, code);
+
+CREATE INDEX promotions_tenant_idx ON promotions (tenant_id, created_at DESC);
+
+ALTER TABLE promotions ENABLE ROW LEVEL SECURITY;
+ALTER TABLE promotions FORCE ROW LEVEL SECURITY;
+CREATE POLICY promotions_tenant ON promotions
+    USING (tenant_visible(tenant_id))
+    WITH CHECK (tenant_visible(tenant_id) AND tenant_id <> '*');
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+-- codes are only unique per shop now, going back fails while two shops
+-- share one
+DROP POLICY promotions_tenant ON promotions;
+ALTER TABLE promotions NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE promotions DISABLE ROW LEVEL SECURITY;
+
+DROP INDEX promotions_tenant_idx;
+
+ALTER TABLE promotions
+    DROP CONSTRAINT promotions_tenant_code_key,
+    ADD CONSTRAINT promotions_code_key UNIQUE (code),
+    DROP COLUMN tenant_id;
+
+-- +goose StatementEnd
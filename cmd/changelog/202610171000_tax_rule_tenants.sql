@@ -0,0 +1,37 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- every shop sets its own tax rules, rules created before belong to the
+-- default one
+ALTER TABLE tax_rules
+    ADD COLUMN tenant_id varchar(64) NOT NULL DEFAULT 'default';
+
+ALTER TABLE tax_rules ALTER COLUMN tenant_id DROP DEFAULT;
+
+ALTER TABLE tax_rules
+    DROP CONSTRAINT tax_rules_tax_class_region_key,
+    ADD CONSTRAINT tax_rules_tenant_class_region_key UNIQUE (tenant_id, tax_class, region);
+
+ALTER TABLE tax_rules ENABLE ROW LEVEL SECURITY;
+ALTER TABLE tax_rules FORCE ROW LEVEL SECURITY;
+CREATE POLICY tax_rules_tenant ON tax_rules
+    USING (tenant_visible(tenant_id))
+    WITH CHECK (tenant_visible(tenant_id) AND tenant_id <> '*');
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+-- rules are only unique per shop now, going back fails while two shops have
+-- a rule for the same class and region
+DROP POLICY tax_rules_tenant ON tax_rules;
+ALTER TABLE tax_rules NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE tax_rules DISABLE ROW LEVEL SECURITY;
+
+ALTER TABLE tax_rules
+    DROP CONSTRAINT tax_rules_tenant_class_region_key,
+    ADD CONSTRAINT tax_rules_tax_class_region_key UNIQUE (tax_class, region),
+    DROP COLUMN tenant_id;
+
+-- +goose StatementEnd
@@ -0,0 +1,154 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- sagas start before their order is stored and outbox events outlive deleted
+-- orders, so both carry the tenant themselves. The backfill has to see the
+-- orders of every shop, sagas whose order was never stored belong to the
+-- default one.
+SELECT set_config('app.tenant_id', '*', true);
+
+ALTER TABLE order_sagas
+    ADD COLUMN tenant_id varchar(64);
+ALTER TABLE order_outbox
+    ADD COLUMN tenant_id varchar(64);
+
+UPDATE order_sagas
+SET tenant_id = COALESCE((SELECT o.tenant_id FROM orders o WHERE o.uuid = order_sagas.order_uuid), 'default');
+
+UPDATE order_outbox
+SET tenant_id = COALESCE(payload->>'tenant_id', 'default');
+
+ALTER TABLE order_sagas ALTER COLUMN tenant_id SET NOT NULL;
+ALTER TABLE order_outbox ALTER COLUMN tenant_id SET NOT NULL;
+
+ALTER TABLE order_sagas ENABLE ROW LEVEL SECURITY;
+ALTER TABLE order_sagas FORCE ROW LEVEL SECURITY;
+CREATE POLICY order_sagas_tenant ON order_sagas
+    USING (tenant_visible(tenant_id))
+    WITH CHECK (tenant_visible(tenant_id) AND tenant_id <> '*');
+
+ALTER TABLE order_outbox ENABLE ROW LEVEL SECURITY;
+ALTER TABLE order_outbox FORCE ROW LEVEL SECURITY;
+CREATE POLICY order_outbox_tenant ON order_outbox
+    USING (tenant_visible(tenant_id))
+    WITH CHECK (tenant_visible(tenant_id) AND tenant_id <> '*');
+
+-- the other rows of an order or a product are visible with their parent
+CREATE FUNCTION order_visible(order_id uuid) RETURNS boolean AS $$
+    SELECT EXISTS (SELECT 1 FROM orders WHERE uuid = order_id AND tenant_visible(tenant_id))
+$$ LANGUAGE sql STABLE;
+
+CREATE FUNCTION product_visible(product_id uuid) RETURNS boolean AS $$
+    SELECT EXISTS (SELECT 1 FROM product WHERE uuid = product_id AND tenant_visible(tenant_id))
+$$ LANGUAGE sql STABLE;
+
+ALTER TABLE order_status_history ENABLE ROW LEVEL SECURITY;
+ALTER TABLE order_status_history FORCE ROW LEVEL SECURITY;
+CREATE POLICY order_status_history_tenant ON order_status_history
+    USING (order_visible(order_uuid));
+
+ALTER TABLE order_stock_returns ENABLE ROW LEVEL SECURITY;
+ALTER TABLE order_stock_returns FORCE ROW LEVEL SECURITY;
+CREATE POLICY order_stock_returns_tenant ON order_stock_returns
+    USING (order_visible(order_uuid));
+
+ALTER TABLE order_reservations ENABLE ROW LEVEL SECURITY;
+ALTER TABLE order_reservations FORCE ROW LEVEL SECURITY;
+CREATE POLICY order_reservations_tenant ON order_reservations
+    USING (order_visible(order_uuid));
+
+ALTER TABLE shipments ENABLE ROW LEVEL SECURITY;
+ALTER TABLE shipments FORCE ROW LEVEL SECURITY;
+CREATE POLICY shipments_tenant ON shipments
+    USING (order_visible(order_uuid));
+
+ALTER TABLE shipment_lines ENABLE ROW LEVEL SECURITY;
+ALTER TABLE shipment_lines FORCE ROW LEVEL SECURITY;
+CREATE POLICY shipment_lines_tenant ON shipment_lines
+    USING (order_visible(order_uuid));
+
+ALTER TABLE order_returns ENABLE ROW LEVEL SECURITY;
+ALTER TABLE order_returns FORCE ROW LEVEL SECURITY;
+CREATE POLICY order_returns_tenant ON order_returns
+    USING (order_visible(order_uuid));
+
+ALTER TABLE order_return_lines ENABLE ROW LEVEL SECURITY;
+ALTER TABLE order_return_lines FORCE ROW LEVEL SECURITY;
+CREATE POLICY order_return_lines_tenant ON order_return_lines
+    USING (order_visible(order_uuid));
+
+ALTER TABLE payments ENABLE ROW LEVEL SECURITY;
+ALTER TABLE payments FORCE ROW LEVEL SECURITY;
+CREATE POLICY payments_tenant ON payments
+    USING (order_visible(order_uuid));
+
+ALTER TABLE product_price_history ENABLE ROW LEVEL SECURITY;
+ALTER TABLE product_price_history FORCE ROW LEVEL SECURITY;
+CREATE POLICY product_price_history_tenant ON product_price_history
+    USING (product_visible(product_uuid));
+
+ALTER TABLE scheduled_price_changes ENABLE ROW LEVEL SECURITY;
+ALTER TABLE scheduled_price_changes FORCE ROW LEVEL SECURITY;
+CREATE POLICY scheduled_price_changes_tenant ON scheduled_price_changes
+    USING (product_visible(product_uuid));
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP POLICY scheduled_price_changes_tenant ON scheduled_price_changes;
+ALTER TABLE scheduled_price_changes NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE scheduled_price_changes DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY product_price_history_tenant ON product_price_history;
+ALTER TABLE product_price_history NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE product_price_history DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY payments_tenant ON payments;
+ALTER TABLE payments NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE payments DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY order_return_lines_tenant ON order_return_lines;
+ALTER TABLE order_return_lines NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE order_return_lines DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY order_returns_tenant ON order_returns;
+ALTER TABLE order_returns NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE order_returns DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY shipment_lines_tenant ON shipment_lines;
+ALTER TABLE shipment_lines NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE shipment_lines DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY shipments_tenant ON shipments;
+ALTER TABLE shipments NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE shipments DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY order_reservations_tenant ON order_reservations;
+ALTER TABLE order_reservations NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE order_reservations DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY order_stock_returns_tenant ON order_stock_returns;
+ALTER TABLE order_stock_returns NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE order_stock_returns DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY order_status_history_tenant ON order_status_history;
+ALTER TABLE order_status_history NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE order_status_history DISABLE ROW LEVEL SECURITY;
+
+DROP FUNCTION product_visible;
+DROP FUNCTION order_visible;
+
+DROP POLICY order_outbox_tenant ON order_outbox;
+ALTER TABLE order_outbox NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE order_outbox DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY order_sagas_tenant ON order_sagas;
+ALTER TABLE order_sagas NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE order_sagas DISABLE ROW LEVEL SECURITY;
+
+ALTER TABLE order_outbox DROP COLUMN tenant_id;
+ALTER TABLE order_sagas DROP COLUMN tenant_id;
+
+-- +goose StatementEnd
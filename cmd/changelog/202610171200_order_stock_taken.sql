@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- stock_taken is set when SMS wrote the products of the order off, only
+-- those orders get their products back on cancel. Orders stored by a saga
+-- or held by TCC took stock, the backfill has to see every shop.
+SELECT set_config('app.tenant_id', '*', true);
+
+ALTER TABLE orders
+    ADD COLUMN stock_taken BOOLEAN NOT NULL DEFAULT FALSE;
+
+UPDATE orders
+SET stock_taken = TRUE
+WHERE uuid IN (SELECT order_uuid FROM order_sagas WHERE step = 'order_persisted')
+   OR uuid IN (SELECT order_uuid FROM order_reservations WHERE stock_held);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE orders
+    DROP COLUMN stock_taken;
+
+-- +goose StatementEnd
@@ -21,8 +21,66 @@ type Config struct {
 		GRPCPort int `mapstructure:"grpc_port"`
 		RESTPort int `mapstructure:"rest_port"`
 	} `yaml:"server" mapstructure:"server"`
-	SMS GRPCClient `mapstructure:"sms"`
-	OMS GRPCClient `mapstructure:"oms"`
+	SMS  GRPCClient `mapstructure:"sms"`
+	OMS  GRPCClient `mapstructure:"oms"`
+	Saga struct {
+		RecoveryInterval time.Duration `mapstructure:"recovery_interval"`
+		RecoveryAfter    time.Duration `mapstructure:"recovery_after"`
+	} `yaml:"saga" mapstructure:"saga"`
+	Outbox struct {
+		Publisher    string        `mapstructure:"publisher"`
+		PollInterval time.Duration `mapstructure:"poll_interval"`
+		BatchSize    int           `mapstructure:"batch_size"`
+	} `yaml:"outbox" mapstructure:"outbox"`
+	Idempotency struct {
+		TTL             time.Duration `mapstructure:"ttl"`
+		CleanupInterval time.Duration `mapstructure:"cleanup_interval"`
+	} `yaml:"idempotency" mapstructure:"idempotency"`
+	TCC struct {
+		ReservationTTL time.Duration `mapstructure:"reservation_ttl"`
+		SweepInterval  time.Duration `mapstructure:"sweep_interval"`
+	} `yaml:"tcc" mapstructure:"tcc"`
+	Currency struct {
+		Default string            `mapstructure:"default"`
+		Rates   map[string]string `mapstructure:"rates"`
+	} `yaml:"currency" mapstructure:"currency"`
+	Tax struct {
+		DefaultRegion string `mapstructure:"default_region"`
+	} `yaml:"tax" mapstructure:"tax"`
+	PriceSchedule struct {
+		Interval time.Duration `mapstructure:"interval"`
+	} `yaml:"price_schedule" mapstructure:"price_schedule"`
+	Payment struct {
+		Provider string `mapstructure:"provider"`
+		// FakeLimit is the largest amount the fake provider authorizes, empty for no limit
+		FakeLimit string `mapstructure:"fake_limit"`
+	} `yaml:"payment" mapstructure:"payment"`
+	Invoice struct {
+		NumberPrefix  string `mapstructure:"number_prefix"`
+		SellerName    string `mapstructure:"seller_name"`
+		SellerAddress string `mapstructure:"seller_address"`
+		SellerTaxID   string `mapstructure:"seller_tax_id"`
+		// Font is the TrueType font file PDF invoices are drawn with, PDF
+		// invoices are disabled without one
+		Font string `mapstructure:"font"`
+	} `yaml:"invoice" mapstructure:"invoice"`
+	OrderWatch struct {
+		RetryInterval time.Duration `mapstructure:"retry_interval"`
+	} `yaml:"order_watch" mapstructure:"order_watch"`
+	Auth struct {
+		// JWKSFile holds the keys tokens are signed with
+		JWKSFile  string `mapstructure:"jwks_file"`
+		Issuer    string `mapstructure:"issuer"`
+		Audience  string `mapstructure:"audience"`
+		RoleClaim string `mapstructure:"role_claim"`
+		// TenantClaim names the shop a token is limited to
+		TenantClaim string `mapstructure:"tenant_claim"`
+	} `yaml:"auth" mapstructure:"auth"`
+	Tenant struct {
+		// Default is the shop of tokens that name none, empty to reject them.
+		// Admin tokens may pick another one.
+		Default string `mapstructure:"default"`
+	} `yaml:"tenant" mapstructure:"tenant"`
 }
 
 type GRPCClient struct {
@@ -1,7 +1,23 @@
 package controllers
 
-import "github.com/gin-gonic/gin"
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/igntnk/stocky-oms/models"
+	"reflect"
+)
+
+var validate = newValidator()
 
 type Controller interface {
 	Register(r *gin.Engine)
 }
+
+func newValidator() *validator.Validate {
+	v := validator.New()
+	// money is validated by its amount in cents, so gt=0 means a positive price
+	v.RegisterCustomTypeFunc(func(field reflect.Value) any {
+		return field.Interface().(models.Money).Cents()
+	}, models.Money{})
+	return v
+}
@@ -0,0 +1,213 @@
+package controllers
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/igntnk/stocky-oms/models"
+	"github.com/igntnk/stocky-oms/service"
+	"net/http"
+)
+
+type customerController struct {
+	customers service.CustomerService
+}
+
+func NewCustomerController(customers service.CustomerService) Controller {
+	return &customerController{
+		customers: customers,
+	}
+}
+
+// Register adds the customer routes, :id is the user id orders are placed for
+func (c *customerController) Register(r *gin.Engine) {
+	customersGroup := r.Group("/api/customers")
+	customersGroup.POST("", c.Create)
+	customersGroup.GET("", c.List)
+	customersGroup.GET("/:id", c.Get)
+	customersGroup.PATCH("/:id", c.Update)
+	customersGroup.GET("/:id/orders", c.ListOrders)
+	customersGroup.GET("/:id/stats", c.Stats)
+	customersGroup.POST("/:id/addresses", c.AddAddress)
+	customersGroup.PUT("/:id/addresses/:address_id", c.UpdateAddress)
+	customersGroup.DELETE("/:id/addresses/:address_id", c.DeleteAddress)
+}
+
+func (c *customerController) Create(context *gin.Context) {
+	var err error
+
+	createReq := models.CustomerCreateRequest{}
+	err = context.ShouldBindBodyWithJSON(&createReq)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": errors.Join(err, errors.New("failed to parse body")).Error()})
+		return
+	}
+
+	err = validate.Struct(createReq)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	customer, err := c.customers.CreateCustomer(context, createReq)
+	if err != nil {
+		context.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{"customer": customer})
+}
+
+func (c *customerController) List(context *gin.Context) {
+	filter := models.CustomerFilter{
+		Limit: defaultListLimit,
+	}
+	err := context.ShouldBindQuery(&filter)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": errors.Join(err, errors.New("failed to parse query")).Error()})
+		return
+	}
+
+	err = validate.Struct(filter)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	customers, err := c.customers.ListCustomers(context, filter)
+	if err != nil {
+		context.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{"customers": customers})
+}
+
+func (c *customerController) Get(context *gin.Context) {
+	customer, err := c.customers.GetCustomer(context, context.Param("id"))
+	if err != nil {
+		context.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{"customer": customer})
+}
+
+func (c *customerController) Update(context *gin.Context) {
+	var err error
+
+	updateReq := models.CustomerUpdateRequest{}
+	err = context.ShouldBindBodyWithJSON(&updateReq)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": errors.Join(err, errors.New("failed to parse body")).Error()})
+		return
+	}
+
+	err = validate.Struct(updateReq)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	customer, err := c.customers.UpdateCustomer(context, context.Param("id"), updateReq)
+	if err != nil {
+		context.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{"customer": customer})
+}
+
+func (c *customerController) ListOrders(context *gin.Context) {
+	filter := models.OrderFilter{
+		Limit: defaultListLimit,
+	}
+	err := context.ShouldBindQuery(&filter)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": errors.Join(err, errors.New("failed to parse query")).Error()})
+		return
+	}
+
+	err = validate.Struct(filter)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	orders, err := c.customers.ListCustomerOrders(context, context.Param("id"), filter)
+	if err != nil {
+		context.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{"orders": orders})
+}
+
+func (c *customerController) Stats(context *gin.Context) {
+	stats, err := c.customers.GetCustomerStats(context, context.Param("id"))
+	if err != nil {
+		context.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{"stats": stats})
+}
+
+func (c *customerController) AddAddress(context *gin.Context) {
+	var err error
+
+	addressReq := models.CustomerAddressRequest{}
+	err = context.ShouldBindBodyWithJSON(&addressReq)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": errors.Join(err, errors.New("failed to parse body")).Error()})
+		return
+	}
+
+	err = validate.Struct(addressReq)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	address, err := c.customers.AddCustomerAddress(context, context.Param("id"), addressReq)
+	if err != nil {
+		context.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{"address": address})
+}
+
+func (c *customerController) UpdateAddress(context *gin.Context) {
+	var err error
+
+	addressReq := models.CustomerAddressRequest{}
+	err = context.ShouldBindBodyWithJSON(&addressReq)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": errors.Join(err, errors.New("failed to parse body")).Error()})
+		return
+	}
+
+	err = validate.Struct(addressReq)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	address, err := c.customers.UpdateCustomerAddress(context, context.Param("id"), context.Param("address_id"), addressReq)
+	if err != nil {
+		context.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{"address": address})
+}
+
+func (c *customerController) DeleteAddress(context *gin.Context) {
+	err := c.customers.DeleteCustomerAddress(context, context.Param("id"), context.Param("address_id"))
+	if err != nil {
+		context.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	context.Status(http.StatusNoContent)
+}
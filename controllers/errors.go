@@ -0,0 +1,88 @@
+package controllers
+
+import (
+	"errors"
+	"github.com/igntnk/stocky-oms/service"
+	"net/http"
+)
+
+// errorStatus maps service layer errors to HTTP status codes
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrOrderNotFound),
+		errors.Is(err, service.ErrProductNotFound),
+		errors.Is(err, service.ErrPromotionNotFound),
+		errors.Is(err, service.ErrTaxRuleNotFound),
+		errors.Is(err, service.ErrPriceNotFound),
+		errors.Is(err, service.ErrPriceChangeNotFound),
+		errors.Is(err, service.ErrOrderProductNotFound),
+		errors.Is(err, service.ErrShipmentNotFound),
+		errors.Is(err, service.ErrReturnNotFound),
+		errors.Is(err, service.ErrPaymentNotFound),
+		errors.Is(err, service.ErrCustomerNotFound),
+		errors.Is(err, service.ErrAddressNotFound),
+		errors.Is(err, service.ErrShippingMethodNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrInvalidStatusTransition),
+		errors.Is(err, service.ErrOrderStatusConflict),
+		errors.Is(err, service.ErrOrderLinesConflict),
+		errors.Is(err, service.ErrIdempotencyKeyInProgress),
+		errors.Is(err, service.ErrReservationExpired),
+		errors.Is(err, service.ErrPromotionCodeUsed),
+		errors.Is(err, service.ErrPromotionUnavailable),
+		errors.Is(err, service.ErrTaxRuleExists),
+		errors.Is(err, service.ErrPriceChangeNotPending),
+		errors.Is(err, service.ErrOrderNotEditable),
+		errors.Is(err, service.ErrInvalidShipmentTransition),
+		errors.Is(err, service.ErrShipmentStatusConflict),
+		errors.Is(err, service.ErrOrderNotShippable),
+		errors.Is(err, service.ErrInvalidReturnTransition),
+		errors.Is(err, service.ErrReturnStatusConflict),
+		errors.Is(err, service.ErrOrderNotReturnable),
+		errors.Is(err, service.ErrPaymentNotAuthorized),
+		errors.Is(err, service.ErrPaymentNotCaptured),
+		errors.Is(err, service.ErrPaymentConflict),
+		errors.Is(err, service.ErrOrderNotInvoiceable),
+		errors.Is(err, service.ErrOrderInvoiced),
+		errors.Is(err, service.ErrCustomerExists),
+		errors.Is(err, service.ErrShippingMethodExists),
+		errors.Is(err, service.ErrShippingMethodInUse),
+		errors.Is(err, service.ErrStockReturnInProgress):
+		return http.StatusConflict
+	case errors.Is(err, service.ErrIdempotencyKeyConflict),
+		errors.Is(err, service.ErrCurrencyMismatch),
+		errors.Is(err, service.ErrInvalidCoupon),
+		errors.Is(err, service.ErrShipmentExceedsOrder),
+		errors.Is(err, service.ErrReturnExceedsOrder),
+		errors.Is(err, service.ErrInvalidShippingMethod),
+		errors.Is(err, service.ErrShippingAddressRequired):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, service.ErrPermissionDenied):
+		return http.StatusForbidden
+	case errors.Is(err, service.ErrPaymentDeclined):
+		return http.StatusPaymentRequired
+	case errors.Is(err, service.ErrInvoicePDFDisabled):
+		return http.StatusNotImplemented
+	case errors.Is(err, service.ErrStockReturnFailed),
+		errors.Is(err, service.ErrPaymentFailed):
+		return http.StatusBadGateway
+	case errors.Is(err, service.ErrInvalidOrderID),
+		errors.Is(err, service.ErrInvalidProductID),
+		errors.Is(err, service.ErrInvalidOrderData),
+		errors.Is(err, service.ErrEmptyOrder),
+		errors.Is(err, service.ErrInvalidIdempotencyKey),
+		errors.Is(err, service.ErrInvalidPromotionID),
+		errors.Is(err, service.ErrInvalidPromotion),
+		errors.Is(err, service.ErrInvalidTaxRuleID),
+		errors.Is(err, service.ErrInvalidPriceChangeID),
+		errors.Is(err, service.ErrInvalidPriceChange),
+		errors.Is(err, service.ErrInvalidShipmentID),
+		errors.Is(err, service.ErrInvalidReturnID),
+		errors.Is(err, service.ErrInvalidCustomerID),
+		errors.Is(err, service.ErrInvalidAddressID),
+		errors.Is(err, service.ErrInvalidShippingMethodID):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,61 @@
+package controllers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/igntnk/stocky-oms/models"
+	"github.com/igntnk/stocky-oms/service"
+	"net/http"
+)
+
+type invoiceController struct {
+	invoices service.InvoiceService
+}
+
+func NewInvoiceController(invoices service.InvoiceService) Controller {
+	return &invoiceController{
+		invoices: invoices,
+	}
+}
+
+func (ic *invoiceController) Register(r *gin.Engine) {
+	r.GET("/api/orders/:id/invoice", ic.Get)
+}
+
+func (ic *invoiceController) Get(context *gin.Context) {
+	var err error
+
+	query := models.InvoiceQuery{}
+	err = context.ShouldBindQuery(&query)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	err = validate.Struct(query)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	invoice, err := ic.invoices.GetOrderInvoice(context, context.Param("id"))
+	if err != nil {
+		context.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	if query.Format == models.InvoiceFormatJSON {
+		context.JSON(http.StatusOK, gin.H{"invoice": invoice})
+		return
+	}
+
+	content, contentType, err := ic.invoices.RenderInvoice(invoice, query.Format)
+	if err != nil {
+		context.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	if contentType == "application/pdf" {
+		context.Header("Content-Disposition", `inline; filename="`+invoice.Number+`.pdf"`)
+	}
+	context.Data(http.StatusOK, contentType, content)
+}
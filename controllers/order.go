@@ -4,19 +4,32 @@ import (
 	"errors"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/igntnk/stocky-oms/auth"
 	"github.com/igntnk/stocky-oms/models"
 	"github.com/igntnk/stocky-oms/requests"
 	"github.com/igntnk/stocky-oms/service"
+	"io"
+	"math"
 	"net/http"
 )
 
+const (
+	defaultListLimit = 20
+
+	idempotencyKeyHeader = "Idempotency-Key"
+	sagaCreateScope      = "rest.saga_create"
+	tccCreateScope       = "rest.tcc_create"
+)
+
 type orderController struct {
-	orders service.OrderService
+	orders      service.OrderService
+	idempotency service.IdempotencyService
 }
 
-func NewOrderController(orders service.OrderService) Controller {
+func NewOrderController(orders service.OrderService, idempotency service.IdempotencyService) Controller {
 	return &orderController{
-		orders: orders,
+		orders:      orders,
+		idempotency: idempotency,
 	}
 }
 
@@ -26,6 +39,21 @@ func (o *orderController) Register(r *gin.Engine) {
 
 	tccGroup := r.Group("/api/TCC/order")
 	tccGroup.POST("/create", o.TCCCreate)
+
+	ordersGroup := r.Group("/api/orders")
+	ordersGroup.GET("", o.List)
+	ordersGroup.GET("/:id", o.Get)
+	ordersGroup.PATCH("/:id", o.Update)
+	ordersGroup.DELETE("/:id", o.Delete)
+	ordersGroup.GET("/:id/products", o.GetProducts)
+	ordersGroup.POST("/:id/products", o.AddProduct)
+	ordersGroup.PATCH("/:id/products/:product_id", o.ChangeProductAmount)
+	ordersGroup.DELETE("/:id/products/:product_id", o.RemoveProduct)
+	ordersGroup.POST("/:id/status", o.ChangeStatus)
+	ordersGroup.GET("/:id/history", o.GetStatusHistory)
+	ordersGroup.POST("/:id/cancel", o.Cancel)
+
+	r.GET("/api/stock-returns", o.ListStockReturns)
 }
 
 func (o *orderController) Create(context *gin.Context) {
@@ -52,14 +80,25 @@ func (o *orderController) Create(context *gin.Context) {
 		})
 	}
 
-	order, err := o.orders.CreateSagaOrder(context, models.OrderCreateRequest{
-		UserID:   "000000000000000000000000",
-		StaffID:  "000000000000000000000000",
+	identity, ok := auth.FromContext(context)
+	if !ok {
+		context.JSON(http.StatusUnauthorized, gin.H{"error": auth.ErrMissingToken.Error()})
+		return
+	}
+
+	createReq := models.OrderCreateRequest{
+		UserID:   identity.UserID,
+		StaffID:  identity.StaffID,
 		Comment:  receivedOrder.Comment,
+		Currency: receivedOrder.Currency,
+		Coupons:  receivedOrder.Coupons,
+		Region:   receivedOrder.Region,
 		Products: products,
-	})
+	}
+
+	order, err := o.idempotency.CreateOrder(context, sagaCreateScope, context.GetHeader(idempotencyKeyHeader), createReq, o.orders.CreateSagaOrder)
 	if err != nil {
-		context.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		context.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -90,16 +129,259 @@ func (o *orderController) TCCCreate(context *gin.Context) {
 		})
 	}
 
-	order, err := o.orders.TccCreateOrder(context, models.OrderCreateRequest{
-		UserID:   "000000000000000000000000",
-		StaffID:  "000000000000000000000000",
+	identity, ok := auth.FromContext(context)
+	if !ok {
+		context.JSON(http.StatusUnauthorized, gin.H{"error": auth.ErrMissingToken.Error()})
+		return
+	}
+
+	createReq := models.OrderCreateRequest{
+		UserID:   identity.UserID,
+		StaffID:  identity.StaffID,
 		Comment:  receivedOrder.Comment,
+		Currency: receivedOrder.Currency,
+		Coupons:  receivedOrder.Coupons,
+		Region:   receivedOrder.Region,
 		Products: products,
-	})
+	}
+
+	order, err := o.idempotency.CreateOrder(context, tccCreateScope, context.GetHeader(idempotencyKeyHeader), createReq, o.orders.TccCreateOrder)
+	if err != nil {
+		context.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{"order": order})
+}
+
+func (o *orderController) List(context *gin.Context) {
+	filter := models.OrderFilter{
+		Limit: defaultListLimit,
+	}
+	err := context.ShouldBindQuery(&filter)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": errors.Join(err, errors.New("failed to parse query")).Error()})
+		return
+	}
+
+	err = validate.Struct(filter)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	orders, err := o.orders.ListOrders(context, filter)
+	if err != nil {
+		context.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{"orders": orders})
+}
+
+func (o *orderController) Get(context *gin.Context) {
+	order, err := o.orders.GetOrder(context, context.Param("id"))
+	if err != nil {
+		context.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{"order": order})
+}
+
+func (o *orderController) Update(context *gin.Context) {
+	var err error
+
+	updateReq := models.OrderUpdateRequest{}
+	err = context.ShouldBindBodyWithJSON(&updateReq)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": errors.Join(err, errors.New("failed to parse body")).Error()})
+		return
+	}
+
+	err = validate.Struct(updateReq)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	order, err := o.orders.UpdateOrder(context, context.Param("id"), updateReq)
+	if err != nil {
+		context.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{"order": order})
+}
+
+func (o *orderController) Delete(context *gin.Context) {
+	err := o.orders.DeleteOrder(context, context.Param("id"))
+	if err != nil {
+		context.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	context.Status(http.StatusNoContent)
+}
+
+func (o *orderController) GetProducts(context *gin.Context) {
+	products, err := o.orders.GetOrderProducts(context, context.Param("id"))
+	if err != nil {
+		context.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{"products": products})
+}
+
+func (o *orderController) AddProduct(context *gin.Context) {
+	var err error
+
+	receivedProduct := requests.CreateOrderProduct{}
+	err = context.ShouldBindBodyWithJSON(&receivedProduct)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": errors.Join(err, errors.New("failed to parse body")).Error()})
+		return
+	}
+
+	prUuid, err := uuid.Parse(receivedProduct.Uuid)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if receivedProduct.Amount <= 0 || receivedProduct.Amount != math.Trunc(receivedProduct.Amount) {
+		context.JSON(http.StatusBadRequest, gin.H{"error": "amount must be a positive whole number"})
+		return
+	}
+
+	order, err := o.orders.AddOrderProduct(context, context.Param("id"), prUuid.String(), int(receivedProduct.Amount))
+	if err != nil {
+		context.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{"order": order})
+}
+
+func (o *orderController) ChangeProductAmount(context *gin.Context) {
+	var err error
+
+	changeReq := models.OrderProductAmountRequest{}
+	err = context.ShouldBindBodyWithJSON(&changeReq)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": errors.Join(err, errors.New("failed to parse body")).Error()})
+		return
+	}
+
+	err = validate.Struct(changeReq)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	order, err := o.orders.ChangeOrderProductAmount(context, context.Param("id"), context.Param("product_id"), changeReq.Amount)
+	if err != nil {
+		context.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{"order": order})
+}
+
+func (o *orderController) RemoveProduct(context *gin.Context) {
+	order, err := o.orders.RemoveOrderProduct(context, context.Param("id"), context.Param("product_id"))
+	if err != nil {
+		context.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{"order": order})
+}
+
+func (o *orderController) ChangeStatus(context *gin.Context) {
+	var err error
+
+	changeReq := models.OrderStatusChangeRequest{}
+	err = context.ShouldBindBodyWithJSON(&changeReq)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": errors.Join(err, errors.New("failed to parse body")).Error()})
+		return
+	}
+
+	err = validate.Struct(changeReq)
 	if err != nil {
-		context.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		context.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	order, err := o.orders.ChangeOrderStatus(context, context.Param("id"), changeReq)
+	if err != nil {
+		context.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{"order": order})
+}
+
+func (o *orderController) GetStatusHistory(context *gin.Context) {
+	history, err := o.orders.GetOrderStatusHistory(context, context.Param("id"))
+	if err != nil {
+		context.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{"history": history})
+}
+
+// Cancel is safe to retry: repeating it for a cancelled order retries
+// returning the order products to the warehouse.
+func (o *orderController) Cancel(context *gin.Context) {
+	var err error
+
+	cancelReq := models.OrderCancelRequest{}
+	err = context.ShouldBindBodyWithJSON(&cancelReq)
+	if err != nil && !errors.Is(err, io.EOF) {
+		context.JSON(http.StatusBadRequest, gin.H{"error": errors.Join(err, errors.New("failed to parse body")).Error()})
+		return
+	}
+
+	err = validate.Struct(cancelReq)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	order, err := o.orders.CancelOrder(context, context.Param("id"), cancelReq)
+	if err != nil {
+		context.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
 	context.JSON(http.StatusOK, gin.H{"order": order})
 }
+
+func (o *orderController) ListStockReturns(context *gin.Context) {
+	filter := models.StockReturnFilter{
+		Limit:  defaultListLimit,
+		Status: models.StockReturnStatusFailed,
+	}
+	err := context.ShouldBindQuery(&filter)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": errors.Join(err, errors.New("failed to parse query")).Error()})
+		return
+	}
+
+	err = validate.Struct(filter)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	stockReturns, err := o.orders.ListStockReturns(context, filter)
+	if err != nil {
+		context.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{"stock_returns": stockReturns})
+}
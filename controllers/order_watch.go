@@ -0,0 +1,72 @@
+package controllers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/igntnk/stocky-oms/models"
+	"github.com/igntnk/stocky-oms/service"
+	"io"
+	"net/http"
+	"time"
+)
+
+// orderWatchKeepAlive is how often an idle event stream gets a comment so
+// proxies don't close it
+const orderWatchKeepAlive = 15 * time.Second
+
+type orderWatchController struct {
+	watch service.OrderWatchService
+}
+
+func NewOrderWatchController(watch service.OrderWatchService) Controller {
+	return &orderWatchController{
+		watch: watch,
+	}
+}
+
+func (wc *orderWatchController) Register(r *gin.Engine) {
+	r.GET("/api/orders/watch", wc.Watch)
+}
+
+// Watch streams order changes as server-sent events named after the change
+// type. The stream ends if the client falls behind.
+func (wc *orderWatchController) Watch(context *gin.Context) {
+	var err error
+
+	filter := models.OrderWatchFilter{}
+	err = context.ShouldBindQuery(&filter)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	err = validate.Struct(filter)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	changes := wc.watch.Watch(context.Request.Context(), filter)
+
+	keepAlive := time.NewTicker(orderWatchKeepAlive)
+	defer keepAlive.Stop()
+
+	context.Header("Content-Type", "text/event-stream")
+	context.Header("Cache-Control", "no-cache")
+	context.Header("X-Accel-Buffering", "no")
+	context.Status(http.StatusOK)
+	context.Writer.Flush()
+
+	context.Stream(func(w io.Writer) bool {
+		select {
+		case change, ok := <-changes:
+			if !ok {
+				return false
+			}
+			context.SSEvent(string(change.Type), change)
+			return true
+		case <-keepAlive.C:
+			_, err := io.WriteString(w, ": keep-alive\n\n")
+			return err == nil
+		}
+	})
+}
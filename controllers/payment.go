@@ -0,0 +1,65 @@
+package controllers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/igntnk/stocky-oms/service"
+	"net/http"
+)
+
+type paymentController struct {
+	payments service.PaymentService
+}
+
+func NewPaymentController(payments service.PaymentService) Controller {
+	return &paymentController{
+		payments: payments,
+	}
+}
+
+func (pc *paymentController) Register(r *gin.Engine) {
+	paymentsGroup := r.Group("/api/orders/:id/payments")
+	paymentsGroup.GET("", pc.List)
+	paymentsGroup.POST("/authorize", pc.Authorize)
+	paymentsGroup.POST("/capture", pc.Capture)
+	paymentsGroup.POST("/void", pc.Void)
+}
+
+func (pc *paymentController) List(context *gin.Context) {
+	payments, err := pc.payments.ListOrderPayments(context, context.Param("id"))
+	if err != nil {
+		context.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{"payments": payments})
+}
+
+func (pc *paymentController) Authorize(context *gin.Context) {
+	payment, err := pc.payments.AuthorizeOrder(context, context.Param("id"))
+	if err != nil {
+		context.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{"payment": payment})
+}
+
+func (pc *paymentController) Capture(context *gin.Context) {
+	payment, err := pc.payments.CaptureOrder(context, context.Param("id"))
+	if err != nil {
+		context.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{"payment": payment})
+}
+
+func (pc *paymentController) Void(context *gin.Context) {
+	payment, err := pc.payments.VoidOrder(context, context.Param("id"))
+	if err != nil {
+		context.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{"payment": payment})
+}
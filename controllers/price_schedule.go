@@ -0,0 +1,97 @@
+package controllers
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/igntnk/stocky-oms/models"
+	"github.com/igntnk/stocky-oms/service"
+	"net/http"
+)
+
+type priceScheduleController struct {
+	priceChanges service.PriceScheduleService
+}
+
+func NewPriceScheduleController(priceChanges service.PriceScheduleService) Controller {
+	return &priceScheduleController{
+		priceChanges: priceChanges,
+	}
+}
+
+func (p *priceScheduleController) Register(r *gin.Engine) {
+	priceChangesGroup := r.Group("/api/price-changes")
+	priceChangesGroup.POST("", p.Create)
+	priceChangesGroup.GET("", p.List)
+	priceChangesGroup.GET("/:id", p.Get)
+	priceChangesGroup.POST("/:id/cancel", p.Cancel)
+}
+
+func (p *priceScheduleController) Create(context *gin.Context) {
+	var err error
+
+	createReq := models.PriceChangeCreateRequest{}
+	err = context.ShouldBindBodyWithJSON(&createReq)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": errors.Join(err, errors.New("failed to parse body")).Error()})
+		return
+	}
+
+	err = validate.Struct(createReq)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	change, err := p.priceChanges.SchedulePriceChange(context, createReq)
+	if err != nil {
+		context.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{"price_change": change})
+}
+
+func (p *priceScheduleController) List(context *gin.Context) {
+	filter := models.PriceChangeFilter{
+		Limit: defaultListLimit,
+	}
+	err := context.ShouldBindQuery(&filter)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": errors.Join(err, errors.New("failed to parse query")).Error()})
+		return
+	}
+
+	err = validate.Struct(filter)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	changes, err := p.priceChanges.ListPriceChanges(context, filter)
+	if err != nil {
+		context.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{"price_changes": changes})
+}
+
+func (p *priceScheduleController) Get(context *gin.Context) {
+	change, err := p.priceChanges.GetPriceChange(context, context.Param("id"))
+	if err != nil {
+		context.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{"price_change": change})
+}
+
+func (p *priceScheduleController) Cancel(context *gin.Context) {
+	change, err := p.priceChanges.CancelPriceChange(context, context.Param("id"))
+	if err != nil {
+		context.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{"price_change": change})
+}
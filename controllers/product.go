@@ -0,0 +1,195 @@
+package controllers
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/igntnk/stocky-oms/models"
+	"github.com/igntnk/stocky-oms/service"
+	"net/http"
+)
+
+type productController struct {
+	products service.ProductService
+}
+
+func NewProductController(products service.ProductService) Controller {
+	return &productController{
+		products: products,
+	}
+}
+
+func (p *productController) Register(r *gin.Engine) {
+	productsGroup := r.Group("/api/products")
+	productsGroup.POST("", p.Create)
+	productsGroup.GET("", p.List)
+	productsGroup.GET("/:id", p.Get)
+	productsGroup.PATCH("/:id", p.Update)
+	productsGroup.DELETE("/:id", p.Delete)
+	productsGroup.GET("/by-order/:order_id", p.GetByOrder)
+	productsGroup.GET("/by-code/:code", p.GetByCode)
+	productsGroup.GET("/:id/prices", p.ListPrices)
+	productsGroup.GET("/:id/price", p.GetPriceAt)
+}
+
+func (p *productController) Create(context *gin.Context) {
+	var err error
+
+	createReq := models.ProductCreateRequest{}
+	err = context.ShouldBindBodyWithJSON(&createReq)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": errors.Join(err, errors.New("failed to parse body")).Error()})
+		return
+	}
+
+	err = validate.Struct(createReq)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	product, err := p.products.CreateProduct(context, createReq)
+	if err != nil {
+		context.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{"product": product})
+}
+
+func (p *productController) List(context *gin.Context) {
+	filter := models.ProductFilter{
+		Limit: defaultListLimit,
+	}
+	err := context.ShouldBindQuery(&filter)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": errors.Join(err, errors.New("failed to parse query")).Error()})
+		return
+	}
+
+	err = validate.Struct(filter)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	products, err := p.products.ListProducts(context, filter.Limit, filter.Offset)
+	if err != nil {
+		context.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{"products": products})
+}
+
+func (p *productController) Get(context *gin.Context) {
+	product, err := p.products.GetProduct(context, context.Param("id"))
+	if err != nil {
+		context.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{"product": product})
+}
+
+func (p *productController) GetByCode(context *gin.Context) {
+	product, err := p.products.GetProductByCode(context, context.Param("code"))
+	if err != nil {
+		context.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{"product": product})
+}
+
+func (p *productController) Update(context *gin.Context) {
+	var err error
+
+	updateReq := models.ProductUpdateRequest{}
+	err = context.ShouldBindBodyWithJSON(&updateReq)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": errors.Join(err, errors.New("failed to parse body")).Error()})
+		return
+	}
+
+	err = validate.Struct(updateReq)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	product, err := p.products.UpdateProduct(context, context.Param("id"), updateReq)
+	if err != nil {
+		context.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{"product": product})
+}
+
+func (p *productController) Delete(context *gin.Context) {
+	err := p.products.DeleteProduct(context, context.Param("id"))
+	if err != nil {
+		context.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	context.Status(http.StatusNoContent)
+}
+
+func (p *productController) GetByOrder(context *gin.Context) {
+	products, err := p.products.GetProductsByOrder(context, context.Param("order_id"))
+	if err != nil {
+		context.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{"products": products})
+}
+
+func (p *productController) ListPrices(context *gin.Context) {
+	filter := models.ProductPriceFilter{
+		Limit: defaultListLimit,
+	}
+	err := context.ShouldBindQuery(&filter)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": errors.Join(err, errors.New("failed to parse query")).Error()})
+		return
+	}
+
+	err = validate.Struct(filter)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	prices, err := p.products.ListPriceHistory(context, context.Param("id"), filter)
+	if err != nil {
+		context.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{"prices": prices})
+}
+
+func (p *productController) GetPriceAt(context *gin.Context) {
+	query := models.ProductPriceQuery{}
+	err := context.ShouldBindQuery(&query)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": errors.Join(err, errors.New("failed to parse query")).Error()})
+		return
+	}
+
+	err = validate.Struct(query)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	price, err := p.products.GetPriceAt(context, context.Param("id"), query.At)
+	if err != nil {
+		context.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{"price": price})
+}
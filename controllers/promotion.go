@@ -0,0 +1,123 @@
+package controllers
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/igntnk/stocky-oms/models"
+	"github.com/igntnk/stocky-oms/service"
+	"net/http"
+)
+
+type promotionController struct {
+	promotions service.PromotionService
+}
+
+func NewPromotionController(promotions service.PromotionService) Controller {
+	return &promotionController{
+		promotions: promotions,
+	}
+}
+
+func (p *promotionController) Register(r *gin.Engine) {
+	promotionsGroup := r.Group("/api/promotions")
+	promotionsGroup.POST("", p.Create)
+	promotionsGroup.GET("", p.List)
+	promotionsGroup.GET("/:id", p.Get)
+	promotionsGroup.PATCH("/:id", p.Update)
+	promotionsGroup.DELETE("/:id", p.Delete)
+}
+
+func (p *promotionController) Create(context *gin.Context) {
+	var err error
+
+	createReq := models.PromotionCreateRequest{}
+	err = context.ShouldBindBodyWithJSON(&createReq)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": errors.Join(err, errors.New("failed to parse body")).Error()})
+		return
+	}
+
+	err = validate.Struct(createReq)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	promotion, err := p.promotions.CreatePromotion(context, createReq)
+	if err != nil {
+		context.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{"promotion": promotion})
+}
+
+func (p *promotionController) List(context *gin.Context) {
+	filter := models.PromotionFilter{
+		Limit: defaultListLimit,
+	}
+	err := context.ShouldBindQuery(&filter)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": errors.Join(err, errors.New("failed to parse query")).Error()})
+		return
+	}
+
+	err = validate.Struct(filter)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	promotions, err := p.promotions.ListPromotions(context, filter)
+	if err != nil {
+		context.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{"promotions": promotions})
+}
+
+func (p *promotionController) Get(context *gin.Context) {
+	promotion, err := p.promotions.GetPromotion(context, context.Param("id"))
+	if err != nil {
+		context.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{"promotion": promotion})
+}
+
+func (p *promotionController) Update(context *gin.Context) {
+	var err error
+
+	updateReq := models.PromotionUpdateRequest{}
+	err = context.ShouldBindBodyWithJSON(&updateReq)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": errors.Join(err, errors.New("failed to parse body")).Error()})
+		return
+	}
+
+	err = validate.Struct(updateReq)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	promotion, err := p.promotions.UpdatePromotion(context, context.Param("id"), updateReq)
+	if err != nil {
+		context.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{"promotion": promotion})
+}
+
+func (p *promotionController) Delete(context *gin.Context) {
+	err := p.promotions.DeletePromotion(context, context.Param("id"))
+	if err != nil {
+		context.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	context.Status(http.StatusNoContent)
+}
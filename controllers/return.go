@@ -0,0 +1,113 @@
+package controllers
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/igntnk/stocky-oms/models"
+	"github.com/igntnk/stocky-oms/service"
+	"net/http"
+)
+
+type returnController struct {
+	returns service.ReturnService
+}
+
+func NewReturnController(returns service.ReturnService) Controller {
+	return &returnController{
+		returns: returns,
+	}
+}
+
+func (rc *returnController) Register(r *gin.Engine) {
+	r.POST("/api/orders/:id/returns", rc.Create)
+
+	returnsGroup := r.Group("/api/returns")
+	returnsGroup.GET("", rc.List)
+	returnsGroup.GET("/:id", rc.Get)
+	returnsGroup.POST("/:id/status", rc.ChangeStatus)
+}
+
+func (rc *returnController) Create(context *gin.Context) {
+	var err error
+
+	createReq := models.ReturnCreateRequest{}
+	err = context.ShouldBindBodyWithJSON(&createReq)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": errors.Join(err, errors.New("failed to parse body")).Error()})
+		return
+	}
+
+	err = validate.Struct(createReq)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ret, err := rc.returns.CreateReturn(context, context.Param("id"), createReq)
+	if err != nil {
+		context.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{"return": ret})
+}
+
+func (rc *returnController) List(context *gin.Context) {
+	filter := models.ReturnFilter{
+		Limit: defaultListLimit,
+	}
+	err := context.ShouldBindQuery(&filter)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": errors.Join(err, errors.New("failed to parse query")).Error()})
+		return
+	}
+
+	err = validate.Struct(filter)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	returns, err := rc.returns.ListReturns(context, filter)
+	if err != nil {
+		context.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{"returns": returns})
+}
+
+func (rc *returnController) Get(context *gin.Context) {
+	ret, err := rc.returns.GetReturn(context, context.Param("id"))
+	if err != nil {
+		context.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{"return": ret})
+}
+
+func (rc *returnController) ChangeStatus(context *gin.Context) {
+	var err error
+
+	changeReq := models.ReturnStatusChangeRequest{}
+	err = context.ShouldBindBodyWithJSON(&changeReq)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": errors.Join(err, errors.New("failed to parse body")).Error()})
+		return
+	}
+
+	err = validate.Struct(changeReq)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ret, err := rc.returns.ChangeReturnStatus(context, context.Param("id"), changeReq)
+	if err != nil {
+		context.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{"return": ret})
+}
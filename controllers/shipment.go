@@ -0,0 +1,98 @@
+package controllers
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/igntnk/stocky-oms/models"
+	"github.com/igntnk/stocky-oms/service"
+	"net/http"
+)
+
+type shipmentController struct {
+	shipments service.ShipmentService
+}
+
+func NewShipmentController(shipments service.ShipmentService) Controller {
+	return &shipmentController{
+		shipments: shipments,
+	}
+}
+
+func (sc *shipmentController) Register(r *gin.Engine) {
+	r.POST("/api/orders/:id/shipments", sc.Create)
+	r.GET("/api/orders/:id/shipments", sc.ListByOrder)
+
+	shipmentsGroup := r.Group("/api/shipments")
+	shipmentsGroup.GET("/:id", sc.Get)
+	shipmentsGroup.POST("/:id/status", sc.ChangeStatus)
+}
+
+func (sc *shipmentController) Create(context *gin.Context) {
+	var err error
+
+	createReq := models.ShipmentCreateRequest{}
+	err = context.ShouldBindBodyWithJSON(&createReq)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": errors.Join(err, errors.New("failed to parse body")).Error()})
+		return
+	}
+
+	err = validate.Struct(createReq)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	shipment, err := sc.shipments.CreateShipment(context, context.Param("id"), createReq)
+	if err != nil {
+		context.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{"shipment": shipment})
+}
+
+func (sc *shipmentController) ListByOrder(context *gin.Context) {
+	shipments, err := sc.shipments.ListOrderShipments(context, context.Param("id"))
+	if err != nil {
+		context.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{"shipments": shipments})
+}
+
+func (sc *shipmentController) Get(context *gin.Context) {
+	shipment, err := sc.shipments.GetShipment(context, context.Param("id"))
+	if err != nil {
+		context.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{"shipment": shipment})
+}
+
+func (sc *shipmentController) ChangeStatus(context *gin.Context) {
+	var err error
+
+	changeReq := models.ShipmentStatusChangeRequest{}
+	err = context.ShouldBindBodyWithJSON(&changeReq)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": errors.Join(err, errors.New("failed to parse body")).Error()})
+		return
+	}
+
+	err = validate.Struct(changeReq)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	shipment, err := sc.shipments.ChangeShipmentStatus(context, context.Param("id"), changeReq)
+	if err != nil {
+		context.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{"shipment": shipment})
+}
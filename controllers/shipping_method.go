@@ -0,0 +1,123 @@
+package controllers
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/igntnk/stocky-oms/models"
+	"github.com/igntnk/stocky-oms/service"
+	"net/http"
+)
+
+type shippingMethodController struct {
+	methods service.ShippingMethodService
+}
+
+func NewShippingMethodController(methods service.ShippingMethodService) Controller {
+	return &shippingMethodController{
+		methods: methods,
+	}
+}
+
+func (m *shippingMethodController) Register(r *gin.Engine) {
+	methodsGroup := r.Group("/api/shipping-methods")
+	methodsGroup.POST("", m.Create)
+	methodsGroup.GET("", m.List)
+	methodsGroup.GET("/:id", m.Get)
+	methodsGroup.PATCH("/:id", m.Update)
+	methodsGroup.DELETE("/:id", m.Delete)
+}
+
+func (m *shippingMethodController) Create(context *gin.Context) {
+	var err error
+
+	createReq := models.ShippingMethodCreateRequest{}
+	err = context.ShouldBindBodyWithJSON(&createReq)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": errors.Join(err, errors.New("failed to parse body")).Error()})
+		return
+	}
+
+	err = validate.Struct(createReq)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	method, err := m.methods.CreateShippingMethod(context, createReq)
+	if err != nil {
+		context.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{"shipping_method": method})
+}
+
+func (m *shippingMethodController) List(context *gin.Context) {
+	filter := models.ShippingMethodFilter{
+		Limit: defaultListLimit,
+	}
+	err := context.ShouldBindQuery(&filter)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": errors.Join(err, errors.New("failed to parse query")).Error()})
+		return
+	}
+
+	err = validate.Struct(filter)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	methods, err := m.methods.ListShippingMethods(context, filter)
+	if err != nil {
+		context.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{"shipping_methods": methods})
+}
+
+func (m *shippingMethodController) Get(context *gin.Context) {
+	method, err := m.methods.GetShippingMethod(context, context.Param("id"))
+	if err != nil {
+		context.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{"shipping_method": method})
+}
+
+func (m *shippingMethodController) Update(context *gin.Context) {
+	var err error
+
+	updateReq := models.ShippingMethodUpdateRequest{}
+	err = context.ShouldBindBodyWithJSON(&updateReq)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": errors.Join(err, errors.New("failed to parse body")).Error()})
+		return
+	}
+
+	err = validate.Struct(updateReq)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	method, err := m.methods.UpdateShippingMethod(context, context.Param("id"), updateReq)
+	if err != nil {
+		context.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{"shipping_method": method})
+}
+
+func (m *shippingMethodController) Delete(context *gin.Context) {
+	err := m.methods.DeleteShippingMethod(context, context.Param("id"))
+	if err != nil {
+		context.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	context.Status(http.StatusNoContent)
+}
@@ -0,0 +1,123 @@
+package controllers
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/igntnk/stocky-oms/models"
+	"github.com/igntnk/stocky-oms/service"
+	"net/http"
+)
+
+type taxController struct {
+	taxRules service.TaxService
+}
+
+func NewTaxController(taxRules service.TaxService) Controller {
+	return &taxController{
+		taxRules: taxRules,
+	}
+}
+
+func (t *taxController) Register(r *gin.Engine) {
+	taxRulesGroup := r.Group("/api/tax-rules")
+	taxRulesGroup.POST("", t.Create)
+	taxRulesGroup.GET("", t.List)
+	taxRulesGroup.GET("/:id", t.Get)
+	taxRulesGroup.PATCH("/:id", t.Update)
+	taxRulesGroup.DELETE("/:id", t.Delete)
+}
+
+func (t *taxController) Create(context *gin.Context) {
+	var err error
+
+	createReq := models.TaxRuleCreateRequest{}
+	err = context.ShouldBindBodyWithJSON(&createReq)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": errors.Join(err, errors.New("failed to parse body")).Error()})
+		return
+	}
+
+	err = validate.Struct(createReq)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rule, err := t.taxRules.CreateTaxRule(context, createReq)
+	if err != nil {
+		context.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{"tax_rule": rule})
+}
+
+func (t *taxController) List(context *gin.Context) {
+	filter := models.TaxRuleFilter{
+		Limit: defaultListLimit,
+	}
+	err := context.ShouldBindQuery(&filter)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": errors.Join(err, errors.New("failed to parse query")).Error()})
+		return
+	}
+
+	err = validate.Struct(filter)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rules, err := t.taxRules.ListTaxRules(context, filter)
+	if err != nil {
+		context.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{"tax_rules": rules})
+}
+
+func (t *taxController) Get(context *gin.Context) {
+	rule, err := t.taxRules.GetTaxRule(context, context.Param("id"))
+	if err != nil {
+		context.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{"tax_rule": rule})
+}
+
+func (t *taxController) Update(context *gin.Context) {
+	var err error
+
+	updateReq := models.TaxRuleUpdateRequest{}
+	err = context.ShouldBindBodyWithJSON(&updateReq)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": errors.Join(err, errors.New("failed to parse body")).Error()})
+		return
+	}
+
+	err = validate.Struct(updateReq)
+	if err != nil {
+		context.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rule, err := t.taxRules.UpdateTaxRule(context, context.Param("id"), updateReq)
+	if err != nil {
+		context.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{"tax_rule": rule})
+}
+
+func (t *taxController) Delete(context *gin.Context) {
+	err := t.taxRules.DeleteTaxRule(context, context.Param("id"))
+	if err != nil {
+		context.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	context.Status(http.StatusNoContent)
+}
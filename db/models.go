@@ -14,10 +14,13 @@ import (
 type OrderStatus string
 
 const (
-	OrderStatusNew        OrderStatus = "new"
-	OrderStatusProcessing OrderStatus = "processing"
-	OrderStatusCompleted  OrderStatus = "completed"
-	OrderStatusCancelled  OrderStatus = "cancelled"
+	OrderStatusNew              OrderStatus = "new"
+	OrderStatusProcessing       OrderStatus = "processing"
+	OrderStatusCompleted        OrderStatus = "completed"
+	OrderStatusCancelled        OrderStatus = "cancelled"
+	OrderStatusPending          OrderStatus = "pending"
+	OrderStatusPartiallyShipped OrderStatus = "partially_shipped"
+	OrderStatusShipped          OrderStatus = "shipped"
 )
 
 func (e *OrderStatus) Scan(src interface{}) error {
@@ -55,22 +58,622 @@ func (ns NullOrderStatus) Value() (driver.Value, error) {
 	return string(ns.OrderStatus), nil
 }
 
-type Order struct {
+type PaymentStatus string
+
+const (
+	PaymentStatusAuthorized PaymentStatus = "authorized"
+	PaymentStatusCaptured   PaymentStatus = "captured"
+	PaymentStatusVoided     PaymentStatus = "voided"
+	PaymentStatusRefunded   PaymentStatus = "refunded"
+	PaymentStatusFailed     PaymentStatus = "failed"
+)
+
+func (e *PaymentStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = PaymentStatus(s)
+	case string:
+		*e = PaymentStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for PaymentStatus: %T", src)
+	}
+	return nil
+}
+
+type NullPaymentStatus struct {
+	PaymentStatus PaymentStatus
+	Valid         bool // Valid is true if PaymentStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullPaymentStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.PaymentStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.PaymentStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullPaymentStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.PaymentStatus), nil
+}
+
+type PromotionKind string
+
+const (
+	PromotionKindPercentage PromotionKind = "percentage"
+	PromotionKindFixed      PromotionKind = "fixed"
+	PromotionKindBuyXGetY   PromotionKind = "buy_x_get_y"
+)
+
+func (e *PromotionKind) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = PromotionKind(s)
+	case string:
+		*e = PromotionKind(s)
+	default:
+		return fmt.Errorf("unsupported scan type for PromotionKind: %T", src)
+	}
+	return nil
+}
+
+type NullPromotionKind struct {
+	PromotionKind PromotionKind
+	Valid         bool // Valid is true if PromotionKind is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullPromotionKind) Scan(value interface{}) error {
+	if value == nil {
+		ns.PromotionKind, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.PromotionKind.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullPromotionKind) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.PromotionKind), nil
+}
+
+type PromotionScope string
+
+const (
+	PromotionScopeOrder   PromotionScope = "order"
+	PromotionScopeProduct PromotionScope = "product"
+)
+
+func (e *PromotionScope) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = PromotionScope(s)
+	case string:
+		*e = PromotionScope(s)
+	default:
+		return fmt.Errorf("unsupported scan type for PromotionScope: %T", src)
+	}
+	return nil
+}
+
+type NullPromotionScope struct {
+	PromotionScope PromotionScope
+	Valid          bool // Valid is true if PromotionScope is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullPromotionScope) Scan(value interface{}) error {
+	if value == nil {
+		ns.PromotionScope, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.PromotionScope.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullPromotionScope) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.PromotionScope), nil
+}
+
+type ReservationStatus string
+
+const (
+	ReservationStatusReserved  ReservationStatus = "reserved"
+	ReservationStatusConfirmed ReservationStatus = "confirmed"
+	ReservationStatusReleased  ReservationStatus = "released"
+)
+
+func (e *ReservationStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ReservationStatus(s)
+	case string:
+		*e = ReservationStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ReservationStatus: %T", src)
+	}
+	return nil
+}
+
+type NullReservationStatus struct {
+	ReservationStatus ReservationStatus
+	Valid             bool // Valid is true if ReservationStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullReservationStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.ReservationStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ReservationStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullReservationStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ReservationStatus), nil
+}
+
+type ReturnStatus string
+
+const (
+	ReturnStatusRequested ReturnStatus = "requested"
+	ReturnStatusApproved  ReturnStatus = "approved"
+	ReturnStatusRejected  ReturnStatus = "rejected"
+	ReturnStatusReceived  ReturnStatus = "received"
+	ReturnStatusRefunded  ReturnStatus = "refunded"
+)
+
+func (e *ReturnStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ReturnStatus(s)
+	case string:
+		*e = ReturnStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ReturnStatus: %T", src)
+	}
+	return nil
+}
+
+type NullReturnStatus struct {
+	ReturnStatus ReturnStatus
+	Valid        bool // Valid is true if ReturnStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullReturnStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.ReturnStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ReturnStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullReturnStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ReturnStatus), nil
+}
+
+type SagaStep string
+
+const (
+	SagaStepStarted        SagaStep = "started"
+	SagaStepStockRemoved   SagaStep = "stock_removed"
+	SagaStepOrderPersisted SagaStep = "order_persisted"
+	SagaStepCompensated    SagaStep = "compensated"
+	SagaStepFailed         SagaStep = "failed"
+)
+
+func (e *SagaStep) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = SagaStep(s)
+	case string:
+		*e = SagaStep(s)
+	default:
+		return fmt.Errorf("unsupported scan type for SagaStep: %T", src)
+	}
+	return nil
+}
+
+type NullSagaStep struct {
+	SagaStep SagaStep
+	Valid    bool // Valid is true if SagaStep is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullSagaStep) Scan(value interface{}) error {
+	if value == nil {
+		ns.SagaStep, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.SagaStep.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullSagaStep) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.SagaStep), nil
+}
+
+type ScheduledPriceStatus string
+
+const (
+	ScheduledPriceStatusPending   ScheduledPriceStatus = "pending"
+	ScheduledPriceStatusApplied   ScheduledPriceStatus = "applied"
+	ScheduledPriceStatusCancelled ScheduledPriceStatus = "cancelled"
+	ScheduledPriceStatusFailed    ScheduledPriceStatus = "failed"
+)
+
+func (e *ScheduledPriceStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ScheduledPriceStatus(s)
+	case string:
+		*e = ScheduledPriceStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ScheduledPriceStatus: %T", src)
+	}
+	return nil
+}
+
+type NullScheduledPriceStatus struct {
+	ScheduledPriceStatus ScheduledPriceStatus
+	Valid                bool // Valid is true if ScheduledPriceStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullScheduledPriceStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.ScheduledPriceStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ScheduledPriceStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullScheduledPriceStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ScheduledPriceStatus), nil
+}
+
+type ShipmentStatus string
+
+const (
+	ShipmentStatusPending   ShipmentStatus = "pending"
+	ShipmentStatusShipped   ShipmentStatus = "shipped"
+	ShipmentStatusDelivered ShipmentStatus = "delivered"
+	ShipmentStatusCancelled ShipmentStatus = "cancelled"
+)
+
+func (e *ShipmentStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ShipmentStatus(s)
+	case string:
+		*e = ShipmentStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ShipmentStatus: %T", src)
+	}
+	return nil
+}
+
+type NullShipmentStatus struct {
+	ShipmentStatus ShipmentStatus
+	Valid          bool // Valid is true if ShipmentStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullShipmentStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.ShipmentStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ShipmentStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullShipmentStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ShipmentStatus), nil
+}
+
+type ShippingRule string
+
+const (
+	ShippingRuleFlat     ShippingRule = "flat"
+	ShippingRuleWeight   ShippingRule = "weight"
+	ShippingRuleFreeOver ShippingRule = "free_over"
+)
+
+func (e *ShippingRule) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ShippingRule(s)
+	case string:
+		*e = ShippingRule(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ShippingRule: %T", src)
+	}
+	return nil
+}
+
+type NullShippingRule struct {
+	ShippingRule ShippingRule
+	Valid        bool // Valid is true if ShippingRule is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullShippingRule) Scan(value interface{}) error {
+	if value == nil {
+		ns.ShippingRule, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ShippingRule.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullShippingRule) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ShippingRule), nil
+}
+
+type StockReturnStatus string
+
+const (
+	StockReturnStatusPending    StockReturnStatus = "pending"
+	StockReturnStatusInProgress StockReturnStatus = "in_progress"
+	StockReturnStatusCompleted  StockReturnStatus = "completed"
+	StockReturnStatusFailed     StockReturnStatus = "failed"
+)
+
+func (e *StockReturnStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = StockReturnStatus(s)
+	case string:
+		*e = StockReturnStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for StockReturnStatus: %T", src)
+	}
+	return nil
+}
+
+type NullStockReturnStatus struct {
+	StockReturnStatus StockReturnStatus
+	Valid             bool // Valid is true if StockReturnStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullStockReturnStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.StockReturnStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.StockReturnStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullStockReturnStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.StockReturnStatus), nil
+}
+
+type Customer struct {
+	Uuid                       pgtype.UUID
+	TenantID                   string
+	UserID                     string
+	Name                       string
+	Email                      string
+	Phone                      string
+	CreatedAt                  pgtype.Timestamp
+	UpdatedAt                  pgtype.Timestamp
+	DefaultShippingAddressUuid pgtype.UUID
+}
+
+type CustomerAddress struct {
 	Uuid         pgtype.UUID
-	Comment      pgtype.Text
-	UserID       string
-	StaffID      string
-	OrderCost    pgtype.Numeric
-	CreationDate pgtype.Timestamp
-	FinishDate   pgtype.Timestamp
-	Status       OrderStatus
+	CustomerUuid pgtype.UUID
+	Label        string
+	Recipient    string
+	Line1        string
+	Line2        string
+	City         string
+	Region       string
+	PostalCode   string
+	Country      string
+	Phone        string
+	CreatedAt    pgtype.Timestamp
+}
+
+type IdempotencyKey struct {
+	Scope       string
+	Key         string
+	Fingerprint string
+	Response    []byte
+	CreatedAt   pgtype.Timestamp
+	ExpiresAt   pgtype.Timestamp
+}
+
+type Invoice struct {
+	Uuid      pgtype.UUID
+	OrderUuid pgtype.UUID
+	Number    int64
+	Snapshot  []byte
+	IssuedAt  pgtype.Timestamp
+	TenantID  string
+}
+
+type InvoiceNumber struct {
+	TenantID   string
+	LastNumber int64
+}
+
+type Order struct {
+	Uuid               pgtype.UUID
+	Comment            pgtype.Text
+	UserID             string
+	StaffID            string
+	OrderCost          pgtype.Numeric
+	CreationDate       pgtype.Timestamp
+	FinishDate         pgtype.Timestamp
+	Status             OrderStatus
+	Currency           string
+	Discount           pgtype.Numeric
+	PromotionUuids     []pgtype.UUID
+	Region             string
+	NetAmount          pgtype.Numeric
+	TaxAmount          pgtype.Numeric
+	TenantID           string
+	ShippingMethodUuid pgtype.UUID
+	ShippingCost       pgtype.Numeric
+	ShippingAddress    []byte
+	BillingAddress     []byte
+	StockTaken         bool
+}
+
+type OrderOutbox struct {
+	ID          int64
+	EventUuid   pgtype.UUID
+	EventType   string
+	OrderUuid   pgtype.UUID
+	Payload     []byte
+	CreatedAt   pgtype.Timestamp
+	PublishedAt pgtype.Timestamp
+	Attempts    int32
+	LastError   pgtype.Text
+	TenantID    string
 }
 
 type OrderProduct struct {
-	ProductUuid pgtype.UUID
+	ProductUuid      pgtype.UUID
+	OrderUuid        pgtype.UUID
+	ResultPrice      pgtype.Numeric
+	Amount           int32
+	ListPrice        pgtype.Numeric
+	Discount         pgtype.Numeric
+	PromotionUuids   []pgtype.UUID
+	TaxRate          pgtype.Numeric
+	TaxAmount        pgtype.Numeric
+	TaxInclusive     bool
+	PriceHistoryUuid pgtype.UUID
+	TenantID         string
+}
+
+type OrderReservation struct {
+	Uuid        pgtype.UUID
 	OrderUuid   pgtype.UUID
-	ResultPrice pgtype.Numeric
+	ProductCode pgtype.UUID
 	Amount      int32
+	Status      ReservationStatus
+	StockHeld   bool
+	ExpiresAt   pgtype.Timestamp
+	CreatedAt   pgtype.Timestamp
+	UpdatedAt   pgtype.Timestamp
+}
+
+type OrderReturn struct {
+	Uuid          pgtype.UUID
+	OrderUuid     pgtype.UUID
+	Status        ReturnStatus
+	RefundAmount  pgtype.Numeric
+	Currency      string
+	StockReturned bool
+	CreatedBy     string
+	Reason        string
+	CreatedAt     pgtype.Timestamp
+	UpdatedAt     pgtype.Timestamp
+	RefundedAt    pgtype.Timestamp
+}
+
+type OrderReturnLine struct {
+	ReturnUuid   pgtype.UUID
+	ProductUuid  pgtype.UUID
+	OrderUuid    pgtype.UUID
+	Amount       int32
+	RefundAmount pgtype.Numeric
+}
+
+type OrderSaga struct {
+	Uuid      pgtype.UUID
+	OrderUuid pgtype.UUID
+	Step      SagaStep
+	Products  []byte
+	LastError pgtype.Text
+	CreatedAt pgtype.Timestamp
+	UpdatedAt pgtype.Timestamp
+	TenantID  string
+}
+
+type OrderStatusHistory struct {
+	Uuid       pgtype.UUID
+	OrderUuid  pgtype.UUID
+	FromStatus OrderStatus
+	ToStatus   OrderStatus
+	Actor      string
+	Reason     pgtype.Text
+	ChangedAt  pgtype.Timestamp
+}
+
+type OrderStockReturn struct {
+	OrderUuid pgtype.UUID
+	Status    StockReturnStatus
+	Attempts  int32
+	LastError pgtype.Text
+	CreatedAt pgtype.Timestamp
+	UpdatedAt pgtype.Timestamp
+}
+
+type Payment struct {
+	Uuid           pgtype.UUID
+	OrderUuid      pgtype.UUID
+	Provider       string
+	Reference      string
+	Status         PaymentStatus
+	Amount         pgtype.Numeric
+	Currency       string
+	CapturedAmount pgtype.Numeric
+	RefundedAmount pgtype.Numeric
+	Error          string
+	CreatedAt      pgtype.Timestamp
+	UpdatedAt      pgtype.Timestamp
 }
 
 type Product struct {
@@ -78,4 +681,99 @@ type Product struct {
 	Name         string
 	ProductCode  pgtype.UUID
 	CustomerCost pgtype.Numeric
+	Currency     string
+	TaxClass     string
+	TenantID     string
+	WeightGrams  int32
+}
+
+type ProductPriceHistory struct {
+	Uuid          pgtype.UUID
+	ProductUuid   pgtype.UUID
+	CustomerCost  pgtype.Numeric
+	Currency      string
+	EffectiveFrom pgtype.Timestamp
+	EffectiveTo   pgtype.Timestamp
+	ChangedBy     string
+	Reason        string
+}
+
+type Promotion struct {
+	Uuid         pgtype.UUID
+	Code         pgtype.Text
+	Name         string
+	Kind         PromotionKind
+	Scope        PromotionScope
+	ProductCode  pgtype.UUID
+	Percent      pgtype.Numeric
+	Amount       pgtype.Numeric
+	Currency     pgtype.Text
+	BuyQuantity  pgtype.Int4
+	FreeQuantity pgtype.Int4
+	UsageLimit   pgtype.Int4
+	UsageCount   int32
+	ValidFrom    pgtype.Timestamp
+	ValidTo      pgtype.Timestamp
+	Active       bool
+	CreatedAt    pgtype.Timestamp
+	TenantID     string
+}
+
+type ScheduledPriceChange struct {
+	Uuid             pgtype.UUID
+	ProductUuid      pgtype.UUID
+	CustomerCost     pgtype.Numeric
+	Currency         pgtype.Text
+	RevertOf         pgtype.UUID
+	RunAt            pgtype.Timestamp
+	Status           ScheduledPriceStatus
+	PreviousCost     pgtype.Numeric
+	PreviousCurrency pgtype.Text
+	CreatedBy        string
+	Reason           string
+	Error            string
+	CreatedAt        pgtype.Timestamp
+	AppliedAt        pgtype.Timestamp
+}
+
+type Shipment struct {
+	Uuid           pgtype.UUID
+	OrderUuid      pgtype.UUID
+	Carrier        string
+	TrackingNumber string
+	Status         ShipmentStatus
+	CreatedAt      pgtype.Timestamp
+	ShippedAt      pgtype.Timestamp
+	DeliveredAt    pgtype.Timestamp
+}
+
+type ShipmentLine struct {
+	ShipmentUuid pgtype.UUID
+	ProductUuid  pgtype.UUID
+	OrderUuid    pgtype.UUID
+	Amount       int32
+}
+
+type ShippingMethod struct {
+	Uuid          pgtype.UUID
+	TenantID      string
+	Code          string
+	Name          string
+	Rule          ShippingRule
+	Currency      string
+	Cost          pgtype.Numeric
+	CostPerKg     pgtype.Numeric
+	FreeThreshold pgtype.Numeric
+	Active        bool
+	CreatedAt     pgtype.Timestamp
+}
+
+type TaxRule struct {
+	Uuid      pgtype.UUID
+	TaxClass  string
+	Region    string
+	Rate      pgtype.Numeric
+	Inclusive bool
+	CreatedAt pgtype.Timestamp
+	TenantID  string
 }
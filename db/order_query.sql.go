@@ -11,28 +11,96 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const addOrderStatusHistory = `-- name: AddOrderStatusHistory :one
+INSERT INTO order_status_history (
+    uuid, order_uuid, from_status, to_status, actor, reason
+) VALUES (
+             $1, $2, $3, $4, $5, $6
+         )
+    RETURNING uuid, order_uuid, from_status, to_status, actor, reason, changed_at
+`
+
+type AddOrderStatusHistoryParams struct {
+	Uuid       pgtype.UUID
+	OrderUuid  pgtype.UUID
+	FromStatus OrderStatus
+	ToStatus   OrderStatus
+	Actor      string
+	Reason     pgtype.Text
+}
+
+func (q *Queries) AddOrderStatusHistory(ctx context.Context, arg AddOrderStatusHistoryParams) (OrderStatusHistory, error) {
+	row := q.db.QueryRow(ctx, addOrderStatusHistory,
+		arg.Uuid,
+		arg.OrderUuid,
+		arg.FromStatus,
+		arg.ToStatus,
+		arg.Actor,
+		arg.Reason,
+	)
+	var i OrderStatusHistory
+	err := row.Scan(
+		&i.Uuid,
+		&i.OrderUuid,
+		&i.FromStatus,
+		&i.ToStatus,
+		&i.Actor,
+		&i.Reason,
+		&i.ChangedAt,
+	)
+	return i, err
+}
+
 const addProductToOrder = `-- name: AddProductToOrder :one
 INSERT INTO order_products (
-    product_uuid, order_uuid, result_price, amount
+    product_uuid, order_uuid, result_price, amount, list_price, discount, promotion_uuids,
+    tax_rate, tax_amount, tax_inclusive, price_history_uuid, tenant_id
 ) VALUES (
-             (select uuid from product where product_code = $1), $2, $3, $4
+             (select uuid from product where product_code = $1 AND tenant_id = $2),
+             $3,
+             $4,
+             $5,
+             COALESCE($6::decimal, $4),
+             COALESCE($7::decimal, 0),
+             COALESCE($8::uuid[], '{}'),
+             COALESCE($9::decimal, 0),
+             COALESCE($10::decimal, 0),
+             COALESCE($11::boolean, FALSE),
+             $12,
+             $2
          )
-    RETURNING product_uuid, order_uuid, result_price, amount
+    RETURNING product_uuid, order_uuid, result_price, amount, list_price, discount, promotion_uuids, tax_rate, tax_amount, tax_inclusive, price_history_uuid, tenant_id
 `
 
 type AddProductToOrderParams struct {
-	ProductCode pgtype.UUID
-	OrderUuid   pgtype.UUID
-	ResultPrice pgtype.Numeric
-	Amount      int32
+	ProductCode      pgtype.UUID
+	TenantID         string
+	OrderUuid        pgtype.UUID
+	ResultPrice      pgtype.Numeric
+	Amount           int32
+	ListPrice        pgtype.Numeric
+	Discount         pgtype.Numeric
+	PromotionUuids   []pgtype.UUID
+	TaxRate          pgtype.Numeric
+	TaxAmount        pgtype.Numeric
+	TaxInclusive     pgtype.Bool
+	PriceHistoryUuid pgtype.UUID
 }
 
 func (q *Queries) AddProductToOrder(ctx context.Context, arg AddProductToOrderParams) (OrderProduct, error) {
 	row := q.db.QueryRow(ctx, addProductToOrder,
 		arg.ProductCode,
+		arg.TenantID,
 		arg.OrderUuid,
 		arg.ResultPrice,
 		arg.Amount,
+		arg.ListPrice,
+		arg.Discount,
+		arg.PromotionUuids,
+		arg.TaxRate,
+		arg.TaxAmount,
+		arg.TaxInclusive,
+		arg.PriceHistoryUuid,
 	)
 	var i OrderProduct
 	err := row.Scan(
@@ -40,37 +108,76 @@ func (q *Queries) AddProductToOrder(ctx context.Context, arg AddProductToOrderPa
 		&i.OrderUuid,
 		&i.ResultPrice,
 		&i.Amount,
+		&i.ListPrice,
+		&i.Discount,
+		&i.PromotionUuids,
+		&i.TaxRate,
+		&i.TaxAmount,
+		&i.TaxInclusive,
+		&i.PriceHistoryUuid,
+		&i.TenantID,
 	)
 	return i, err
 }
 
 const calculateOrderTotal = `-- name: CalculateOrderTotal :one
-SELECT SUM(result_price * amount) as total FROM order_products
-WHERE order_uuid = $1
+SELECT COALESCE(SUM(result_price * amount - discount + CASE WHEN tax_inclusive THEN 0 ELSE tax_amount END), 0)::decimal as total
+FROM order_products
+WHERE order_uuid = $1 AND $2::varchar IN (tenant_id, '*')
 `
 
-func (q *Queries) CalculateOrderTotal(ctx context.Context, orderUuid pgtype.UUID) (int64, error) {
-	row := q.db.QueryRow(ctx, calculateOrderTotal, orderUuid)
-	var total int64
+type CalculateOrderTotalParams struct {
+	OrderUuid pgtype.UUID
+	TenantID  string
+}
+
+func (q *Queries) CalculateOrderTotal(ctx context.Context, arg CalculateOrderTotalParams) (pgtype.Numeric, error) {
+	row := q.db.QueryRow(ctx, calculateOrderTotal, arg.OrderUuid, arg.TenantID)
+	var total pgtype.Numeric
 	err := row.Scan(&total)
 	return total, err
 }
 
 const createOrder = `-- name: CreateOrder :one
 INSERT INTO orders (
-    uuid, comment, user_id, staff_id, order_cost
+    uuid, comment, user_id, staff_id, order_cost, currency, discount, promotion_uuids,
+    region, net_amount, tax_amount, shipping_method_uuid, shipping_cost, shipping_address,
+    billing_address, stock_taken, tenant_id
 ) VALUES (
-             $1, $2, $3, $4, $5
+             $1, $2, $3, $4, $5, $6,
+             COALESCE($7::decimal, 0),
+             COALESCE($8::uuid[], '{}'),
+             COALESCE($9::varchar, ''),
+             COALESCE($10::decimal, 0),
+             COALESCE($11::decimal, 0),
+             $12,
+             COALESCE($13::decimal, 0),
+             $14,
+             $15,
+             $16,
+             $17
          )
-    RETURNING uuid, comment, user_id, staff_id, order_cost, creation_date, finish_date, status
+    RETURNING uuid, comment, user_id, staff_id, order_cost, creation_date, finish_date, status, currency, discount, promotion_uuids, region, net_amount, tax_amount, tenant_id, shipping_method_uuid, shipping_cost, shipping_address, billing_address, stock_taken
 `
 
 type CreateOrderParams struct {
-	Uuid      pgtype.UUID
-	Comment   pgtype.Text
-	UserID    string
-	StaffID   string
-	OrderCost pgtype.Numeric
+	Uuid               pgtype.UUID
+	Comment            pgtype.Text
+	UserID             string
+	StaffID            string
+	OrderCost          pgtype.Numeric
+	Currency           string
+	Discount           pgtype.Numeric
+	PromotionUuids     []pgtype.UUID
+	Region             pgtype.Text
+	NetAmount          pgtype.Numeric
+	TaxAmount          pgtype.Numeric
+	ShippingMethodUuid pgtype.UUID
+	ShippingCost       pgtype.Numeric
+	ShippingAddress    []byte
+	BillingAddress     []byte
+	StockTaken         bool
+	TenantID           string
 }
 
 func (q *Queries) CreateOrder(ctx context.Context, arg CreateOrderParams) (Order, error) {
@@ -80,6 +187,18 @@ func (q *Queries) CreateOrder(ctx context.Context, arg CreateOrderParams) (Order
 		arg.UserID,
 		arg.StaffID,
 		arg.OrderCost,
+		arg.Currency,
+		arg.Discount,
+		arg.PromotionUuids,
+		arg.Region,
+		arg.NetAmount,
+		arg.TaxAmount,
+		arg.ShippingMethodUuid,
+		arg.ShippingCost,
+		arg.ShippingAddress,
+		arg.BillingAddress,
+		arg.StockTaken,
+		arg.TenantID,
 	)
 	var i Order
 	err := row.Scan(
@@ -91,36 +210,152 @@ func (q *Queries) CreateOrder(ctx context.Context, arg CreateOrderParams) (Order
 		&i.CreationDate,
 		&i.FinishDate,
 		&i.Status,
+		&i.Currency,
+		&i.Discount,
+		&i.PromotionUuids,
+		&i.Region,
+		&i.NetAmount,
+		&i.TaxAmount,
+		&i.TenantID,
+		&i.ShippingMethodUuid,
+		&i.ShippingCost,
+		&i.ShippingAddress,
+		&i.BillingAddress,
+		&i.StockTaken,
+	)
+	return i, err
+}
+
+const createPendingOrder = `-- name: CreatePendingOrder :one
+INSERT INTO orders (
+    uuid, comment, user_id, staff_id, order_cost, currency, discount, promotion_uuids,
+    region, net_amount, tax_amount, shipping_method_uuid, shipping_cost, shipping_address,
+    billing_address, stock_taken, status, tenant_id
+) VALUES (
+             $1, $2, $3, $4, $5, $6,
+             COALESCE($7::decimal, 0),
+             COALESCE($8::uuid[], '{}'),
+             COALESCE($9::varchar, ''),
+             COALESCE($10::decimal, 0),
+             COALESCE($11::decimal, 0),
+             $12,
+             COALESCE($13::decimal, 0),
+             $14,
+             $15,
+             $16,
+             'pending',
+             $17
+         )
+    RETURNING uuid, comment, user_id, staff_id, order_cost, creation_date, finish_date, status, currency, discount, promotion_uuids, region, net_amount, tax_amount, tenant_id, shipping_method_uuid, shipping_cost, shipping_address, billing_address, stock_taken
+`
+
+type CreatePendingOrderParams struct {
+	Uuid               pgtype.UUID
+	Comment            pgtype.Text
+	UserID             string
+	StaffID            string
+	OrderCost          pgtype.Numeric
+	Currency           string
+	Discount           pgtype.Numeric
+	PromotionUuids     []pgtype.UUID
+	Region             pgtype.Text
+	NetAmount          pgtype.Numeric
+	TaxAmount          pgtype.Numeric
+	ShippingMethodUuid pgtype.UUID
+	ShippingCost       pgtype.Numeric
+	ShippingAddress    []byte
+	BillingAddress     []byte
+	StockTaken         bool
+	TenantID           string
+}
+
+func (q *Queries) CreatePendingOrder(ctx context.Context, arg CreatePendingOrderParams) (Order, error) {
+	row := q.db.QueryRow(ctx, createPendingOrder,
+		arg.Uuid,
+		arg.Comment,
+		arg.UserID,
+		arg.StaffID,
+		arg.OrderCost,
+		arg.Currency,
+		arg.Discount,
+		arg.PromotionUuids,
+		arg.Region,
+		arg.NetAmount,
+		arg.TaxAmount,
+		arg.ShippingMethodUuid,
+		arg.ShippingCost,
+		arg.ShippingAddress,
+		arg.BillingAddress,
+		arg.StockTaken,
+		arg.TenantID,
+	)
+	var i Order
+	err := row.Scan(
+		&i.Uuid,
+		&i.Comment,
+		&i.UserID,
+		&i.StaffID,
+		&i.OrderCost,
+		&i.CreationDate,
+		&i.FinishDate,
+		&i.Status,
+		&i.Currency,
+		&i.Discount,
+		&i.PromotionUuids,
+		&i.Region,
+		&i.NetAmount,
+		&i.TaxAmount,
+		&i.TenantID,
+		&i.ShippingMethodUuid,
+		&i.ShippingCost,
+		&i.ShippingAddress,
+		&i.BillingAddress,
+		&i.StockTaken,
 	)
 	return i, err
 }
 
 const deleteOrder = `-- name: DeleteOrder :exec
 DELETE FROM orders
-WHERE uuid = $1
+WHERE uuid = $1 AND $2::varchar IN (tenant_id, '*')
 `
 
-func (q *Queries) DeleteOrder(ctx context.Context, uuid pgtype.UUID) error {
-	_, err := q.db.Exec(ctx, deleteOrder, uuid)
+type DeleteOrderParams struct {
+	Uuid     pgtype.UUID
+	TenantID string
+}
+
+func (q *Queries) DeleteOrder(ctx context.Context, arg DeleteOrderParams) error {
+	_, err := q.db.Exec(ctx, deleteOrder, arg.Uuid, arg.TenantID)
 	return err
 }
 
 const deleteOrderProducts = `-- name: DeleteOrderProducts :exec
-DELETE FROM order_products where order_uuid = $1
+DELETE FROM order_products where order_uuid = $1 AND $2::varchar IN (tenant_id, '*')
 `
 
-func (q *Queries) DeleteOrderProducts(ctx context.Context, orderUuid pgtype.UUID) error {
-	_, err := q.db.Exec(ctx, deleteOrderProducts, orderUuid)
+type DeleteOrderProductsParams struct {
+	OrderUuid pgtype.UUID
+	TenantID  string
+}
+
+func (q *Queries) DeleteOrderProducts(ctx context.Context, arg DeleteOrderProductsParams) error {
+	_, err := q.db.Exec(ctx, deleteOrderProducts, arg.OrderUuid, arg.TenantID)
 	return err
 }
 
 const getOrder = `-- name: GetOrder :one
-SELECT uuid, comment, user_id, staff_id, order_cost, creation_date, finish_date, status FROM orders
-WHERE uuid = $1 LIMIT 1
+SELECT uuid, comment, user_id, staff_id, order_cost, creation_date, finish_date, status, currency, discount, promotion_uuids, region, net_amount, tax_amount, tenant_id, shipping_method_uuid, shipping_cost, shipping_address, billing_address, stock_taken FROM orders
+WHERE uuid = $1 AND $2::varchar IN (tenant_id, '*') LIMIT 1
 `
 
-func (q *Queries) GetOrder(ctx context.Context, uuid pgtype.UUID) (Order, error) {
-	row := q.db.QueryRow(ctx, getOrder, uuid)
+type GetOrderParams struct {
+	Uuid     pgtype.UUID
+	TenantID string
+}
+
+func (q *Queries) GetOrder(ctx context.Context, arg GetOrderParams) (Order, error) {
+	row := q.db.QueryRow(ctx, getOrder, arg.Uuid, arg.TenantID)
 	var i Order
 	err := row.Scan(
 		&i.Uuid,
@@ -131,27 +366,52 @@ func (q *Queries) GetOrder(ctx context.Context, uuid pgtype.UUID) (Order, error)
 		&i.CreationDate,
 		&i.FinishDate,
 		&i.Status,
+		&i.Currency,
+		&i.Discount,
+		&i.PromotionUuids,
+		&i.Region,
+		&i.NetAmount,
+		&i.TaxAmount,
+		&i.TenantID,
+		&i.ShippingMethodUuid,
+		&i.ShippingCost,
+		&i.ShippingAddress,
+		&i.BillingAddress,
+		&i.StockTaken,
 	)
 	return i, err
 }
 
 const getOrderProducts = `-- name: GetOrderProducts :many
-SELECT op.product_uuid, op.order_uuid, op.result_price, op.amount, p.name as product_name, p.product_code FROM order_products op
+SELECT op.product_uuid, op.order_uuid, op.result_price, op.amount, op.list_price, op.discount, op.promotion_uuids, op.tax_rate, op.tax_amount, op.tax_inclusive, op.price_history_uuid, op.tenant_id, p.name as product_name, p.product_code FROM order_products op
                                                              JOIN product p ON op.product_uuid = p.uuid
-WHERE op.order_uuid = $1
+WHERE op.order_uuid = $1 AND $2::varchar IN (op.tenant_id, '*')
 `
 
+type GetOrderProductsParams struct {
+	OrderUuid pgtype.UUID
+	TenantID  string
+}
+
 type GetOrderProductsRow struct {
-	ProductUuid pgtype.UUID
-	OrderUuid   pgtype.UUID
-	ResultPrice pgtype.Numeric
-	Amount      int32
-	ProductName string
-	ProductCode pgtype.UUID
+	ProductUuid      pgtype.UUID
+	OrderUuid        pgtype.UUID
+	ResultPrice      pgtype.Numeric
+	Amount           int32
+	ListPrice        pgtype.Numeric
+	Discount         pgtype.Numeric
+	PromotionUuids   []pgtype.UUID
+	TaxRate          pgtype.Numeric
+	TaxAmount        pgtype.Numeric
+	TaxInclusive     bool
+	PriceHistoryUuid pgtype.UUID
+	TenantID         string
+	ProductName      string
+	ProductCode      pgtype.UUID
 }
 
-func (q *Queries) GetOrderProducts(ctx context.Context, orderUuid pgtype.UUID) ([]GetOrderProductsRow, error) {
-	rows, err := q.db.Query(ctx, getOrderProducts, orderUuid)
+func (q *Queries) GetOrderProducts(ctx context.Context, arg GetOrderProductsParams) ([]GetOrderProductsRow, error) {
+	rows, err := q.db.Query(ctx, getOrderProducts, arg.OrderUuid, arg.TenantID)
 	if err != nil {
 		return nil, err
 	}
@@ -164,6 +424,14 @@ func (q *Queries) GetOrderProducts(ctx context.Context, orderUuid pgtype.UUID) (
 			&i.OrderUuid,
 			&i.ResultPrice,
 			&i.Amount,
+			&i.ListPrice,
+			&i.Discount,
+			&i.PromotionUuids,
+			&i.TaxRate,
+			&i.TaxAmount,
+			&i.TaxInclusive,
+			&i.PriceHistoryUuid,
+			&i.TenantID,
 			&i.ProductName,
 			&i.ProductCode,
 		); err != nil {
@@ -177,21 +445,71 @@ func (q *Queries) GetOrderProducts(ctx context.Context, orderUuid pgtype.UUID) (
 	return items, nil
 }
 
+const listOrderStatusHistory = `-- name: ListOrderStatusHistory :many
+SELECT uuid, order_uuid, from_status, to_status, actor, reason, changed_at FROM order_status_history
+WHERE order_uuid = $1
+  AND order_uuid IN (select o.uuid from orders o where $2::varchar IN (o.tenant_id, '*'))
+ORDER BY changed_at
+`
+
+type ListOrderStatusHistoryParams struct {
+	OrderUuid pgtype.UUID
+	TenantID  string
+}
+
+func (q *Queries) ListOrderStatusHistory(ctx context.Context, arg ListOrderStatusHistoryParams) ([]OrderStatusHistory, error) {
+	rows, err := q.db.Query(ctx, listOrderStatusHistory, arg.OrderUuid, arg.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []OrderStatusHistory
+	for rows.Next() {
+		var i OrderStatusHistory
+		if err := rows.Scan(
+			&i.Uuid,
+			&i.OrderUuid,
+			&i.FromStatus,
+			&i.ToStatus,
+			&i.Actor,
+			&i.Reason,
+			&i.ChangedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listOrders = `-- name: ListOrders :many
-SELECT uuid, comment, user_id, staff_id, order_cost, creation_date, finish_date, status FROM orders
-where status = $1
+SELECT uuid, comment, user_id, staff_id, order_cost, creation_date, finish_date, status, currency, discount, promotion_uuids, region, net_amount, tax_amount, tenant_id, shipping_method_uuid, shipping_cost, shipping_address, billing_address, stock_taken FROM orders
+where ($1::order_status IS NULL OR status = $1)
+  AND ($2::varchar IS NULL OR user_id = $2)
+  AND $3::varchar IN (tenant_id, '*')
 ORDER BY creation_date DESC
-limit $2 offset $3
+limit $5 offset $4
 `
 
 type ListOrdersParams struct {
-	Status OrderStatus
-	Limit  int32
-	Offset int32
+	Status   NullOrderStatus
+	UserID   pgtype.Text
+	TenantID string
+	Offset   int32
+	Limit    int32
 }
 
 func (q *Queries) ListOrders(ctx context.Context, arg ListOrdersParams) ([]Order, error) {
-	rows, err := q.db.Query(ctx, listOrders, arg.Status, arg.Limit, arg.Offset)
+	rows, err := q.db.Query(ctx, listOrders,
+		arg.Status,
+		arg.UserID,
+		arg.TenantID,
+		arg.Offset,
+		arg.Limit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -208,6 +526,18 @@ func (q *Queries) ListOrders(ctx context.Context, arg ListOrdersParams) ([]Order
 			&i.CreationDate,
 			&i.FinishDate,
 			&i.Status,
+			&i.Currency,
+			&i.Discount,
+			&i.PromotionUuids,
+			&i.Region,
+			&i.NetAmount,
+			&i.TaxAmount,
+			&i.TenantID,
+			&i.ShippingMethodUuid,
+			&i.ShippingCost,
+			&i.ShippingAddress,
+			&i.BillingAddress,
+			&i.StockTaken,
 		); err != nil {
 			return nil, err
 		}
@@ -219,55 +549,105 @@ func (q *Queries) ListOrders(ctx context.Context, arg ListOrdersParams) ([]Order
 	return items, nil
 }
 
+const lockOrder = `-- name: LockOrder :one
+SELECT uuid, comment, user_id, staff_id, order_cost, creation_date, finish_date, status, currency, discount, promotion_uuids, region, net_amount, tax_amount, tenant_id, shipping_method_uuid, shipping_cost, shipping_address, billing_address, stock_taken FROM orders
+WHERE uuid = $1 AND $2::varchar IN (tenant_id, '*') LIMIT 1
+    FOR UPDATE
+`
+
+type LockOrderParams struct {
+	Uuid     pgtype.UUID
+	TenantID string
+}
+
+func (q *Queries) LockOrder(ctx context.Context, arg LockOrderParams) (Order, error) {
+	row := q.db.QueryRow(ctx, lockOrder, arg.Uuid, arg.TenantID)
+	var i Order
+	err := row.Scan(
+		&i.Uuid,
+		&i.Comment,
+		&i.UserID,
+		&i.StaffID,
+		&i.OrderCost,
+		&i.CreationDate,
+		&i.FinishDate,
+		&i.Status,
+		&i.Currency,
+		&i.Discount,
+		&i.PromotionUuids,
+		&i.Region,
+		&i.NetAmount,
+		&i.TaxAmount,
+		&i.TenantID,
+		&i.ShippingMethodUuid,
+		&i.ShippingCost,
+		&i.ShippingAddress,
+		&i.BillingAddress,
+		&i.StockTaken,
+	)
+	return i, err
+}
+
+const markOrderStockTaken = `-- name: MarkOrderStockTaken :exec
+UPDATE orders
+SET stock_taken = TRUE
+WHERE uuid = $1 AND $2::varchar IN (tenant_id, '*')
+`
+
+type MarkOrderStockTakenParams struct {
+	Uuid     pgtype.UUID
+	TenantID string
+}
+
+func (q *Queries) MarkOrderStockTaken(ctx context.Context, arg MarkOrderStockTakenParams) error {
+	_, err := q.db.Exec(ctx, markOrderStockTaken, arg.Uuid, arg.TenantID)
+	return err
+}
+
 const removeProductFromOrder = `-- name: RemoveProductFromOrder :exec
 DELETE FROM order_products
-WHERE product_uuid = $1 AND order_uuid = $2
+WHERE product_uuid = $1 AND order_uuid = $2 AND $3::varchar IN (tenant_id, '*')
 `
 
 type RemoveProductFromOrderParams struct {
 	ProductUuid pgtype.UUID
 	OrderUuid   pgtype.UUID
+	TenantID    string
 }
 
 func (q *Queries) RemoveProductFromOrder(ctx context.Context, arg RemoveProductFromOrderParams) error {
-	_, err := q.db.Exec(ctx, removeProductFromOrder, arg.ProductUuid, arg.OrderUuid)
+	_, err := q.db.Exec(ctx, removeProductFromOrder, arg.ProductUuid, arg.OrderUuid, arg.TenantID)
 	return err
 }
 
 const updateOrder = `-- name: UpdateOrder :one
 UPDATE orders
 SET
-    comment = COALESCE($2, comment),
-    user_id = COALESCE($3, user_id),
-    staff_id = COALESCE($4, staff_id),
-    order_cost = COALESCE($5, order_cost),
-    status = COALESCE($6, status),
-    finish_date = CASE
-                      WHEN $6 = 'completed' AND status != 'completed' THEN NOW()
-                      WHEN $6 != 'completed' THEN NULL
-                      ELSE finish_date
-        END
-WHERE uuid = $1
-    RETURNING uuid, comment, user_id, staff_id, order_cost, creation_date, finish_date, status
+    comment = COALESCE($1, comment),
+    user_id = COALESCE($2, user_id),
+    staff_id = COALESCE($3, staff_id),
+    order_cost = COALESCE($4, order_cost)
+WHERE uuid = $5 AND $6::varchar IN (tenant_id, '*')
+    RETURNING uuid, comment, user_id, staff_id, order_cost, creation_date, finish_date, status, currency, discount, promotion_uuids, region, net_amount, tax_amount, tenant_id, shipping_method_uuid, shipping_cost, shipping_address, billing_address, stock_taken
 `
 
 type UpdateOrderParams struct {
-	Uuid      pgtype.UUID
 	Comment   pgtype.Text
-	UserID    string
-	StaffID   string
+	UserID    pgtype.Text
+	StaffID   pgtype.Text
 	OrderCost pgtype.Numeric
-	Status    OrderStatus
+	Uuid      pgtype.UUID
+	TenantID  string
 }
 
 func (q *Queries) UpdateOrder(ctx context.Context, arg UpdateOrderParams) (Order, error) {
 	row := q.db.QueryRow(ctx, updateOrder,
-		arg.Uuid,
 		arg.Comment,
 		arg.UserID,
 		arg.StaffID,
 		arg.OrderCost,
-		arg.Status,
+		arg.Uuid,
+		arg.TenantID,
 	)
 	var i Order
 	err := row.Scan(
@@ -279,24 +659,155 @@ func (q *Queries) UpdateOrder(ctx context.Context, arg UpdateOrderParams) (Order
 		&i.CreationDate,
 		&i.FinishDate,
 		&i.Status,
+		&i.Currency,
+		&i.Discount,
+		&i.PromotionUuids,
+		&i.Region,
+		&i.NetAmount,
+		&i.TaxAmount,
+		&i.TenantID,
+		&i.ShippingMethodUuid,
+		&i.ShippingCost,
+		&i.ShippingAddress,
+		&i.BillingAddress,
+		&i.StockTaken,
+	)
+	return i, err
+}
+
+const updateOrderProduct = `-- name: UpdateOrderProduct :one
+UPDATE order_products
+SET amount = $1,
+    discount = $2,
+    tax_rate = $3,
+    tax_amount = $4,
+    tax_inclusive = $5
+WHERE order_uuid = $6
+  AND product_uuid = (select p.uuid from product p where p.product_code = $7 AND p.tenant_id = order_products.tenant_id)
+  AND $8::varchar IN (tenant_id, '*')
+    RETURNING product_uuid, order_uuid, result_price, amount, list_price, discount, promotion_uuids, tax_rate, tax_amount, tax_inclusive, price_history_uuid, tenant_id
+`
+
+type UpdateOrderProductParams struct {
+	Amount       int32
+	Discount     pgtype.Numeric
+	TaxRate      pgtype.Numeric
+	TaxAmount    pgtype.Numeric
+	TaxInclusive bool
+	OrderUuid    pgtype.UUID
+	ProductCode  pgtype.UUID
+	TenantID     string
+}
+
+func (q *Queries) UpdateOrderProduct(ctx context.Context, arg UpdateOrderProductParams) (OrderProduct, error) {
+	row := q.db.QueryRow(ctx, updateOrderProduct,
+		arg.Amount,
+		arg.Discount,
+		arg.TaxRate,
+		arg.TaxAmount,
+		arg.TaxInclusive,
+		arg.OrderUuid,
+		arg.ProductCode,
+		arg.TenantID,
+	)
+	var i OrderProduct
+	err := row.Scan(
+		&i.ProductUuid,
+		&i.OrderUuid,
+		&i.ResultPrice,
+		&i.Amount,
+		&i.ListPrice,
+		&i.Discount,
+		&i.PromotionUuids,
+		&i.TaxRate,
+		&i.TaxAmount,
+		&i.TaxInclusive,
+		&i.PriceHistoryUuid,
+		&i.TenantID,
 	)
 	return i, err
 }
 
 const updateOrderStatus = `-- name: UpdateOrderStatus :one
 UPDATE orders
-SET status = $2, finish_date = CASE WHEN $2 = 'completed' THEN NOW() ELSE finish_date END
-WHERE uuid = $1
-    RETURNING uuid, comment, user_id, staff_id, order_cost, creation_date, finish_date, status
+SET status = $1, finish_date = CASE WHEN $1 = 'completed' THEN NOW() ELSE finish_date END
+WHERE uuid = $2 AND status = $3
+  AND $4::varchar IN (tenant_id, '*')
+    RETURNING uuid, comment, user_id, staff_id, order_cost, creation_date, finish_date, status, currency, discount, promotion_uuids, region, net_amount, tax_amount, tenant_id, shipping_method_uuid, shipping_cost, shipping_address, billing_address, stock_taken
 `
 
 type UpdateOrderStatusParams struct {
-	Uuid   pgtype.UUID
-	Status OrderStatus
+	Status     OrderStatus
+	Uuid       pgtype.UUID
+	FromStatus OrderStatus
+	TenantID   string
 }
 
 func (q *Queries) UpdateOrderStatus(ctx context.Context, arg UpdateOrderStatusParams) (Order, error) {
-	row := q.db.QueryRow(ctx, updateOrderStatus, arg.Uuid, arg.Status)
+	row := q.db.QueryRow(ctx, updateOrderStatus,
+		arg.Status,
+		arg.Uuid,
+		arg.FromStatus,
+		arg.TenantID,
+	)
+	var i Order
+	err := row.Scan(
+		&i.Uuid,
+		&i.Comment,
+		&i.UserID,
+		&i.StaffID,
+		&i.OrderCost,
+		&i.CreationDate,
+		&i.FinishDate,
+		&i.Status,
+		&i.Currency,
+		&i.Discount,
+		&i.PromotionUuids,
+		&i.Region,
+		&i.NetAmount,
+		&i.TaxAmount,
+		&i.TenantID,
+		&i.ShippingMethodUuid,
+		&i.ShippingCost,
+		&i.ShippingAddress,
+		&i.BillingAddress,
+		&i.StockTaken,
+	)
+	return i, err
+}
+
+const updateOrderTotals = `-- name: UpdateOrderTotals :one
+UPDATE orders
+SET order_cost = $1,
+    discount = $2,
+    net_amount = $3,
+    tax_amount = $4,
+    shipping_cost = $5
+WHERE uuid = $6 AND status = 'new'
+  AND $7::varchar IN (tenant_id, '*')
+    RETURNING uuid, comment, user_id, staff_id, order_cost, creation_date, finish_date, status, currency, discount, promotion_uuids, region, net_amount, tax_amount, tenant_id, shipping_method_uuid, shipping_cost, shipping_address, billing_address, stock_taken
+`
+
+type UpdateOrderTotalsParams struct {
+	OrderCost    pgtype.Numeric
+	Discount     pgtype.Numeric
+	NetAmount    pgtype.Numeric
+	TaxAmount    pgtype.Numeric
+	ShippingCost pgtype.Numeric
+	Uuid         pgtype.UUID
+	TenantID     string
+}
+
+func (q *Queries) UpdateOrderTotals(ctx context.Context, arg UpdateOrderTotalsParams) (Order, error) {
+	row := q.db.QueryRow(ctx, updateOrderTotals,
+		arg.OrderCost,
+		arg.Discount,
+		arg.NetAmount,
+		arg.TaxAmount,
+		arg.ShippingCost,
+		arg.Uuid,
+		arg.TenantID,
+	)
 	var i Order
 	err := row.Scan(
 		&i.Uuid,
@@ -307,6 +818,18 @@ func (q *Queries) UpdateOrderStatus(ctx context.Context, arg UpdateOrderStatusPa
 		&i.CreationDate,
 		&i.FinishDate,
 		&i.Status,
+		&i.Currency,
+		&i.Discount,
+		&i.PromotionUuids,
+		&i.Region,
+		&i.NetAmount,
+		&i.TaxAmount,
+		&i.TenantID,
+		&i.ShippingMethodUuid,
+		&i.ShippingCost,
+		&i.ShippingAddress,
+		&i.BillingAddress,
+		&i.StockTaken,
 	)
 	return i, err
 }
@@ -12,9 +12,9 @@ import (
 )
 
 const createProduct = `-- name: CreateProduct :one
-INSERT INTO product (uuid, name, product_code, customer_cost)
-VALUES ($1, $2, $3, $4)
-    RETURNING uuid, name, product_code, customer_cost
+INSERT INTO product (uuid, name, product_code, customer_cost, currency, tax_class, weight_grams, tenant_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+    RETURNING uuid, name, product_code, customer_cost, currency, tax_class, tenant_id, weight_grams
 `
 
 type CreateProductParams struct {
@@ -22,6 +22,10 @@ type CreateProductParams struct {
 	Name         string
 	ProductCode  pgtype.UUID
 	CustomerCost pgtype.Numeric
+	Currency     string
+	TaxClass     string
+	WeightGrams  int32
+	TenantID     string
 }
 
 func (q *Queries) CreateProduct(ctx context.Context, arg CreateProductParams) (Product, error) {
@@ -30,6 +34,10 @@ func (q *Queries) CreateProduct(ctx context.Context, arg CreateProductParams) (P
 		arg.Name,
 		arg.ProductCode,
 		arg.CustomerCost,
+		arg.Currency,
+		arg.TaxClass,
+		arg.WeightGrams,
+		arg.TenantID,
 	)
 	var i Product
 	err := row.Scan(
@@ -37,45 +45,94 @@ func (q *Queries) CreateProduct(ctx context.Context, arg CreateProductParams) (P
 		&i.Name,
 		&i.ProductCode,
 		&i.CustomerCost,
+		&i.Currency,
+		&i.TaxClass,
+		&i.TenantID,
+		&i.WeightGrams,
 	)
 	return i, err
 }
 
 const deleteProduct = `-- name: DeleteProduct :exec
 DELETE FROM product
-WHERE uuid = $1
+WHERE uuid = $1 AND $2::varchar IN (tenant_id, '*')
 `
 
-func (q *Queries) DeleteProduct(ctx context.Context, uuid pgtype.UUID) error {
-	_, err := q.db.Exec(ctx, deleteProduct, uuid)
+type DeleteProductParams struct {
+	Uuid     pgtype.UUID
+	TenantID string
+}
+
+func (q *Queries) DeleteProduct(ctx context.Context, arg DeleteProductParams) error {
+	_, err := q.db.Exec(ctx, deleteProduct, arg.Uuid, arg.TenantID)
 	return err
 }
 
 const getProduct = `-- name: GetProduct :one
-SELECT uuid, name, product_code, customer_cost FROM product
-WHERE product_code = $1 LIMIT 1
+SELECT uuid, name, product_code, customer_cost, currency, tax_class, tenant_id, weight_grams FROM product
+WHERE product_code = $1 AND $2::varchar IN (tenant_id, '*') LIMIT 1
 `
 
-func (q *Queries) GetProduct(ctx context.Context, productCode pgtype.UUID) (Product, error) {
-	row := q.db.QueryRow(ctx, getProduct, productCode)
+type GetProductParams struct {
+	ProductCode pgtype.UUID
+	TenantID    string
+}
+
+func (q *Queries) GetProduct(ctx context.Context, arg GetProductParams) (Product, error) {
+	row := q.db.QueryRow(ctx, getProduct, arg.ProductCode, arg.TenantID)
 	var i Product
 	err := row.Scan(
 		&i.Uuid,
 		&i.Name,
 		&i.ProductCode,
 		&i.CustomerCost,
+		&i.Currency,
+		&i.TaxClass,
+		&i.TenantID,
+		&i.WeightGrams,
+	)
+	return i, err
+}
+
+const getProductByUuid = `-- name: GetProductByUuid :one
+SELECT uuid, name, product_code, customer_cost, currency, tax_class, tenant_id, weight_grams FROM product
+WHERE uuid = $1 AND $2::varchar IN (tenant_id, '*') LIMIT 1
+`
+
+type GetProductByUuidParams struct {
+	Uuid     pgtype.UUID
+	TenantID string
+}
+
+func (q *Queries) GetProductByUuid(ctx context.Context, arg GetProductByUuidParams) (Product, error) {
+	row := q.db.QueryRow(ctx, getProductByUuid, arg.Uuid, arg.TenantID)
+	var i Product
+	err := row.Scan(
+		&i.Uuid,
+		&i.Name,
+		&i.ProductCode,
+		&i.CustomerCost,
+		&i.Currency,
+		&i.TaxClass,
+		&i.TenantID,
+		&i.WeightGrams,
 	)
 	return i, err
 }
 
 const getProductsByOrder = `-- name: GetProductsByOrder :many
-SELECT p.uuid, p.name, p.product_code, p.customer_cost FROM product p
+SELECT p.uuid, p.name, p.product_code, p.customer_cost, p.currency, p.tax_class, p.tenant_id, p.weight_grams FROM product p
                     JOIN order_products op ON p.uuid = op.product_uuid
-WHERE op.order_uuid = $1
+WHERE op.order_uuid = $1 AND $2::varchar IN (op.tenant_id, '*')
 `
 
-func (q *Queries) GetProductsByOrder(ctx context.Context, orderUuid pgtype.UUID) ([]Product, error) {
-	rows, err := q.db.Query(ctx, getProductsByOrder, orderUuid)
+type GetProductsByOrderParams struct {
+	OrderUuid pgtype.UUID
+	TenantID  string
+}
+
+func (q *Queries) GetProductsByOrder(ctx context.Context, arg GetProductsByOrderParams) ([]Product, error) {
+	rows, err := q.db.Query(ctx, getProductsByOrder, arg.OrderUuid, arg.TenantID)
 	if err != nil {
 		return nil, err
 	}
@@ -88,6 +145,10 @@ func (q *Queries) GetProductsByOrder(ctx context.Context, orderUuid pgtype.UUID)
 			&i.Name,
 			&i.ProductCode,
 			&i.CustomerCost,
+			&i.Currency,
+			&i.TaxClass,
+			&i.TenantID,
+			&i.WeightGrams,
 		); err != nil {
 			return nil, err
 		}
@@ -100,18 +161,20 @@ func (q *Queries) GetProductsByOrder(ctx context.Context, orderUuid pgtype.UUID)
 }
 
 const listProducts = `-- name: ListProducts :many
-SELECT uuid, name, product_code, customer_cost FROM product
+SELECT uuid, name, product_code, customer_cost, currency, tax_class, tenant_id, weight_grams FROM product
+WHERE $3::varchar IN (tenant_id, '*')
 ORDER BY name
 limit $1 offset $2
 `
 
 type ListProductsParams struct {
-	Limit  int32
-	Offset int32
+	Limit    int32
+	Offset   int32
+	TenantID string
 }
 
 func (q *Queries) ListProducts(ctx context.Context, arg ListProductsParams) ([]Product, error) {
-	rows, err := q.db.Query(ctx, listProducts, arg.Limit, arg.Offset)
+	rows, err := q.db.Query(ctx, listProducts, arg.Limit, arg.Offset, arg.TenantID)
 	if err != nil {
 		return nil, err
 	}
@@ -124,6 +187,10 @@ func (q *Queries) ListProducts(ctx context.Context, arg ListProductsParams) ([]P
 			&i.Name,
 			&i.ProductCode,
 			&i.CustomerCost,
+			&i.Currency,
+			&i.TaxClass,
+			&i.TenantID,
+			&i.WeightGrams,
 		); err != nil {
 			return nil, err
 		}
@@ -137,24 +204,37 @@ func (q *Queries) ListProducts(ctx context.Context, arg ListProductsParams) ([]P
 
 const updateProduct = `-- name: UpdateProduct :one
 UPDATE product
-SET name = $2, product_code = $3, customer_cost = $4
-WHERE uuid = $1
-    RETURNING uuid, name, product_code, customer_cost
+SET name = COALESCE($1, name),
+    product_code = COALESCE($2, product_code),
+    customer_cost = COALESCE($3, customer_cost),
+    currency = COALESCE($4, currency),
+    tax_class = COALESCE($5, tax_class),
+    weight_grams = COALESCE($6, weight_grams)
+WHERE uuid = $7 AND $8::varchar IN (tenant_id, '*')
+    RETURNING uuid, name, product_code, customer_cost, currency, tax_class, tenant_id, weight_grams
 `
 
 type UpdateProductParams struct {
-	Uuid         pgtype.UUID
-	Name         string
+	Name         pgtype.Text
 	ProductCode  pgtype.UUID
 	CustomerCost pgtype.Numeric
+	Currency     pgtype.Text
+	TaxClass     pgtype.Text
+	WeightGrams  pgtype.Int4
+	Uuid         pgtype.UUID
+	TenantID     string
 }
 
 func (q *Queries) UpdateProduct(ctx context.Context, arg UpdateProductParams) (Product, error) {
 	row := q.db.QueryRow(ctx, updateProduct,
-		arg.Uuid,
 		arg.Name,
 		arg.ProductCode,
 		arg.CustomerCost,
+		arg.Currency,
+		arg.TaxClass,
+		arg.WeightGrams,
+		arg.Uuid,
+		arg.TenantID,
 	)
 	var i Product
 	err := row.Scan(
@@ -162,6 +242,10 @@ func (q *Queries) UpdateProduct(ctx context.Context, arg UpdateProductParams) (P
 		&i.Name,
 		&i.ProductCode,
 		&i.CustomerCost,
+		&i.Currency,
+		&i.TaxClass,
+		&i.TenantID,
+		&i.WeightGrams,
 	)
 	return i, err
 }
@@ -0,0 +1,86 @@
+-- name: CreateCustomer :one
+INSERT INTO customers (uuid, tenant_id, user_id, name, email, phone)
+VALUES ($1, sqlc.arg(tenant_id), $2, $3, $4, $5)
+    RETURNING *;
+
+-- name: EnsureCustomer :exec
+INSERT INTO customers (uuid, tenant_id, user_id)
+VALUES ($1, sqlc.arg(tenant_id), $2)
+ON CONFLICT (tenant_id, user_id) DO NOTHING;
+
+-- name: GetCustomer :one
+SELECT * FROM customers
+WHERE user_id = $1 AND sqlc.arg(tenant_id)::varchar IN (tenant_id, '*') LIMIT 1;
+
+-- name: ListCustomers :many
+SELECT * FROM customers
+WHERE sqlc.arg(tenant_id)::varchar IN (tenant_id, '*')
+ORDER BY created_at DESC
+limit sqlc.arg('limit') offset sqlc.arg('offset');
+
+-- name: UpdateCustomer :one
+UPDATE customers
+SET name = COALESCE(sqlc.narg(name), name),
+    email = COALESCE(sqlc.narg(email), email),
+    phone = COALESCE(sqlc.narg(phone), phone),
+    default_shipping_address_uuid = COALESCE(sqlc.narg(default_shipping_address_uuid), default_shipping_address_uuid),
+    updated_at = NOW()
+WHERE uuid = sqlc.arg(uuid) AND sqlc.arg(tenant_id)::varchar IN (tenant_id, '*')
+    RETURNING *;
+
+-- name: AddCustomerAddress :one
+INSERT INTO customer_addresses (
+    uuid, customer_uuid, label, recipient, line1, line2, city, region, postal_code, country, phone
+) VALUES (
+             $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+         )
+    RETURNING *;
+
+-- name: GetCustomerAddress :one
+SELECT * FROM customer_addresses
+WHERE uuid = $1 AND customer_uuid = $2;
+
+-- name: ListCustomerAddresses :many
+SELECT * FROM customer_addresses
+WHERE customer_uuid = $1
+ORDER BY created_at;
+
+-- name: UpdateCustomerAddress :one
+UPDATE customer_addresses
+SET label = $3,
+    recipient = $4,
+    line1 = $5,
+    line2 = $6,
+    city = $7,
+    region = $8,
+    postal_code = $9,
+    country = $10,
+    phone = $11
+WHERE uuid = $1 AND customer_uuid = $2
+    RETURNING *;
+
+-- name: DeleteCustomerAddress :execrows
+DELETE FROM customer_addresses
+WHERE uuid = $1 AND customer_uuid = $2;
+
+-- name: GetCustomerOrderStats :one
+SELECT COUNT(*)::integer AS order_count,
+       MAX(creation_date)::timestamp AS last_order_at
+FROM orders
+WHERE user_id = sqlc.arg(user_id) AND status <> 'pending'
+  AND sqlc.arg(tenant_id)::varchar IN (tenant_id, '*');
+
+-- name: ListCustomerSpending :many
+SELECT o.currency,
+       SUM(o.order_cost - COALESCE(r.refunded, 0))::decimal AS total_spent
+FROM orders o
+         LEFT JOIN (
+    SELECT order_uuid, SUM(refund_amount) AS refunded
+    FROM order_returns
+    WHERE status = 'refunded'
+    GROUP BY order_uuid
+) r ON r.order_uuid = o.uuid
+WHERE o.user_id = sqlc.arg(user_id) AND o.status NOT IN ('pending', 'cancelled')
+  AND sqlc.arg(tenant_id)::varchar IN (o.tenant_id, '*')
+GROUP BY o.currency
+ORDER BY o.currency;
@@ -0,0 +1,18 @@
+-- name: NextInvoiceNumber :one
+INSERT INTO invoice_numbers (tenant_id, last_number)
+VALUES (sqlc.arg(tenant_id), 1)
+ON CONFLICT (tenant_id) DO UPDATE
+SET last_number = invoice_numbers.last_number + 1
+    RETURNING last_number;
+
+-- name: CreateInvoice :one
+INSERT INTO invoices (
+    uuid, order_uuid, number, snapshot, tenant_id
+) VALUES (
+             $1, $2, $3, $4, sqlc.arg(tenant_id)
+         )
+    RETURNING *;
+
+-- name: GetOrderInvoice :one
+SELECT * FROM invoices
+WHERE order_uuid = $1 AND sqlc.arg(tenant_id)::varchar IN (tenant_id, '*');
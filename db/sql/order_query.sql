@@ -1,68 +1,160 @@
 -- name: CreateOrder :one
 INSERT INTO orders (
-    uuid, comment, user_id, staff_id, order_cost
+    uuid, comment, user_id, staff_id, order_cost, currency, discount, promotion_uuids,
+    region, net_amount, tax_amount, shipping_method_uuid, shipping_cost, shipping_address,
+    billing_address, stock_taken, tenant_id
 ) VALUES (
-             $1, $2, $3, $4, $5
+             $1, $2, $3, $4, $5, $6,
+             COALESCE(sqlc.narg(discount)::decimal, 0),
+             COALESCE(sqlc.narg(promotion_uuids)::uuid[], '{}'),
+             COALESCE(sqlc.narg(region)::varchar, ''),
+             COALESCE(sqlc.narg(net_amount)::decimal, 0),
+             COALESCE(sqlc.narg(tax_amount)::decimal, 0),
+             sqlc.narg(shipping_method_uuid),
+             COALESCE(sqlc.narg(shipping_cost)::decimal, 0),
+             sqlc.narg(shipping_address),
+             sqlc.narg(billing_address),
+             sqlc.arg(stock_taken),
+             sqlc.arg(tenant_id)
+         )
+    RETURNING *;
+
+-- name: CreatePendingOrder :one
+INSERT INTO orders (
+    uuid, comment, user_id, staff_id, order_cost, currency, discount, promotion_uuids,
+    region, net_amount, tax_amount, shipping_method_uuid, shipping_cost, shipping_address,
+    billing_address, stock_taken, status, tenant_id
+) VALUES (
+             $1, $2, $3, $4, $5, $6,
+             COALESCE(sqlc.narg(discount)::decimal, 0),
+             COALESCE(sqlc.narg(promotion_uuids)::uuid[], '{}'),
+             COALESCE(sqlc.narg(region)::varchar, ''),
+             COALESCE(sqlc.narg(net_amount)::decimal, 0),
+             COALESCE(sqlc.narg(tax_amount)::decimal, 0),
+             sqlc.narg(shipping_method_uuid),
+             COALESCE(sqlc.narg(shipping_cost)::decimal, 0),
+             sqlc.narg(shipping_address),
+             sqlc.narg(billing_address),
+             sqlc.arg(stock_taken),
+             'pending',
+             sqlc.arg(tenant_id)
          )
     RETURNING *;
 
 -- name: GetOrder :one
 SELECT * FROM orders
-WHERE uuid = $1 LIMIT 1;
+WHERE uuid = $1 AND sqlc.arg(tenant_id)::varchar IN (tenant_id, '*') LIMIT 1;
+
+-- name: LockOrder :one
+SELECT * FROM orders
+WHERE uuid = $1 AND sqlc.arg(tenant_id)::varchar IN (tenant_id, '*') LIMIT 1
+    FOR UPDATE;
 
 -- name: ListOrders :many
 SELECT * FROM orders
-where status = $1
+where (sqlc.narg(status)::order_status IS NULL OR status = sqlc.narg(status))
+  AND (sqlc.narg(user_id)::varchar IS NULL OR user_id = sqlc.narg(user_id))
+  AND sqlc.arg(tenant_id)::varchar IN (tenant_id, '*')
 ORDER BY creation_date DESC
-limit $2 offset $3;
+limit sqlc.arg('limit') offset sqlc.arg('offset');
 
 -- name: UpdateOrderStatus :one
 UPDATE orders
-SET status = $2, finish_date = CASE WHEN $2 = 'completed' THEN NOW() ELSE finish_date END
-WHERE uuid = $1
+SET status = sqlc.arg(status), finish_date = CASE WHEN sqlc.arg(status) = 'completed' THEN NOW() ELSE finish_date END
+WHERE uuid = sqlc.arg(uuid) AND status = sqlc.arg(from_status)
+  AND sqlc.arg(tenant_id)::varchar IN (tenant_id, '*')
     RETURNING *;
 
+-- name: MarkOrderStockTaken :exec
+UPDATE orders
+SET stock_taken = TRUE
+WHERE uuid = $1 AND sqlc.arg(tenant_id)::varchar IN (tenant_id, '*');
+
 -- name: DeleteOrder :exec
 DELETE FROM orders
-WHERE uuid = $1;
+WHERE uuid = $1 AND sqlc.arg(tenant_id)::varchar IN (tenant_id, '*');
 
 -- name: DeleteOrderProducts :exec
-DELETE FROM order_products where order_uuid = $1;
+DELETE FROM order_products where order_uuid = $1 AND sqlc.arg(tenant_id)::varchar IN (tenant_id, '*');
 
 -- name: AddProductToOrder :one
 INSERT INTO order_products (
-    product_uuid, order_uuid, result_price, amount
+    product_uuid, order_uuid, result_price, amount, list_price, discount, promotion_uuids,
+    tax_rate, tax_amount, tax_inclusive, price_history_uuid, tenant_id
 ) VALUES (
-             (select uuid from product where product_code = $1), $2, $3, $4
+             (select uuid from product where product_code = sqlc.arg(product_code) AND tenant_id = sqlc.arg(tenant_id)),
+             sqlc.arg(order_uuid),
+             sqlc.arg(result_price),
+             sqlc.arg(amount),
+             COALESCE(sqlc.narg(list_price)::decimal, sqlc.arg(result_price)),
+             COALESCE(sqlc.narg(discount)::decimal, 0),
+             COALESCE(sqlc.narg(promotion_uuids)::uuid[], '{}'),
+             COALESCE(sqlc.narg(tax_rate)::decimal, 0),
+             COALESCE(sqlc.narg(tax_amount)::decimal, 0),
+             COALESCE(sqlc.narg(tax_inclusive)::boolean, FALSE),
+             sqlc.narg(price_history_uuid),
+             sqlc.arg(tenant_id)
          )
     RETURNING *;
 
 -- name: RemoveProductFromOrder :exec
 DELETE FROM order_products
-WHERE product_uuid = $1 AND order_uuid = $2;
+WHERE product_uuid = $1 AND order_uuid = $2 AND sqlc.arg(tenant_id)::varchar IN (tenant_id, '*');
 
 -- name: GetOrderProducts :many
 SELECT op.*, p.name as product_name, p.product_code FROM order_products op
                                                              JOIN product p ON op.product_uuid = p.uuid
-WHERE op.order_uuid = $1;
+WHERE op.order_uuid = $1 AND sqlc.arg(tenant_id)::varchar IN (op.tenant_id, '*');
+
 
+-- name: UpdateOrderProduct :one
+UPDATE order_products
+SET amount = sqlc.arg(amount),
+    discount = sqlc.arg(discount),
+    tax_rate = sqlc.arg(tax_rate),
+    tax_amount = sqlc.arg(tax_amount),
+    tax_inclusive = sqlc.arg(tax_inclusive)
+WHERE order_uuid = sqlc.arg(order_uuid)
+  AND product_uuid = (select p.uuid from product p where p.product_code = sqlc.arg(product_code) AND p.tenant_id = order_products.tenant_id)
+  AND sqlc.arg(tenant_id)::varchar IN (tenant_id, '*')
+    RETURNING *;
 
 -- name: CalculateOrderTotal :one
-SELECT SUM(result_price * amount) as total FROM order_products
-WHERE order_uuid = $1;
+SELECT COALESCE(SUM(result_price * amount - discount + CASE WHEN tax_inclusive THEN 0 ELSE tax_amount END), 0)::decimal as total
+FROM order_products
+WHERE order_uuid = $1 AND sqlc.arg(tenant_id)::varchar IN (tenant_id, '*');
+
+-- name: UpdateOrderTotals :one
+UPDATE orders
+SET order_cost = sqlc.arg(order_cost),
+    discount = sqlc.arg(discount),
+    net_amount = sqlc.arg(net_amount),
+    tax_amount = sqlc.arg(tax_amount),
+    shipping_cost = sqlc.arg(shipping_cost)
+WHERE uuid = sqlc.arg(uuid) AND status = 'new'
+  AND sqlc.arg(tenant_id)::varchar IN (tenant_id, '*')
+    RETURNING *;
 
 -- name: UpdateOrder :one
 UPDATE orders
 SET
-    comment = COALESCE($2, comment),
-    user_id = COALESCE($3, user_id),
-    staff_id = COALESCE($4, staff_id),
-    order_cost = COALESCE($5, order_cost),
-    status = COALESCE($6, status),
-    finish_date = CASE
-                      WHEN $6 = 'completed' AND status != 'completed' THEN NOW()
-                      WHEN $6 != 'completed' THEN NULL
-                      ELSE finish_date
-        END
-WHERE uuid = $1
-    RETURNING *;
\ No newline at end of file
+    comment = COALESCE(sqlc.narg(comment), comment),
+    user_id = COALESCE(sqlc.narg(user_id), user_id),
+    staff_id = COALESCE(sqlc.narg(staff_id), staff_id),
+    order_cost = COALESCE(sqlc.narg(order_cost), order_cost)
+WHERE uuid = sqlc.arg(uuid) AND sqlc.arg(tenant_id)::varchar IN (tenant_id, '*')
+    RETURNING *;
+
+-- name: AddOrderStatusHistory :one
+INSERT INTO order_status_history (
+    uuid, order_uuid, from_status, to_status, actor, reason
+) VALUES (
+             $1, $2, $3, $4, $5, $6
+         )
+    RETURNING *;
+
+-- name: ListOrderStatusHistory :many
+SELECT * FROM order_status_history
+WHERE order_uuid = $1
+  AND order_uuid IN (select o.uuid from orders o where sqlc.arg(tenant_id)::varchar IN (o.tenant_id, '*'))
+ORDER BY changed_at;
@@ -0,0 +1,21 @@
+-- name: AddOutboxEvent :exec
+INSERT INTO order_outbox (event_uuid, event_type, order_uuid, payload, tenant_id)
+VALUES ($1, $2, $3, $4, sqlc.arg(tenant_id));
+
+-- name: ListPendingOutboxEvents :many
+SELECT * FROM order_outbox
+WHERE published_at IS NULL
+  AND sqlc.arg(tenant_id)::varchar IN (tenant_id, '*')
+ORDER BY id
+LIMIT $1
+FOR UPDATE SKIP LOCKED;
+
+-- name: MarkOutboxEventPublished :exec
+UPDATE order_outbox
+SET published_at = NOW(), attempts = attempts + 1, last_error = NULL
+WHERE id = $1 AND sqlc.arg(tenant_id)::varchar IN (tenant_id, '*');
+
+-- name: MarkOutboxEventFailed :exec
+UPDATE order_outbox
+SET attempts = attempts + 1, last_error = $2
+WHERE id = $1 AND sqlc.arg(tenant_id)::varchar IN (tenant_id, '*');
@@ -0,0 +1,53 @@
+-- name: CreatePayment :one
+INSERT INTO payments (
+    uuid, order_uuid, provider, reference, status, amount, currency, error
+) VALUES (
+             sqlc.arg(uuid),
+             (select o.uuid from orders o
+              where o.uuid = sqlc.arg(order_uuid) AND sqlc.arg(tenant_id)::varchar IN (o.tenant_id, '*')),
+             sqlc.arg(provider),
+             sqlc.arg(reference),
+             sqlc.arg(status),
+             sqlc.arg(amount),
+             sqlc.arg(currency),
+             sqlc.arg(error)
+         )
+    RETURNING *;
+
+-- name: GetActivePayment :one
+SELECT * FROM payments
+WHERE order_uuid = $1 AND status IN ('authorized', 'captured', 'refunded')
+  AND order_uuid IN (select o.uuid from orders o where sqlc.arg(tenant_id)::varchar IN (o.tenant_id, '*'))
+LIMIT 1;
+
+-- name: ListOrderPayments :many
+SELECT * FROM payments
+WHERE order_uuid = $1
+  AND order_uuid IN (select o.uuid from orders o where sqlc.arg(tenant_id)::varchar IN (o.tenant_id, '*'))
+ORDER BY created_at;
+
+-- name: CapturePayment :one
+UPDATE payments
+SET status = 'captured',
+    captured_amount = sqlc.arg(captured_amount),
+    updated_at = NOW()
+WHERE payments.uuid = sqlc.arg(uuid) AND status = 'authorized'
+  AND order_uuid IN (select o.uuid from orders o where sqlc.arg(tenant_id)::varchar IN (o.tenant_id, '*'))
+    RETURNING *;
+
+-- name: VoidPayment :one
+UPDATE payments
+SET status = 'voided',
+    updated_at = NOW()
+WHERE payments.uuid = $1 AND status = 'authorized'
+  AND order_uuid IN (select o.uuid from orders o where sqlc.arg(tenant_id)::varchar IN (o.tenant_id, '*'))
+    RETURNING *;
+
+-- name: AddPaymentRefund :one
+UPDATE payments
+SET refunded_amount = refunded_amount + sqlc.arg(amount),
+    status = CASE WHEN refunded_amount + sqlc.arg(amount) >= captured_amount THEN 'refunded'::payment_status ELSE status END,
+    updated_at = NOW()
+WHERE payments.uuid = sqlc.arg(uuid) AND status = 'captured' AND refunded_amount + sqlc.arg(amount) <= captured_amount
+  AND order_uuid IN (select o.uuid from orders o where sqlc.arg(tenant_id)::varchar IN (o.tenant_id, '*'))
+    RETURNING *;
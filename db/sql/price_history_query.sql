@@ -0,0 +1,41 @@
+-- name: AddPriceHistory :one
+INSERT INTO product_price_history (
+    uuid, product_uuid, customer_cost, currency, changed_by, reason
+) VALUES (
+             sqlc.arg(uuid),
+             (select p.uuid from product p
+              where p.uuid = sqlc.arg(product_uuid) AND sqlc.arg(tenant_id)::varchar IN (p.tenant_id, '*')),
+             sqlc.arg(customer_cost),
+             sqlc.arg(currency),
+             sqlc.arg(changed_by),
+             sqlc.arg(reason)
+         )
+    RETURNING *;
+
+-- name: ClosePriceHistory :execrows
+UPDATE product_price_history
+SET effective_to = NOW()
+WHERE product_uuid = $1 AND effective_to IS NULL
+  AND product_uuid IN (select p.uuid from product p where sqlc.arg(tenant_id)::varchar IN (p.tenant_id, '*'));
+
+-- name: GetCurrentPrice :one
+SELECT * FROM product_price_history
+WHERE product_uuid = $1 AND effective_to IS NULL
+  AND product_uuid IN (select p.uuid from product p where sqlc.arg(tenant_id)::varchar IN (p.tenant_id, '*'))
+LIMIT 1;
+
+-- name: GetPriceAt :one
+SELECT * FROM product_price_history
+WHERE product_uuid = sqlc.arg(product_uuid)
+  AND effective_from <= sqlc.arg(at)::timestamp
+  AND (effective_to IS NULL OR effective_to > sqlc.arg(at)::timestamp)
+  AND product_uuid IN (select p.uuid from product p where sqlc.arg(tenant_id)::varchar IN (p.tenant_id, '*'))
+ORDER BY effective_from DESC
+LIMIT 1;
+
+-- name: ListPriceHistory :many
+SELECT * FROM product_price_history
+WHERE product_uuid = $1
+  AND product_uuid IN (select p.uuid from product p where sqlc.arg(tenant_id)::varchar IN (p.tenant_id, '*'))
+ORDER BY effective_from DESC
+limit $2 offset $3;
@@ -0,0 +1,68 @@
+-- name: CreateScheduledPriceChange :one
+INSERT INTO scheduled_price_changes (
+    uuid, product_uuid, customer_cost, currency, revert_of, run_at, created_by, reason
+) VALUES (
+             sqlc.arg(uuid),
+             (select p.uuid from product p
+              where p.uuid = sqlc.arg(product_uuid) AND sqlc.arg(tenant_id)::varchar IN (p.tenant_id, '*')),
+             sqlc.arg(customer_cost),
+             sqlc.arg(currency),
+             sqlc.arg(revert_of),
+             sqlc.arg(run_at),
+             sqlc.arg(created_by),
+             sqlc.arg(reason)
+         )
+    RETURNING *;
+
+-- name: GetScheduledPriceChange :one
+SELECT * FROM scheduled_price_changes
+WHERE scheduled_price_changes.uuid = $1
+  AND product_uuid IN (select p.uuid from product p where sqlc.arg(tenant_id)::varchar IN (p.tenant_id, '*'))
+LIMIT 1;
+
+-- name: ListScheduledPriceChanges :many
+SELECT * FROM scheduled_price_changes
+WHERE (sqlc.narg(status)::scheduled_price_status IS NULL OR status = sqlc.narg(status))
+  AND (sqlc.narg(product_uuid)::uuid IS NULL OR product_uuid = sqlc.narg(product_uuid))
+  AND product_uuid IN (select p.uuid from product p where sqlc.arg(tenant_id)::varchar IN (p.tenant_id, '*'))
+ORDER BY run_at
+limit sqlc.arg('limit') offset sqlc.arg('offset');
+
+-- name: CancelScheduledPriceChange :one
+UPDATE scheduled_price_changes
+SET status = 'cancelled'
+WHERE scheduled_price_changes.uuid = $1 AND status = 'pending'
+  AND product_uuid IN (select p.uuid from product p where sqlc.arg(tenant_id)::varchar IN (p.tenant_id, '*'))
+    RETURNING *;
+
+-- name: CancelScheduledReverts :exec
+UPDATE scheduled_price_changes
+SET status = 'cancelled'
+WHERE revert_of = $1 AND status = 'pending'
+  AND product_uuid IN (select p.uuid from product p where sqlc.arg(tenant_id)::varchar IN (p.tenant_id, '*'));
+
+-- name: ClaimDueScheduledPriceChange :one
+SELECT * FROM scheduled_price_changes
+WHERE status = 'pending' AND run_at <= NOW()
+  AND product_uuid IN (select p.uuid from product p where sqlc.arg(tenant_id)::varchar IN (p.tenant_id, '*'))
+ORDER BY run_at
+LIMIT 1
+    FOR UPDATE SKIP LOCKED;
+
+-- name: MarkScheduledPriceChangeApplied :one
+UPDATE scheduled_price_changes
+SET status = 'applied',
+    applied_at = NOW(),
+    previous_cost = $2,
+    previous_currency = $3
+WHERE scheduled_price_changes.uuid = $1
+  AND product_uuid IN (select p.uuid from product p where sqlc.arg(tenant_id)::varchar IN (p.tenant_id, '*'))
+    RETURNING *;
+
+-- name: MarkScheduledPriceChangeFailed :one
+UPDATE scheduled_price_changes
+SET status = 'failed',
+    error = $2
+WHERE scheduled_price_changes.uuid = $1
+  AND product_uuid IN (select p.uuid from product p where sqlc.arg(tenant_id)::varchar IN (p.tenant_id, '*'))
+    RETURNING *;
@@ -1,28 +1,38 @@
 -- name: CreateProduct :one
-INSERT INTO product (uuid, name, product_code, customer_cost)
-VALUES ($1, $2, $3, $4)
+INSERT INTO product (uuid, name, product_code, customer_cost, currency, tax_class, weight_grams, tenant_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, sqlc.arg(tenant_id))
     RETURNING *;
 
 -- name: GetProduct :one
 SELECT * FROM product
-WHERE product_code = $1 LIMIT 1;
+WHERE product_code = $1 AND sqlc.arg(tenant_id)::varchar IN (tenant_id, '*') LIMIT 1;
+
+-- name: GetProductByUuid :one
+SELECT * FROM product
+WHERE uuid = $1 AND sqlc.arg(tenant_id)::varchar IN (tenant_id, '*') LIMIT 1;
 
 -- name: ListProducts :many
 SELECT * FROM product
+WHERE sqlc.arg(tenant_id)::varchar IN (tenant_id, '*')
 ORDER BY name
 limit $1 offset $2;
 
 -- name: UpdateProduct :one
 UPDATE product
-SET name = $2, product_code = $3, customer_cost = $4
-WHERE uuid = $1
+SET name = COALESCE(sqlc.narg(name), name),
+    product_code = COALESCE(sqlc.narg(product_code), product_code),
+    customer_cost = COALESCE(sqlc.narg(customer_cost), customer_cost),
+    currency = COALESCE(sqlc.narg(currency), currency),
+    tax_class = COALESCE(sqlc.narg(tax_class), tax_class),
+    weight_grams = COALESCE(sqlc.narg(weight_grams), weight_grams)
+WHERE uuid = sqlc.arg(uuid) AND sqlc.arg(tenant_id)::varchar IN (tenant_id, '*')
     RETURNING *;
 
 -- name: DeleteProduct :exec
 DELETE FROM product
-WHERE uuid = $1;
+WHERE uuid = $1 AND sqlc.arg(tenant_id)::varchar IN (tenant_id, '*');
 
 -- name: GetProductsByOrder :many
 SELECT p.* FROM product p
                     JOIN order_products op ON p.uuid = op.product_uuid
-WHERE op.order_uuid = $1;
\ No newline at end of file
+WHERE op.order_uuid = $1 AND sqlc.arg(tenant_id)::varchar IN (op.tenant_id, '*');
\ No newline at end of file
@@ -0,0 +1,51 @@
+-- name: CreatePromotion :one
+INSERT INTO promotions (
+    uuid, code, name, kind, scope, product_code, percent, amount, currency,
+    buy_quantity, free_quantity, usage_limit, valid_from, valid_to, tenant_id
+) VALUES (
+             $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, sqlc.arg(tenant_id)
+         )
+    RETURNING *;
+
+-- name: GetPromotion :one
+SELECT * FROM promotions
+WHERE uuid = $1 AND sqlc.arg(tenant_id)::varchar IN (tenant_id, '*') LIMIT 1;
+
+-- name: ListPromotions :many
+SELECT * FROM promotions
+WHERE sqlc.arg(tenant_id)::varchar IN (tenant_id, '*')
+ORDER BY created_at DESC
+limit $1 offset $2;
+
+-- name: UpdatePromotion :one
+UPDATE promotions
+SET name = COALESCE(sqlc.narg(name), name),
+    active = COALESCE(sqlc.narg(active), active),
+    usage_limit = COALESCE(sqlc.narg(usage_limit), usage_limit),
+    valid_from = COALESCE(sqlc.narg(valid_from), valid_from),
+    valid_to = COALESCE(sqlc.narg(valid_to), valid_to)
+WHERE uuid = sqlc.arg(uuid) AND sqlc.arg(tenant_id)::varchar IN (tenant_id, '*')
+    RETURNING *;
+
+-- name: DeletePromotion :execrows
+DELETE FROM promotions
+WHERE uuid = $1 AND sqlc.arg(tenant_id)::varchar IN (tenant_id, '*');
+
+-- name: ListApplicablePromotions :many
+SELECT * FROM promotions
+WHERE active
+  AND (valid_from IS NULL OR valid_from <= NOW())
+  AND (valid_to IS NULL OR valid_to > NOW())
+  AND (usage_limit IS NULL OR usage_count < usage_limit)
+  AND (code IS NULL OR code = ANY(sqlc.arg(codes)::text[]))
+  AND sqlc.arg(tenant_id)::varchar IN (tenant_id, '*')
+ORDER BY created_at;
+
+-- name: UsePromotion :execrows
+UPDATE promotions
+SET usage_count = usage_count + 1
+WHERE uuid = $1
+  AND sqlc.arg(tenant_id)::varchar IN (tenant_id, '*')
+  AND active
+  AND (usage_limit IS NULL OR usage_count < usage_limit)
+  AND (valid_to IS NULL OR valid_to > NOW());
@@ -0,0 +1,41 @@
+-- name: AddOrderReservation :exec
+INSERT INTO order_reservations (uuid, order_uuid, product_code, amount, expires_at)
+VALUES (
+        sqlc.arg(uuid),
+        (select o.uuid from orders o
+         where o.uuid = sqlc.arg(order_uuid) AND sqlc.arg(tenant_id)::varchar IN (o.tenant_id, '*')),
+        sqlc.arg(product_code),
+        sqlc.arg(amount),
+        NOW() + sqlc.arg(ttl_seconds)::float8 * INTERVAL '1 second'
+       );
+
+-- name: ListOrderReservations :many
+SELECT * FROM order_reservations
+WHERE order_uuid = $1
+  AND order_uuid IN (select o.uuid from orders o where sqlc.arg(tenant_id)::varchar IN (o.tenant_id, '*'))
+ORDER BY created_at;
+
+-- name: HoldOrderReservations :execrows
+UPDATE order_reservations
+SET stock_held = TRUE, updated_at = NOW()
+WHERE order_uuid = $1 AND status = 'reserved'
+  AND order_uuid IN (select o.uuid from orders o where sqlc.arg(tenant_id)::varchar IN (o.tenant_id, '*'));
+
+-- name: ConfirmOrderReservations :execrows
+UPDATE order_reservations
+SET status = 'confirmed', updated_at = NOW()
+WHERE order_uuid = $1 AND status = 'reserved' AND expires_at >= NOW()
+  AND order_uuid IN (select o.uuid from orders o where sqlc.arg(tenant_id)::varchar IN (o.tenant_id, '*'));
+
+-- name: ReleaseOrderReservations :many
+UPDATE order_reservations
+SET status = 'released', updated_at = NOW()
+WHERE order_uuid = $1 AND status = 'reserved'
+  AND order_uuid IN (select o.uuid from orders o where sqlc.arg(tenant_id)::varchar IN (o.tenant_id, '*'))
+    RETURNING *;
+
+-- name: ListExpiredReservationOrders :many
+SELECT DISTINCT order_uuid FROM order_reservations
+WHERE status = 'reserved' AND expires_at < NOW()
+  AND order_uuid IN (select o.uuid from orders o where sqlc.arg(tenant_id)::varchar IN (o.tenant_id, '*'))
+LIMIT $1;
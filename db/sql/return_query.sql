@@ -0,0 +1,72 @@
+-- name: CreateReturn :one
+INSERT INTO order_returns (
+    uuid, order_uuid, refund_amount, currency, created_by, reason
+) VALUES (
+             $1, $2, $3, $4, $5, $6
+         )
+    RETURNING *;
+
+-- name: AddReturnLine :one
+INSERT INTO order_return_lines (
+    return_uuid, product_uuid, order_uuid, amount, refund_amount
+) VALUES (
+             sqlc.arg(return_uuid),
+             (select p.uuid from product p
+              join orders o on o.uuid = sqlc.arg(order_uuid) and o.tenant_id = p.tenant_id
+              where p.product_code = sqlc.arg(product_code)),
+             sqlc.arg(order_uuid),
+             sqlc.arg(amount),
+             sqlc.arg(refund_amount)
+         )
+    RETURNING *;
+
+-- name: GetReturn :one
+SELECT * FROM order_returns
+WHERE order_returns.uuid = $1
+  AND order_uuid IN (select o.uuid from orders o where sqlc.arg(tenant_id)::varchar IN (o.tenant_id, '*'))
+LIMIT 1;
+
+-- name: ListReturns :many
+SELECT * FROM order_returns
+WHERE (sqlc.narg(status)::return_status IS NULL OR status = sqlc.narg(status))
+  AND (sqlc.narg(order_uuid)::uuid IS NULL OR order_uuid = sqlc.narg(order_uuid))
+  AND order_uuid IN (select o.uuid from orders o where sqlc.arg(tenant_id)::varchar IN (o.tenant_id, '*'))
+ORDER BY created_at DESC
+limit sqlc.arg('limit') offset sqlc.arg('offset');
+
+-- name: ListReturnLines :many
+SELECT rl.*, p.name as product_name, p.product_code FROM order_return_lines rl
+                                                             JOIN product p ON rl.product_uuid = p.uuid
+WHERE rl.return_uuid = $1;
+
+-- name: ListReturnedAmounts :many
+SELECT rl.product_uuid, SUM(rl.amount)::integer as amount
+FROM order_return_lines rl
+         JOIN order_returns r ON rl.return_uuid = r.uuid
+WHERE rl.order_uuid = $1 AND r.status <> 'rejected'
+GROUP BY rl.product_uuid;
+
+-- name: SumOrderRefunds :one
+SELECT COALESCE(SUM(refund_amount), 0)::decimal as total FROM order_returns
+WHERE order_uuid = $1 AND status <> 'rejected';
+
+-- name: UpdateReturnStatus :one
+UPDATE order_returns
+SET status = sqlc.arg(status),
+    updated_at = NOW(),
+    refunded_at = CASE WHEN sqlc.arg(status) = 'refunded' THEN NOW() END
+WHERE uuid = sqlc.arg(uuid) AND status = sqlc.arg(from_status)
+    RETURNING *;
+
+-- name: ClaimReturnStock :one
+UPDATE order_returns
+SET stock_returned = TRUE,
+    updated_at = NOW()
+WHERE uuid = $1 AND stock_returned = FALSE AND status <> 'requested' AND status <> 'rejected'
+    RETURNING *;
+
+-- name: ReleaseReturnStock :exec
+UPDATE order_returns
+SET stock_returned = FALSE,
+    updated_at = NOW()
+WHERE uuid = $1;
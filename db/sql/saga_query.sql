@@ -0,0 +1,25 @@
+-- name: CreateSaga :one
+INSERT INTO order_sagas (uuid, order_uuid, products, tenant_id)
+VALUES ($1, $2, $3, sqlc.arg(tenant_id))
+    RETURNING *;
+
+-- name: UpdateSagaStep :exec
+UPDATE order_sagas
+SET step = $2, last_error = $3, updated_at = NOW()
+WHERE uuid = $1 AND sqlc.arg(tenant_id)::varchar IN (tenant_id, '*');
+
+-- name: ClaimUnfinishedSaga :one
+-- Claiming touches the saga, other recovery runs skip it until it stays
+-- unfinished for age_seconds again
+UPDATE order_sagas
+SET updated_at = NOW()
+WHERE uuid = (
+    SELECT s.uuid FROM order_sagas s
+    WHERE s.step IN ('started', 'stock_removed')
+      AND s.updated_at < NOW() - sqlc.arg(age_seconds)::float8 * INTERVAL '1 second'
+      AND sqlc.arg(tenant_id)::varchar IN (s.tenant_id, '*')
+    ORDER BY s.created_at
+    LIMIT 1
+        FOR UPDATE SKIP LOCKED
+)
+    RETURNING *;
@@ -0,0 +1,61 @@
+-- name: CreateShipment :one
+INSERT INTO shipments (
+    uuid, order_uuid, carrier, tracking_number
+) VALUES (
+             $1, $2, $3, $4
+         )
+    RETURNING *;
+
+-- name: AddShipmentLine :one
+INSERT INTO shipment_lines (
+    shipment_uuid, product_uuid, order_uuid, amount
+) VALUES (
+             sqlc.arg(shipment_uuid),
+             (select p.uuid from product p
+              join orders o on o.uuid = sqlc.arg(order_uuid) and o.tenant_id = p.tenant_id
+              where p.product_code = sqlc.arg(product_code)),
+             sqlc.arg(order_uuid),
+             sqlc.arg(amount)
+         )
+    RETURNING *;
+
+-- name: GetShipment :one
+SELECT * FROM shipments
+WHERE shipments.uuid = $1
+  AND order_uuid IN (select o.uuid from orders o where sqlc.arg(tenant_id)::varchar IN (o.tenant_id, '*'))
+LIMIT 1;
+
+-- name: ListOrderShipments :many
+SELECT * FROM shipments
+WHERE order_uuid = $1
+ORDER BY created_at;
+
+-- name: ListShipmentLines :many
+SELECT sl.*, p.name as product_name, p.product_code FROM shipment_lines sl
+                                                             JOIN product p ON sl.product_uuid = p.uuid
+WHERE sl.shipment_uuid = $1;
+
+-- name: ListShipmentAmounts :many
+SELECT sl.product_uuid,
+       COALESCE(SUM(sl.amount) FILTER (WHERE s.status <> 'cancelled'), 0)::integer as allocated,
+       COALESCE(SUM(sl.amount) FILTER (WHERE s.status IN ('shipped', 'delivered')), 0)::integer as shipped,
+       COALESCE(SUM(sl.amount) FILTER (WHERE s.status = 'delivered'), 0)::integer as delivered
+FROM shipment_lines sl
+         JOIN shipments s ON sl.shipment_uuid = s.uuid
+WHERE sl.order_uuid = $1
+GROUP BY sl.product_uuid;
+
+-- name: UpdateShipmentStatus :one
+UPDATE shipments
+SET status = sqlc.arg(status),
+    carrier = COALESCE(sqlc.narg(carrier), carrier),
+    tracking_number = COALESCE(sqlc.narg(tracking_number), tracking_number),
+    shipped_at = CASE WHEN sqlc.arg(status) = 'shipped' THEN NOW() ELSE shipped_at END,
+    delivered_at = CASE WHEN sqlc.arg(status) = 'delivered' THEN NOW() ELSE delivered_at END
+WHERE uuid = sqlc.arg(uuid) AND status = sqlc.arg(from_status)
+    RETURNING *;
+
+-- name: CancelOrderShipments :exec
+UPDATE shipments
+SET status = 'cancelled'
+WHERE order_uuid = $1 AND status = 'pending';
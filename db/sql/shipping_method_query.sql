@@ -0,0 +1,33 @@
+-- name: CreateShippingMethod :one
+INSERT INTO shipping_methods (uuid, tenant_id, code, name, rule, currency, cost, cost_per_kg, free_threshold, active)
+VALUES ($1, sqlc.arg(tenant_id), $2, $3, $4, $5, $6, $7, $8, $9)
+    RETURNING *;
+
+-- name: GetShippingMethod :one
+SELECT * FROM shipping_methods
+WHERE uuid = $1 AND sqlc.arg(tenant_id)::varchar IN (tenant_id, '*') LIMIT 1;
+
+-- name: GetShippingMethodByCode :one
+SELECT * FROM shipping_methods
+WHERE code = $1 AND tenant_id = sqlc.arg(tenant_id) LIMIT 1;
+
+-- name: ListShippingMethods :many
+SELECT * FROM shipping_methods
+WHERE (NOT sqlc.arg(active_only)::boolean OR active)
+  AND sqlc.arg(tenant_id)::varchar IN (tenant_id, '*')
+ORDER BY code
+limit sqlc.arg('limit') offset sqlc.arg('offset');
+
+-- name: UpdateShippingMethod :one
+UPDATE shipping_methods
+SET name = COALESCE(sqlc.narg(name), name),
+    cost = COALESCE(sqlc.narg(cost), cost),
+    cost_per_kg = COALESCE(sqlc.narg(cost_per_kg), cost_per_kg),
+    free_threshold = COALESCE(sqlc.narg(free_threshold), free_threshold),
+    active = COALESCE(sqlc.narg(active), active)
+WHERE uuid = sqlc.arg(uuid) AND sqlc.arg(tenant_id)::varchar IN (tenant_id, '*')
+    RETURNING *;
+
+-- name: DeleteShippingMethod :execrows
+DELETE FROM shipping_methods
+WHERE uuid = $1 AND sqlc.arg(tenant_id)::varchar IN (tenant_id, '*');
@@ -0,0 +1,41 @@
+-- name: CreateStockReturn :one
+INSERT INTO order_stock_returns (order_uuid)
+VALUES ((select o.uuid from orders o
+         where o.uuid = sqlc.arg(order_uuid) AND sqlc.arg(tenant_id)::varchar IN (o.tenant_id, '*')))
+ON CONFLICT (order_uuid) DO UPDATE SET updated_at = NOW()
+    RETURNING *;
+
+-- name: ClaimStockReturn :one
+-- A return in progress for longer than the lease was left by a crashed caller
+UPDATE order_stock_returns
+SET status = 'in_progress', attempts = attempts + 1, updated_at = NOW()
+WHERE order_uuid = $1
+  AND (status IN ('pending', 'failed')
+    OR (status = 'in_progress' AND updated_at < NOW() - sqlc.arg(lease_seconds)::float8 * INTERVAL '1 second'))
+  AND order_uuid IN (select o.uuid from orders o where sqlc.arg(tenant_id)::varchar IN (o.tenant_id, '*'))
+    RETURNING *;
+
+-- name: CompleteStockReturn :exec
+UPDATE order_stock_returns
+SET status = 'completed', last_error = NULL, updated_at = NOW()
+WHERE order_uuid = $1
+  AND order_uuid IN (select o.uuid from orders o where sqlc.arg(tenant_id)::varchar IN (o.tenant_id, '*'));
+
+-- name: FailStockReturn :exec
+UPDATE order_stock_returns
+SET status = 'failed', last_error = $2, updated_at = NOW()
+WHERE order_uuid = $1
+  AND order_uuid IN (select o.uuid from orders o where sqlc.arg(tenant_id)::varchar IN (o.tenant_id, '*'));
+
+-- name: GetStockReturn :one
+SELECT * FROM order_stock_returns
+WHERE order_uuid = $1
+  AND order_uuid IN (select o.uuid from orders o where sqlc.arg(tenant_id)::varchar IN (o.tenant_id, '*'))
+LIMIT 1;
+
+-- name: ListStockReturns :many
+SELECT * FROM order_stock_returns
+WHERE order_stock_returns.status = $1
+  AND order_uuid IN (select o.uuid from orders o where sqlc.arg(tenant_id)::varchar IN (o.tenant_id, '*'))
+ORDER BY updated_at DESC
+limit $2 offset $3;
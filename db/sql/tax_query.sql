@@ -0,0 +1,33 @@
+-- name: CreateTaxRule :one
+INSERT INTO tax_rules (uuid, tax_class, region, rate, inclusive, tenant_id)
+VALUES ($1, $2, $3, $4, $5, sqlc.arg(tenant_id))
+    RETURNING *;
+
+-- name: GetTaxRule :one
+SELECT * FROM tax_rules
+WHERE uuid = $1 AND sqlc.arg(tenant_id)::varchar IN (tenant_id, '*') LIMIT 1;
+
+-- name: ListTaxRules :many
+SELECT * FROM tax_rules
+WHERE sqlc.arg(tenant_id)::varchar IN (tenant_id, '*')
+ORDER BY tax_class, region
+limit $1 offset $2;
+
+-- name: UpdateTaxRule :one
+UPDATE tax_rules
+SET rate = COALESCE(sqlc.narg(rate), rate),
+    inclusive = COALESCE(sqlc.narg(inclusive), inclusive)
+WHERE uuid = sqlc.arg(uuid) AND sqlc.arg(tenant_id)::varchar IN (tenant_id, '*')
+    RETURNING *;
+
+-- name: DeleteTaxRule :execrows
+DELETE FROM tax_rules
+WHERE uuid = $1 AND sqlc.arg(tenant_id)::varchar IN (tenant_id, '*');
+
+-- name: FindTaxRule :one
+-- The rule for the region wins over the class rule with an empty region
+SELECT * FROM tax_rules
+WHERE tax_class = $1 AND (region = $2 OR region = '')
+  AND sqlc.arg(tenant_id)::varchar IN (tenant_id, '*')
+ORDER BY region DESC
+LIMIT 1;
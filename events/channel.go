@@ -0,0 +1,36 @@
+package events
+
+import (
+	"context"
+	"github.com/igntnk/stocky-oms/models"
+)
+
+type ChannelPublisher interface {
+	Publisher
+	Events() <-chan models.OrderEvent
+}
+
+type channelPublisher struct {
+	events chan models.OrderEvent
+}
+
+// NewChannelPublisher keeps events in process. Publish blocks while the
+// buffer is full until the event is read or ctx is done.
+func NewChannelPublisher(buffer int) ChannelPublisher {
+	return &channelPublisher{
+		events: make(chan models.OrderEvent, buffer),
+	}
+}
+
+func (p *channelPublisher) Publish(ctx context.Context, event models.OrderEvent) error {
+	select {
+	case p.events <- event:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (p *channelPublisher) Events() <-chan models.OrderEvent {
+	return p.events
+}
@@ -0,0 +1,12 @@
+package events
+
+import (
+	"context"
+	"github.com/igntnk/stocky-oms/models"
+)
+
+// Publisher delivers order events to downstream consumers. Delivery is
+// at-least-once, so consumers should deduplicate by event ID.
+type Publisher interface {
+	Publish(ctx context.Context, event models.OrderEvent) error
+}
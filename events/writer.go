@@ -0,0 +1,32 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/igntnk/stocky-oms/models"
+	"io"
+	"sync"
+)
+
+type writerPublisher struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+// NewWriterPublisher writes every event to w as a JSON line
+func NewWriterPublisher(w io.Writer) Publisher {
+	return &writerPublisher{
+		enc: json.NewEncoder(w),
+	}
+}
+
+func (p *writerPublisher) Publish(ctx context.Context, event models.OrderEvent) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err := p.enc.Encode(event); err != nil {
+		return fmt.Errorf("failed to write event: %w", err)
+	}
+	return nil
+}
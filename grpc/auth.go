@@ -0,0 +1,128 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"github.com/igntnk/stocky-oms/auth"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"strings"
+)
+
+const (
+	authorizationMetadata = "authorization"
+	// tenantMetadata names the shop an admin call acts for when the token doesn't
+	tenantMetadata = "x-tenant-id"
+)
+
+// UnaryAuthInterceptor rejects calls without a valid bearer token and puts the
+// identity of the caller into the call context
+func UnaryAuthInterceptor(verifier auth.Verifier) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := authenticate(ctx, verifier)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamAuthInterceptor is UnaryAuthInterceptor for streams
+func StreamAuthInterceptor(verifier auth.Verifier) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticate(stream.Context(), verifier)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authenticatedStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+// UnaryTenantInterceptor puts the shop the call acts for into the call
+// context. It runs after UnaryAuthInterceptor.
+func UnaryTenantInterceptor(fallback string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := scopeTenant(ctx, fallback)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamTenantInterceptor is UnaryTenantInterceptor for streams
+func StreamTenantInterceptor(fallback string) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := scopeTenant(stream.Context(), fallback)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authenticatedStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+// UnaryPolicyInterceptor rejects calls the policy doesn't allow for the caller
+// role. It runs after UnaryAuthInterceptor.
+func UnaryPolicyInterceptor(policy auth.Policy) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		err := authorize(ctx, policy, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamPolicyInterceptor is UnaryPolicyInterceptor for streams
+func StreamPolicyInterceptor(policy auth.Policy) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		err := authorize(stream.Context(), policy, info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, stream)
+	}
+}
+
+func authorize(ctx context.Context, policy auth.Policy, method string) error {
+	identity, _ := auth.FromContext(ctx)
+	if !policy.Allows(method, identity.Role) {
+		return status.Error(codes.PermissionDenied, auth.ErrPermissionDenied.Error())
+	}
+	return nil
+}
+
+func authenticate(ctx context.Context, verifier auth.Verifier) (context.Context, error) {
+	token, _ := strings.CutPrefix(incomingMetadata(ctx, authorizationMetadata), "Bearer ")
+
+	identity, err := verifier.Verify(token)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	return auth.WithIdentity(auth.WithToken(ctx, token), identity), nil
+}
+
+func scopeTenant(ctx context.Context, fallback string) (context.Context, error) {
+	identity, _ := auth.FromContext(ctx)
+
+	tenant, err := auth.ResolveTenant(identity, incomingMetadata(ctx, tenantMetadata), fallback)
+	if err != nil {
+		if errors.Is(err, auth.ErrPermissionDenied) {
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return auth.WithTenant(ctx, tenant), nil
+}
+
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,65 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"github.com/igntnk/stocky-oms/models"
+	"github.com/igntnk/stocky-oms/protobufs/oms_ext_pb"
+	"github.com/igntnk/stocky-oms/service"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"time"
+)
+
+type invoiceServer struct {
+	oms_ext_pb.UnimplementedInvoiceServiceServer
+	invoiceService service.InvoiceService
+}
+
+func RegisterInvoiceServer(server *grpc.Server, invoiceService service.InvoiceService) {
+	oms_ext_pb.RegisterInvoiceServiceServer(server, &invoiceServer{invoiceService: invoiceService})
+}
+
+func (s *invoiceServer) GetInvoice(ctx context.Context, req *oms_ext_pb.GetInvoiceRequest) (*oms_ext_pb.Invoice, error) {
+	format := models.InvoiceFormat(req.GetFormat())
+	if format != "" && format != models.InvoiceFormatHTML && format != models.InvoiceFormatPDF {
+		return nil, status.Error(codes.InvalidArgument, "format must be html or pdf")
+	}
+
+	invoice, err := s.invoiceService.GetOrderInvoice(ctx, req.GetOrderUuid())
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidOrderID):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case errors.Is(err, service.ErrOrderNotFound):
+			return nil, status.Error(codes.NotFound, "order not found")
+		case errors.Is(err, service.ErrPermissionDenied):
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		case errors.Is(err, service.ErrOrderNotInvoiceable):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		default:
+			return nil, status.Errorf(codes.Internal, "failed to get invoice: %v", err)
+		}
+	}
+
+	content, contentType, err := s.invoiceService.RenderInvoice(invoice, format)
+	if err != nil {
+		if errors.Is(err, service.ErrInvoicePDFDisabled) {
+			return nil, status.Error(codes.Unimplemented, err.Error())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to render invoice: %v", err)
+	}
+
+	issuedAt, _ := time.Parse(time.RFC3339, invoice.IssuedAt)
+
+	return &oms_ext_pb.Invoice{
+		Uuid:        invoice.ID,
+		Number:      invoice.Number,
+		OrderUuid:   invoice.OrderID,
+		IssuedAt:    timestamppb.New(issuedAt),
+		ContentType: contentType,
+		Content:     content,
+	}, nil
+}
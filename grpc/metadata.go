@@ -0,0 +1,105 @@
+package grpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/igntnk/stocky-oms/models"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	idempotencyKeyMetadata = "idempotency-key"
+	// oms_pb messages have no currency field, so it travels in metadata
+	currencyMetadata = "currency"
+	// coupon codes are sent as repeated values of one key
+	couponMetadata = "coupon"
+	regionMetadata = "region"
+	// shipping method is sent by code, addresses as JSON in binary keys
+	shippingMethodMetadata  = "shipping-method"
+	shippingAddressMetadata = "shipping-address-bin"
+	billingAddressMetadata  = "billing-address-bin"
+	// oms_pb.Order carries only the gross cost, the breakdown is sent in headers
+	netAmountMetadata    = "net-amount"
+	taxAmountMetadata    = "tax-amount"
+	shippingCostMetadata = "shipping-cost"
+)
+
+var validate = validator.New()
+
+// incomingMetadata returns the first value of key sent in the request metadata
+func incomingMetadata(ctx context.Context, key string) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	values := md.Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// incomingMetadataValues returns all values of key sent in the request metadata
+func incomingMetadataValues(ctx context.Context, key string) []string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil
+	}
+	return md.Get(key)
+}
+
+// incomingAddress decodes and validates the address sent in the request
+// metadata, it is nil if none was sent
+func incomingAddress(ctx context.Context, key string) (*models.Address, error) {
+	value := incomingMetadata(ctx, key)
+	if value == "" {
+		return nil, nil
+	}
+
+	var address models.Address
+	err := json.Unmarshal([]byte(value), &address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", key, err)
+	}
+
+	err = validate.Struct(address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", key, err)
+	}
+	return &address, nil
+}
+
+// incomingShipping sets the shipping method and addresses of req sent in the
+// request metadata
+func incomingShipping(ctx context.Context, req *models.OrderCreateRequest) error {
+	req.ShippingMethod = incomingMetadata(ctx, shippingMethodMetadata)
+
+	var err error
+	req.ShippingAddress, err = incomingAddress(ctx, shippingAddressMetadata)
+	if err != nil {
+		return err
+	}
+
+	req.BillingAddress, err = incomingAddress(ctx, billingAddressMetadata)
+	return err
+}
+
+// setCurrencyHeader reports the currency of the amounts in a unary response
+func setCurrencyHeader(ctx context.Context, currency string) {
+	_ = grpc.SetHeader(ctx, metadata.Pairs(currencyMetadata, currency))
+}
+
+// orderHeader reports the currency and the net, tax and shipping amounts of
+// an order
+func orderHeader(order *models.OrderResponse) metadata.MD {
+	return metadata.Pairs(
+		currencyMetadata, order.Currency,
+		netAmountMetadata, order.NetAmount.String(),
+		taxAmountMetadata, order.TaxAmount.String(),
+		shippingCostMetadata, order.ShippingCost.String(),
+	)
+}
@@ -4,12 +4,10 @@ import (
 	"context"
 	"errors"
 	"github.com/igntnk/stocky-2pc-controller/protobufs/oms_pb"
-	"github.com/igntnk/stocky-2pc-controller/protobufs/sms_pb"
-	"github.com/igntnk/stocky-oms/clients"
 	"github.com/igntnk/stocky-oms/repository"
 	"google.golang.org/grpc"
 	"google.golang.org/protobuf/types/known/emptypb"
-	"sync"
+	"io"
 	"time"
 
 	"github.com/google/uuid"
@@ -17,40 +15,38 @@ import (
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/igntnk/stocky-oms/auth"
 	"github.com/igntnk/stocky-oms/models"
 	"github.com/igntnk/stocky-oms/service"
 )
 
+const createScope = "grpc.create"
+
 type orderServer struct {
 	oms_pb.UnimplementedOrderServiceServer
 	orderService   service.OrderService
 	productService service.ProductService
-	sms            clients.SMSClient
-	createOrderMu  sync.Mutex
+	idempotency    service.IdempotencyService
 }
 
-func RegisterOrderServer(server *grpc.Server, smsClient clients.SMSClient, productService service.ProductService, orderService service.OrderService) {
-	oms_pb.RegisterOrderServiceServer(server, &orderServer{productService: productService, sms: smsClient, orderService: orderService, createOrderMu: sync.Mutex{}})
+func RegisterOrderServer(
+	server *grpc.Server,
+	productService service.ProductService,
+	orderService service.OrderService,
+	idempotency service.IdempotencyService,
+) {
+	oms_pb.RegisterOrderServiceServer(server, &orderServer{productService: productService, orderService: orderService, idempotency: idempotency})
 }
 
+// TCCCreateOrder drives the TCC phases over one stream. The coordinator sends
+// a lock event and then the order; the pending order is sent back after Try.
+// The next message from the coordinator confirms the order and the confirmed
+// order is sent back. Closing the stream or failing before that cancels it.
 func (s *orderServer) TCCCreateOrder(stream grpc.BidiStreamingServer[oms_pb.CreateOrderRequest, oms_pb.Order]) (err error) {
-	// lock resources event
-	_, err = stream.Recv()
-	if err != nil {
-		return err
-	}
-	s.createOrderMu.Lock()
-	defer s.createOrderMu.Unlock()
-
 	ctx := stream.Context()
 
-	smsStream, err := s.sms.ChangeCoupleProductAmount(ctx)
-	if err != nil {
-		return err
-	}
-
-	// Send freeze event
-	err = smsStream.Send(&sms_pb.RemoveProductsRequest{})
+	// lock resources event
+	_, err = stream.Recv()
 	if err != nil {
 		return err
 	}
@@ -62,99 +58,149 @@ func (s *orderServer) TCCCreateOrder(stream grpc.BidiStreamingServer[oms_pb.Crea
 
 	products := make([]models.OrderProductInput, 0, len(createOrderReq.GetProducts()))
 	for _, p := range createOrderReq.GetProducts() {
+		productID, err := uuid.Parse(p.GetProductUuid())
+		if err != nil {
+			return status.Error(codes.InvalidArgument, "invalid product uuid")
+		}
+
 		products = append(products, models.OrderProductInput{
-			ProductID: uuid.MustParse(p.GetProductUuid()),
+			ProductID: productID,
 			Amount:    int(p.GetAmount()),
 		})
 	}
 
-	createReq := models.OrderCreateRequest{
-		UserID:  createOrderReq.GetUserId(),
-		StaffID: createOrderReq.GetStaffId(),
-		Comment: createOrderReq.GetComment(),
+	identity, ok := auth.FromContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, auth.ErrMissingToken.Error())
 	}
 
-	var order *models.Order
-	order, err = s.orderService.CreateNakedOrder(ctx, createReq)
+	tryReq := models.OrderCreateRequest{
+		UserID:   identity.UserID,
+		StaffID:  identity.StaffID,
+		Comment:  createOrderReq.GetComment(),
+		Currency: incomingMetadata(ctx, currencyMetadata),
+		Coupons:  incomingMetadataValues(ctx, couponMetadata),
+		Region:   incomingMetadata(ctx, regionMetadata),
+		Products: products,
+	}
+	err = incomingShipping(ctx, &tryReq)
 	if err != nil {
-		return err
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	order, err := s.orderService.TryOrder(ctx, tryReq)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrEmptyOrder):
+			return status.Error(codes.InvalidArgument, "order must contain products")
+		case errors.Is(err, service.ErrInvalidShippingMethod),
+			errors.Is(err, service.ErrShippingAddressRequired):
+			return status.Error(codes.InvalidArgument, err.Error())
+		case errors.Is(err, service.ErrProductNotFound):
+			return status.Error(codes.NotFound, "product not found")
+		case errors.Is(err, service.ErrInvalidCoupon):
+			return status.Error(codes.InvalidArgument, err.Error())
+		case errors.Is(err, service.ErrPromotionUnavailable):
+			return status.Error(codes.Aborted, err.Error())
+		case errors.Is(err, service.ErrReservationExpired):
+			return status.Error(codes.DeadlineExceeded, err.Error())
+		default:
+			return status.Errorf(codes.Internal, "failed to reserve order: %v", err)
+		}
 	}
 	defer func() {
 		if err != nil {
-			s.orderService.DeleteOrder(ctx, order.ID.String())
+			s.orderService.CancelPendingOrder(context.WithoutCancel(ctx), order.ID, "order was not confirmed")
 		}
 	}()
 
-	smsPr := make([]*sms_pb.SetProductAmountRequest, len(products))
-	for i, product := range products {
-		_, err = s.orderService.AddOrderProduct(ctx, order.ID.String(), product.ProductID.String(), float64(product.Amount))
-		if err != nil {
-			return err
-		}
-		smsPr[i] = &sms_pb.SetProductAmountRequest{
-			Uuid:        product.ProductID.String(),
-			StoreAmount: float32(product.Amount),
-		}
+	err = stream.SetHeader(orderHeader(order))
+	if err != nil {
+		return err
 	}
 
-	err = smsStream.Send(&sms_pb.RemoveProductsRequest{
-		Products: smsPr,
-	})
+	err = stream.Send(s.orderToProto(order))
 	if err != nil {
 		return err
 	}
 
-	_, err = smsStream.Recv()
+	// confirm event
+	_, err = stream.Recv()
 	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return status.Error(codes.Aborted, "order was not confirmed")
+		}
 		return err
 	}
 
-	resultProducts := make([]*oms_pb.OrderProduct, len(products))
-	for i, product := range products {
-		resultProducts[i] = &oms_pb.OrderProduct{
-			OrderUuid:   order.ID.String(),
-			ResultPrice: 200,
-			ProductCode: product.ProductID.String(),
-			Amount:      int32(product.Amount),
+	order, err = s.orderService.ConfirmOrder(ctx, order.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrReservationExpired):
+			return status.Error(codes.DeadlineExceeded, err.Error())
+		case errors.Is(err, service.ErrOrderStatusConflict):
+			return status.Error(codes.Aborted, err.Error())
+		default:
+			return status.Errorf(codes.Internal, "failed to confirm order: %v", err)
 		}
 	}
 
-	err = stream.Send(&oms_pb.Order{
-		Uuid:         order.ID.String(),
-		Comment:      order.Comment,
-		UserId:       order.UserID,
-		StaffId:      order.StaffID,
-		Status:       oms_pb.OrderStatus_new,
-		OrderCost:    order.OrderCost,
-		CreationDate: timestamppb.New(order.CreationDate),
-		Products:     resultProducts,
-	})
-
-	return nil
+	return stream.Send(s.orderToProto(order))
 }
 
 func (s *orderServer) Create(ctx context.Context, req *oms_pb.CreateOrderRequest) (res *oms_pb.Order, err error) {
 	// Convert protobuf request to service model
 	products := make([]models.OrderProductInput, 0, len(req.GetProducts()))
 	for _, p := range req.GetProducts() {
+		productID, err := uuid.Parse(p.GetProductUuid())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid product uuid")
+		}
+
 		products = append(products, models.OrderProductInput{
-			ProductID: uuid.MustParse(p.GetProductUuid()),
+			ProductID: productID,
 			Amount:    int(p.GetAmount()),
 		})
 	}
 
+	identity, ok := auth.FromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, auth.ErrMissingToken.Error())
+	}
+
+	// the order is placed for the caller, ids in the request are ignored
 	createReq := models.OrderCreateRequest{
-		UserID:   req.GetUserId(),
-		StaffID:  req.GetStaffId(),
+		UserID:   identity.UserID,
+		StaffID:  identity.StaffID,
 		Comment:  req.GetComment(),
+		Currency: incomingMetadata(ctx, currencyMetadata),
+		Coupons:  incomingMetadataValues(ctx, couponMetadata),
+		Region:   incomingMetadata(ctx, regionMetadata),
 		Products: products,
 	}
+	err = incomingShipping(ctx, &createReq)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
 
 	// Call service layer
 	var resp *models.OrderResponse
-	resp, err = s.orderService.CreateOrder(ctx, createReq)
+	resp, err = s.idempotency.CreateOrder(ctx, createScope, incomingMetadata(ctx, idempotencyKeyMetadata), createReq, s.orderService.CreateOrder)
 	if err != nil {
 		switch {
+		case errors.Is(err, service.ErrInvalidIdempotencyKey),
+			errors.Is(err, service.ErrIdempotencyKeyConflict):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case errors.Is(err, service.ErrIdempotencyKeyInProgress):
+			return nil, status.Error(codes.Aborted, err.Error())
+		case errors.Is(err, service.ErrCurrencyMismatch):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		case errors.Is(err, service.ErrInvalidCoupon),
+			errors.Is(err, service.ErrInvalidShippingMethod),
+			errors.Is(err, service.ErrShippingAddressRequired):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case errors.Is(err, service.ErrPromotionUnavailable):
+			return nil, status.Error(codes.Aborted, err.Error())
 		case errors.Is(err, repository.ErrInvalidOrderTotal):
 			return nil, status.Error(codes.InvalidArgument, err.Error())
 		case errors.Is(err, repository.ErrEmptyOrder):
@@ -165,18 +211,23 @@ func (s *orderServer) Create(ctx context.Context, req *oms_pb.CreateOrderRequest
 	}
 
 	// Convert service response to protobuf
+	_ = grpc.SetHeader(ctx, orderHeader(resp))
 	return s.orderToProto(resp), nil
 }
 
 func (s *orderServer) Get(ctx context.Context, req *oms_pb.GetOrderRequest) (*oms_pb.Order, error) {
 	resp, err := s.orderService.GetOrder(ctx, req.GetUuid())
 	if err != nil {
-		if errors.Is(err, repository.ErrOrderNotFound) {
+		if errors.Is(err, service.ErrOrderNotFound) {
 			return nil, status.Error(codes.NotFound, "order not found")
 		}
+		if errors.Is(err, service.ErrPermissionDenied) {
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
 		return nil, status.Errorf(codes.Internal, "failed to get order: %v", err)
 	}
 
+	_ = grpc.SetHeader(ctx, orderHeader(resp))
 	return s.orderToProto(resp), nil
 }
 
@@ -184,7 +235,7 @@ func (s *orderServer) List(ctx context.Context, req *oms_pb.ListOrderRequest) (*
 	filter := models.OrderFilter{
 		Limit:  int(req.GetLimit()),
 		Offset: int(req.GetOffset()),
-		Status: models.OrderStatus(req.GetStatus()),
+		Status: statusFromProto(req.GetStatus()),
 	}
 
 	resp, err := s.orderService.ListOrders(ctx, filter)
@@ -209,27 +260,47 @@ func (s *orderServer) Update(ctx context.Context, req *oms_pb.UpdateOrderRequest
 		updateReq.Comment = req.Comment
 	}
 	if req.Status != nil {
-		statusStr := oms_pb.OrderStatus_name[int32(*req.Status)]
-		updateReq.Status = (*models.OrderStatus)(&statusStr)
+		orderStatus := statusFromProto(*req.Status)
+		updateReq.Status = &orderStatus
 	}
 
 	resp, err := s.orderService.UpdateOrder(ctx, req.GetUuid(), updateReq)
 	if err != nil {
-		if errors.Is(err, repository.ErrOrderNotFound) {
+		switch {
+		case errors.Is(err, service.ErrOrderNotFound):
 			return nil, status.Error(codes.NotFound, "order not found")
+		case errors.Is(err, service.ErrInvalidStatusTransition):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		case errors.Is(err, service.ErrOrderStatusConflict):
+			return nil, status.Error(codes.Aborted, err.Error())
+		case errors.Is(err, service.ErrPaymentDeclined),
+			errors.Is(err, service.ErrPaymentNotAuthorized),
+			errors.Is(err, service.ErrPaymentNotCaptured):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		case errors.Is(err, service.ErrPaymentConflict),
+			errors.Is(err, service.ErrStockReturnInProgress):
+			return nil, status.Error(codes.Aborted, err.Error())
+		case errors.Is(err, service.ErrStockReturnFailed),
+			errors.Is(err, service.ErrPaymentFailed):
+			return nil, status.Error(codes.Unavailable, err.Error())
+		default:
+			return nil, status.Errorf(codes.Internal, "failed to update order: %v", err)
 		}
-		return nil, status.Errorf(codes.Internal, "failed to update order: %v", err)
 	}
 
+	_ = grpc.SetHeader(ctx, orderHeader(resp))
 	return s.orderToProto(resp), nil
 }
 
 func (s *orderServer) Delete(ctx context.Context, req *oms_pb.DeleteOrderRequest) (*emptypb.Empty, error) {
 	err := s.orderService.DeleteOrder(ctx, req.GetUuid())
 	if err != nil {
-		if errors.Is(err, repository.ErrOrderNotFound) {
+		if errors.Is(err, service.ErrOrderNotFound) {
 			return nil, status.Error(codes.NotFound, "order not found")
 		}
+		if errors.Is(err, service.ErrOrderInvoiced) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
 		return nil, status.Errorf(codes.Internal, "failed to delete order: %v", err)
 	}
 
@@ -239,6 +310,9 @@ func (s *orderServer) Delete(ctx context.Context, req *oms_pb.DeleteOrderRequest
 func (s *orderServer) GetProducts(ctx context.Context, req *oms_pb.GetProductsRequest) (*oms_pb.ListResponse, error) {
 	products, err := s.orderService.GetOrderProducts(ctx, req.GetOrderUuid())
 	if err != nil {
+		if errors.Is(err, service.ErrPermissionDenied) {
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
 		return nil, status.Errorf(codes.Internal, "failed to get order products: %v", err)
 	}
 
@@ -249,7 +323,7 @@ func (s *orderServer) GetProducts(ctx context.Context, req *oms_pb.GetProductsRe
 			Uuid:         p.ID,
 			Name:         p.Name,
 			ProductCode:  p.ProductCode,
-			CustomerCost: p.Price,
+			CustomerCost: p.Price.Float64(),
 		})
 	}
 
@@ -275,7 +349,7 @@ func (s *orderServer) orderToProto(o *models.OrderResponse) *oms_pb.Order {
 			ProductUuid: p.ID,
 			OrderUuid:   o.ID,
 			ProductCode: p.ProductCode,
-			ResultPrice: p.Price,
+			ResultPrice: p.Price.Float64(),
 			Amount:      int32(p.Amount),
 		})
 	}
@@ -285,10 +359,32 @@ func (s *orderServer) orderToProto(o *models.OrderResponse) *oms_pb.Order {
 		Comment:      o.Comment,
 		UserId:       o.UserID,
 		StaffId:      o.StaffID,
-		OrderCost:    o.OrderCost,
-		Status:       oms_pb.OrderStatus(oms_pb.OrderStatus_value[string(o.Status)]),
+		OrderCost:    o.OrderCost.Float64(),
+		Status:       statusToProto(o.Status),
 		CreationDate: timestamppb.New(creationDate),
 		FinishDate:   finishDate,
 		Products:     orderProducts,
 	}
 }
+
+// oms.proto spells the cancelled status as "canceled"
+func statusToProto(orderStatus models.OrderStatus) oms_pb.OrderStatus {
+	switch orderStatus {
+	case models.OrderStatusCancelled:
+		return oms_pb.OrderStatus_canceled
+	case models.OrderStatusPending:
+		// oms_pb has no pending status, a reserved order is reported as new
+		return oms_pb.OrderStatus_new
+	case models.OrderStatusPartiallyShipped, models.OrderStatusShipped:
+		// oms_pb has no shipping statuses, the order is still being processed
+		return oms_pb.OrderStatus_processing
+	}
+	return oms_pb.OrderStatus(oms_pb.OrderStatus_value[string(orderStatus)])
+}
+
+func statusFromProto(orderStatus oms_pb.OrderStatus) models.OrderStatus {
+	if orderStatus == oms_pb.OrderStatus_canceled {
+		return models.OrderStatusCancelled
+	}
+	return models.OrderStatus(orderStatus.String())
+}
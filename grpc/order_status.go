@@ -0,0 +1,100 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"github.com/igntnk/stocky-oms/models"
+	"github.com/igntnk/stocky-oms/protobufs/oms_ext_pb"
+	"github.com/igntnk/stocky-oms/service"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"time"
+)
+
+type orderStatusServer struct {
+	oms_ext_pb.UnimplementedOrderStatusServiceServer
+	orderService service.OrderService
+}
+
+func RegisterOrderStatusServer(server *grpc.Server, orderService service.OrderService) {
+	oms_ext_pb.RegisterOrderStatusServiceServer(server, &orderStatusServer{orderService: orderService})
+}
+
+func (s *orderStatusServer) ChangeStatus(ctx context.Context, req *oms_ext_pb.ChangeOrderStatusRequest) (*oms_ext_pb.ChangeOrderStatusResponse, error) {
+	resp, err := s.orderService.ChangeOrderStatus(ctx, req.GetOrderUuid(), models.OrderStatusChangeRequest{
+		Status: models.OrderStatus(req.GetStatus()),
+		Reason: req.GetReason(),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidOrderID):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case errors.Is(err, service.ErrOrderNotFound):
+			return nil, status.Error(codes.NotFound, "order not found")
+		case errors.Is(err, service.ErrInvalidStatusTransition):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		case errors.Is(err, service.ErrOrderStatusConflict):
+			return nil, status.Error(codes.Aborted, err.Error())
+		case errors.Is(err, service.ErrPaymentDeclined),
+			errors.Is(err, service.ErrPaymentNotAuthorized),
+			errors.Is(err, service.ErrPaymentNotCaptured):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		case errors.Is(err, service.ErrPaymentConflict),
+			errors.Is(err, service.ErrStockReturnInProgress):
+			return nil, status.Error(codes.Aborted, err.Error())
+		case errors.Is(err, service.ErrStockReturnFailed),
+			errors.Is(err, service.ErrPaymentFailed):
+			return nil, status.Error(codes.Unavailable, err.Error())
+		default:
+			return nil, status.Errorf(codes.Internal, "failed to change order status: %v", err)
+		}
+	}
+
+	var finishDate *timestamppb.Timestamp
+	if resp.FinishDate != nil {
+		fd, _ := time.Parse(time.RFC3339, *resp.FinishDate)
+		finishDate = timestamppb.New(fd)
+	}
+
+	return &oms_ext_pb.ChangeOrderStatusResponse{
+		OrderUuid:  resp.ID,
+		Status:     string(resp.Status),
+		FinishDate: finishDate,
+	}, nil
+}
+
+func (s *orderStatusServer) GetHistory(ctx context.Context, req *oms_ext_pb.GetOrderStatusHistoryRequest) (*oms_ext_pb.GetOrderStatusHistoryResponse, error) {
+	history, err := s.orderService.GetOrderStatusHistory(ctx, req.GetOrderUuid())
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidOrderID):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case errors.Is(err, service.ErrOrderNotFound):
+			return nil, status.Error(codes.NotFound, "order not found")
+		case errors.Is(err, service.ErrPermissionDenied):
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		default:
+			return nil, status.Errorf(codes.Internal, "failed to get order status history: %v", err)
+		}
+	}
+
+	entries := make([]*oms_ext_pb.OrderStatusHistoryEntry, 0, len(history))
+	for _, h := range history {
+		changedAt, _ := time.Parse(time.RFC3339, h.ChangedAt)
+		entries = append(entries, &oms_ext_pb.OrderStatusHistoryEntry{
+			Uuid:       h.ID,
+			OrderUuid:  h.OrderID,
+			FromStatus: string(h.FromStatus),
+			ToStatus:   string(h.ToStatus),
+			Actor:      h.Actor,
+			Reason:     h.Reason,
+			ChangedAt:  timestamppb.New(changedAt),
+		})
+	}
+
+	return &oms_ext_pb.GetOrderStatusHistoryResponse{
+		Entries: entries,
+	}, nil
+}
@@ -0,0 +1,74 @@
+package grpc
+
+import (
+	"github.com/igntnk/stocky-oms/models"
+	"github.com/igntnk/stocky-oms/protobufs/oms_ext_pb"
+	"github.com/igntnk/stocky-oms/service"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"time"
+)
+
+type orderWatchServer struct {
+	oms_ext_pb.UnimplementedOrderWatchServiceServer
+	watchService service.OrderWatchService
+}
+
+func RegisterOrderWatchServer(server *grpc.Server, watchService service.OrderWatchService) {
+	oms_ext_pb.RegisterOrderWatchServiceServer(server, &orderWatchServer{watchService: watchService})
+}
+
+func (s *orderWatchServer) WatchOrders(req *oms_ext_pb.WatchOrdersRequest, stream grpc.ServerStreamingServer[oms_ext_pb.OrderChange]) error {
+	filter := models.OrderWatchFilter{
+		Status:  models.OrderStatus(req.GetStatus()),
+		UserID:  req.GetUserId(),
+		StaffID: req.GetStaffId(),
+	}
+	err := validate.Struct(filter)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	changes := s.watchService.Watch(stream.Context(), filter)
+
+	for change := range changes {
+		err := stream.Send(orderChangeToProto(change))
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := stream.Context().Err(); err != nil {
+		return status.FromContextError(err).Err()
+	}
+	// the watcher fell behind and was dropped
+	return status.Error(codes.ResourceExhausted, "order watch fell behind, reload the orders and watch again")
+}
+
+func orderChangeToProto(change models.OrderChange) *oms_ext_pb.OrderChange {
+	res := &oms_ext_pb.OrderChange{
+		Type:           string(change.Type),
+		OrderUuid:      change.OrderID,
+		UserId:         change.UserID,
+		StaffId:        change.StaffID,
+		Status:         string(change.Status),
+		PreviousStatus: string(change.PreviousStatus),
+		OccurredAt:     timestamppb.New(change.OccurredAt),
+	}
+
+	if change.Order != nil {
+		creationDate, _ := time.Parse(time.RFC3339, change.Order.CreationDate)
+		res.Comment = change.Order.Comment
+		res.OrderCost = change.Order.OrderCost.String()
+		res.Currency = change.Order.Currency
+		res.CreationDate = timestamppb.New(creationDate)
+		if change.Order.FinishDate != nil {
+			finishDate, _ := time.Parse(time.RFC3339, *change.Order.FinishDate)
+			res.FinishDate = timestamppb.New(finishDate)
+		}
+	}
+
+	return res
+}
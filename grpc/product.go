@@ -4,7 +4,6 @@ import (
 	"context"
 	"errors"
 	"github.com/igntnk/stocky-2pc-controller/protobufs/oms_pb"
-	"github.com/igntnk/stocky-oms/repository"
 	"google.golang.org/grpc"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"time"
@@ -31,7 +30,8 @@ func (s *productServer) Create(ctx context.Context, req *oms_pb.CreateRequest) (
 	createReq := models.ProductCreateRequest{
 		Name:         req.GetName(),
 		ProductCode:  req.GetProductCode(),
-		CustomerCost: req.GetCustomerCost(),
+		CustomerCost: models.MoneyFromFloat(req.GetCustomerCost()),
+		Currency:     incomingMetadata(ctx, currencyMetadata),
 	}
 
 	// Call service layer
@@ -41,18 +41,20 @@ func (s *productServer) Create(ctx context.Context, req *oms_pb.CreateRequest) (
 	}
 
 	// Convert service response to protobuf
+	setCurrencyHeader(ctx, resp.Currency)
 	return s.productToProto(resp), nil
 }
 
 func (s *productServer) Get(ctx context.Context, req *oms_pb.GetRequest) (*oms_pb.Product, error) {
 	resp, err := s.service.GetProduct(ctx, req.GetUuid())
 	if err != nil {
-		if errors.Is(err, repository.ErrProductNotFound) {
+		if errors.Is(err, service.ErrProductNotFound) {
 			return nil, status.Error(codes.NotFound, "product not found")
 		}
 		return nil, status.Errorf(codes.Internal, "failed to get product: %v", err)
 	}
 
+	setCurrencyHeader(ctx, resp.Currency)
 	return s.productToProto(resp), nil
 }
 
@@ -82,24 +84,30 @@ func (s *productServer) Update(ctx context.Context, req *oms_pb.UpdateRequest) (
 		updateReq.ProductCode = req.ProductCode
 	}
 	if req.CustomerCost != nil {
-		updateReq.CustomerCost = req.CustomerCost
+		// oms_pb carries prices as doubles, they are rounded to whole cents
+		cost := models.MoneyFromFloat(req.GetCustomerCost())
+		updateReq.CustomerCost = &cost
+	}
+	if currency := incomingMetadata(ctx, currencyMetadata); currency != "" {
+		updateReq.Currency = &currency
 	}
 
 	resp, err := s.service.UpdateProduct(ctx, req.GetUuid(), updateReq)
 	if err != nil {
-		if errors.Is(err, repository.ErrProductNotFound) {
+		if errors.Is(err, service.ErrProductNotFound) {
 			return nil, status.Error(codes.NotFound, "product not found")
 		}
 		return nil, status.Errorf(codes.Internal, "failed to update product: %v", err)
 	}
 
+	setCurrencyHeader(ctx, resp.Currency)
 	return s.productToProto(resp), nil
 }
 
 func (s *productServer) Delete(ctx context.Context, req *oms_pb.DeleteRequest) (*emptypb.Empty, error) {
 	err := s.service.DeleteProduct(ctx, req.GetUuid())
 	if err != nil {
-		if errors.Is(err, repository.ErrProductNotFound) {
+		if errors.Is(err, service.ErrProductNotFound) {
 			return nil, status.Error(codes.NotFound, "product not found")
 		}
 		return nil, status.Errorf(codes.Internal, "failed to delete product: %v", err)
@@ -133,7 +141,7 @@ func (s *productServer) productToProto(p *models.ProductResponse) *oms_pb.Produc
 		Uuid:         p.ID,
 		Name:         p.Name,
 		ProductCode:  p.ProductCode,
-		CustomerCost: p.CustomerCost,
+		CustomerCost: p.CustomerCost.Float64(),
 		CreatedAt:    timestamppb.New(createdAt),
 		UpdatedAt:    timestamppb.New(updatedAt),
 	}
@@ -0,0 +1,188 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"github.com/igntnk/stocky-oms/models"
+	"github.com/igntnk/stocky-oms/protobufs/oms_ext_pb"
+	"github.com/igntnk/stocky-oms/service"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"time"
+)
+
+type promotionServer struct {
+	oms_ext_pb.UnimplementedPromotionServiceServer
+	service service.PromotionService
+}
+
+func RegisterPromotionServer(server *grpc.Server, promotionService service.PromotionService) {
+	oms_ext_pb.RegisterPromotionServiceServer(server, &promotionServer{service: promotionService})
+}
+
+func (s *promotionServer) Create(ctx context.Context, req *oms_ext_pb.CreatePromotionRequest) (*oms_ext_pb.Promotion, error) {
+	createReq := models.PromotionCreateRequest{
+		Code:         req.GetCode(),
+		Name:         req.GetName(),
+		Kind:         models.PromotionKind(req.GetKind()),
+		Scope:        models.PromotionScope(req.GetScope()),
+		ProductCode:  req.GetProductCode(),
+		Currency:     req.GetCurrency(),
+		BuyQuantity:  int(req.GetBuyQuantity()),
+		FreeQuantity: int(req.GetFreeQuantity()),
+	}
+
+	if req.GetPercent() != "" {
+		percent, err := models.ParseMoney(req.GetPercent())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid percent")
+		}
+		createReq.Percent = &percent
+	}
+	if req.GetAmount() != "" {
+		amount, err := models.ParseMoney(req.GetAmount())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid amount")
+		}
+		createReq.Amount = &amount
+	}
+	if req.UsageLimit != nil {
+		limit := int(req.GetUsageLimit())
+		createReq.UsageLimit = &limit
+	}
+	if req.ValidFrom != nil {
+		validFrom := req.GetValidFrom().AsTime()
+		createReq.ValidFrom = &validFrom
+	}
+	if req.ValidTo != nil {
+		validTo := req.GetValidTo().AsTime()
+		createReq.ValidTo = &validTo
+	}
+
+	resp, err := s.service.CreatePromotion(ctx, createReq)
+	if err != nil {
+		return nil, promotionError(err, "failed to create promotion")
+	}
+
+	return promotionToProto(resp), nil
+}
+
+func (s *promotionServer) Get(ctx context.Context, req *oms_ext_pb.GetPromotionRequest) (*oms_ext_pb.Promotion, error) {
+	resp, err := s.service.GetPromotion(ctx, req.GetUuid())
+	if err != nil {
+		return nil, promotionError(err, "failed to get promotion")
+	}
+
+	return promotionToProto(resp), nil
+}
+
+func (s *promotionServer) List(ctx context.Context, req *oms_ext_pb.ListPromotionsRequest) (*oms_ext_pb.ListPromotionsResponse, error) {
+	resp, err := s.service.ListPromotions(ctx, models.PromotionFilter{
+		Limit:  int(req.GetLimit()),
+		Offset: int(req.GetOffset()),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list promotions: %v", err)
+	}
+
+	promotions := make([]*oms_ext_pb.Promotion, 0, len(resp))
+	for _, p := range resp {
+		promotions = append(promotions, promotionToProto(p))
+	}
+
+	return &oms_ext_pb.ListPromotionsResponse{
+		Promotions: promotions,
+	}, nil
+}
+
+func (s *promotionServer) Update(ctx context.Context, req *oms_ext_pb.UpdatePromotionRequest) (*oms_ext_pb.Promotion, error) {
+	updateReq := models.PromotionUpdateRequest{
+		Name:   req.Name,
+		Active: req.Active,
+	}
+
+	if req.UsageLimit != nil {
+		limit := int(req.GetUsageLimit())
+		updateReq.UsageLimit = &limit
+	}
+	if req.ValidFrom != nil {
+		validFrom := req.GetValidFrom().AsTime()
+		updateReq.ValidFrom = &validFrom
+	}
+	if req.ValidTo != nil {
+		validTo := req.GetValidTo().AsTime()
+		updateReq.ValidTo = &validTo
+	}
+
+	resp, err := s.service.UpdatePromotion(ctx, req.GetUuid(), updateReq)
+	if err != nil {
+		return nil, promotionError(err, "failed to update promotion")
+	}
+
+	return promotionToProto(resp), nil
+}
+
+func (s *promotionServer) Delete(ctx context.Context, req *oms_ext_pb.DeletePromotionRequest) (*oms_ext_pb.DeletePromotionResponse, error) {
+	err := s.service.DeletePromotion(ctx, req.GetUuid())
+	if err != nil {
+		return nil, promotionError(err, "failed to delete promotion")
+	}
+
+	return &oms_ext_pb.DeletePromotionResponse{}, nil
+}
+
+func promotionError(err error, msg string) error {
+	switch {
+	case errors.Is(err, service.ErrInvalidPromotionID),
+		errors.Is(err, service.ErrInvalidPromotion):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, service.ErrPromotionNotFound):
+		return status.Error(codes.NotFound, "promotion not found")
+	case errors.Is(err, service.ErrPromotionCodeUsed):
+		return status.Error(codes.AlreadyExists, err.Error())
+	default:
+		return status.Errorf(codes.Internal, "%s: %v", msg, err)
+	}
+}
+
+func promotionToProto(p *models.PromotionResponse) *oms_ext_pb.Promotion {
+	createdAt, _ := time.Parse(time.RFC3339, p.CreatedAt)
+
+	res := &oms_ext_pb.Promotion{
+		Uuid:         p.ID,
+		Code:         p.Code,
+		Name:         p.Name,
+		Kind:         string(p.Kind),
+		Scope:        string(p.Scope),
+		ProductCode:  p.ProductCode,
+		Currency:     p.Currency,
+		BuyQuantity:  int32(p.BuyQuantity),
+		FreeQuantity: int32(p.FreeQuantity),
+		UsageCount:   int32(p.UsageCount),
+		Active:       p.Active,
+		CreatedAt:    timestamppb.New(createdAt),
+	}
+
+	if p.Percent != nil {
+		res.Percent = p.Percent.String()
+	}
+	if p.Amount != nil {
+		res.Amount = p.Amount.String()
+	}
+	if p.UsageLimit != nil {
+		limit := int32(*p.UsageLimit)
+		res.UsageLimit = &limit
+	}
+	if p.ValidFrom != nil {
+		validFrom, _ := time.Parse(time.RFC3339, *p.ValidFrom)
+		res.ValidFrom = timestamppb.New(validFrom)
+	}
+	if p.ValidTo != nil {
+		validTo, _ := time.Parse(time.RFC3339, *p.ValidTo)
+		res.ValidTo = timestamppb.New(validTo)
+	}
+
+	return res
+}
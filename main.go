@@ -2,13 +2,17 @@ package main
 
 import (
 	trmpgx "github.com/avito-tech/go-transaction-manager/pgxv5"
+	"github.com/igntnk/stocky-oms/auth"
 	"github.com/igntnk/stocky-oms/clients"
 	"github.com/igntnk/stocky-oms/config"
 	"github.com/igntnk/stocky-oms/controllers"
+	"github.com/igntnk/stocky-oms/events"
 	grpcapp "github.com/igntnk/stocky-oms/grpc"
+	"github.com/igntnk/stocky-oms/models"
 	"github.com/igntnk/stocky-oms/repository"
 	"github.com/igntnk/stocky-oms/service"
 	"github.com/igntnk/stocky-oms/web"
+	"github.com/igntnk/stocky-oms/workers"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/jackc/pgx/v5/stdlib"
 	"github.com/pressly/goose/v3"
@@ -35,6 +39,9 @@ func main() {
 		return
 	}
 
+	// queries run with the tenant of their context, row level security relies on it
+	repository.ScopeTenant(dbConf)
+
 	pool, err := pgxpool.NewWithConfig(mainCtx, dbConf)
 	if err != nil {
 		logger.Fatal().Err(err).Msg("failed to connect to database")
@@ -83,24 +90,169 @@ func main() {
 
 	conn := trmpgx.DefaultCtxGetter.DefaultTrOrDB(mainCtx, pool)
 
-	productRepo := repository.NewProductRepository(conn)
+	productRepo := repository.NewProductRepository(pool)
 	orderRepo := repository.NewOrderRepository(pool)
+	stockReturnRepo := repository.NewStockReturnRepository(conn)
+	sagaRepo := repository.NewSagaRepository(conn)
+	idempotencyRepo := repository.NewIdempotencyRepository(conn)
+	promotionRepo := repository.NewPromotionRepository(conn)
+	taxRuleRepo := repository.NewTaxRuleRepository(conn)
+	priceScheduleRepo := repository.NewPriceScheduleRepository(pool)
+	shipmentRepo := repository.NewShipmentRepository(pool)
+	returnRepo := repository.NewReturnRepository(pool)
+	paymentRepo := repository.NewPaymentRepository(conn)
+	invoiceRepo := repository.NewInvoiceRepository(pool)
+	customerRepo := repository.NewCustomerRepository(pool)
+	shippingMethodRepo := repository.NewShippingMethodRepository(conn)
+	orderNotifier := repository.NewOrderNotifier(pool)
+
+	currencyConverter, err := service.NewCurrencyConverter(cfg.Currency.Default, cfg.Currency.Rates)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("failed to load currency rates")
+		return
+	}
+
+	var paymentProvider clients.PaymentProvider
+	switch cfg.Payment.Provider {
+	case "fake":
+		var limit models.Money
+		if cfg.Payment.FakeLimit != "" {
+			limit, err = models.ParseMoney(cfg.Payment.FakeLimit)
+			if err != nil {
+				logger.Fatal().Err(err).Msg("failed to parse fake payment limit")
+				return
+			}
+		}
+		paymentProvider = clients.NewFakePaymentProvider(limit)
+	default:
+		logger.Fatal().Str("provider", cfg.Payment.Provider).Msg("unknown payment provider")
+		return
+	}
+
+	paymentService := service.NewPaymentService(paymentProvider, paymentRepo, orderRepo)
+	productService := service.NewProductService(productRepo, currencyConverter.Default())
+	orderService := service.NewOrderService(smsClient, omsClient, orderRepo, productRepo, stockReturnRepo, sagaRepo, promotionRepo, taxRuleRepo, shippingMethodRepo, customerRepo, paymentService, cfg.TCC.ReservationTTL, currencyConverter, cfg.Tax.DefaultRegion)
+	idempotencyService := service.NewIdempotencyService(idempotencyRepo, cfg.Idempotency.TTL)
+	promotionService := service.NewPromotionService(promotionRepo, currencyConverter.Default())
+	taxService := service.NewTaxService(taxRuleRepo)
+	shippingMethodService := service.NewShippingMethodService(shippingMethodRepo, currencyConverter.Default())
+	priceScheduleService := service.NewPriceScheduleService(priceScheduleRepo, productRepo)
+	shipmentService := service.NewShipmentService(shipmentRepo, orderRepo, paymentService)
+	returnService := service.NewReturnService(smsClient, returnRepo, orderRepo, paymentService)
+	invoiceFont := cfg.Invoice.Font
+	err = service.CheckInvoiceFont(invoiceFont)
+	if err != nil {
+		logger.Warn().Err(err).Str("font", invoiceFont).Msg("PDF invoices are disabled")
+		invoiceFont = ""
+	}
+	invoiceService := service.NewInvoiceService(
+		invoiceRepo,
+		orderService,
+		models.InvoiceSeller{
+			Name:    cfg.Invoice.SellerName,
+			Address: cfg.Invoice.SellerAddress,
+			TaxID:   cfg.Invoice.SellerTaxID,
+		},
+		cfg.Invoice.NumberPrefix,
+		invoiceFont,
+	)
+	orderWatchService := service.NewOrderWatchService(orderService)
+	customerService := service.NewCustomerService(customerRepo, orderService)
+
+	// workers act for every shop
+	workerCtx := auth.WithTenant(mainCtx, auth.AllTenants)
+
+	sagaRecovery := workers.NewSagaRecovery(logger, orderService, cfg.Saga.RecoveryInterval, cfg.Saga.RecoveryAfter)
+	go sagaRecovery.Run(workerCtx)
 
-	productService := service.NewProductService(productRepo)
-	orderService := service.NewOrderService(smsClient, omsClient, orderRepo, productRepo)
+	reservationSweeper := workers.NewReservationSweeper(logger, orderService, cfg.TCC.SweepInterval)
+	go reservationSweeper.Run(workerCtx)
 
-	grpcServer := grpc.NewServer()
-	grpcapp.RegisterOrderServer(grpcServer, smsClient, productService, orderService)
+	var publisher events.Publisher
+	switch cfg.Outbox.Publisher {
+	case "stdout":
+		publisher = events.NewWriterPublisher(os.Stdout)
+	default:
+		logger.Fatal().Str("publisher", cfg.Outbox.Publisher).Msg("unknown outbox publisher")
+		return
+	}
+
+	outboxRepo := repository.NewOutboxRepository(pool)
+	outboxRelay := workers.NewOutboxRelay(logger, outboxRepo, publisher, cfg.Outbox.PollInterval, cfg.Outbox.BatchSize)
+	go outboxRelay.Run(workerCtx)
+
+	idempotencyCleanup := workers.NewIdempotencyCleanup(logger, idempotencyService, cfg.Idempotency.CleanupInterval)
+	go idempotencyCleanup.Run(workerCtx)
+
+	priceScheduler := workers.NewPriceScheduler(logger, priceScheduleService, cfg.PriceSchedule.Interval)
+	go priceScheduler.Run(workerCtx)
+
+	orderWatchRelay := workers.NewOrderWatchRelay(logger, orderNotifier, orderWatchService, cfg.OrderWatch.RetryInterval)
+	go orderWatchRelay.Run(workerCtx)
+
+	keys, err := auth.LoadKeySet(cfg.Auth.JWKSFile)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("failed to load token keys")
+		return
+	}
+	verifier := auth.NewVerifier(keys, cfg.Auth.Issuer, cfg.Auth.Audience, cfg.Auth.RoleClaim, cfg.Auth.TenantClaim)
+
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			grpcapp.UnaryAuthInterceptor(verifier),
+			grpcapp.UnaryTenantInterceptor(cfg.Tenant.Default),
+			grpcapp.UnaryPolicyInterceptor(auth.DefaultPolicy),
+		),
+		grpc.ChainStreamInterceptor(
+			grpcapp.StreamAuthInterceptor(verifier),
+			grpcapp.StreamTenantInterceptor(cfg.Tenant.Default),
+			grpcapp.StreamPolicyInterceptor(auth.DefaultPolicy),
+		),
+	)
+	grpcapp.RegisterOrderServer(grpcServer, productService, orderService, idempotencyService)
 	grpcapp.RegisterProductServer(grpcServer, productService)
+	grpcapp.RegisterOrderStatusServer(grpcServer, orderService)
+	grpcapp.RegisterPromotionServer(grpcServer, promotionService)
+	grpcapp.RegisterInvoiceServer(grpcServer, invoiceService)
+	grpcapp.RegisterOrderWatchServer(grpcServer, orderWatchService)
 
 	cookedGrpcServer := grpcapp.New(grpcServer, cfg.Server.GRPCPort, logger)
 	go func() {
 		cookedGrpcServer.MustRun()
 	}()
 
-	orderController := controllers.NewOrderController(orderService)
+	orderController := controllers.NewOrderController(orderService, idempotencyService)
+	productController := controllers.NewProductController(productService)
+	promotionController := controllers.NewPromotionController(promotionService)
+	taxController := controllers.NewTaxController(taxService)
+	shippingMethodController := controllers.NewShippingMethodController(shippingMethodService)
+	priceScheduleController := controllers.NewPriceScheduleController(priceScheduleService)
+	shipmentController := controllers.NewShipmentController(shipmentService)
+	returnController := controllers.NewReturnController(returnService)
+	paymentController := controllers.NewPaymentController(paymentService)
+	invoiceController := controllers.NewInvoiceController(invoiceService)
+	orderWatchController := controllers.NewOrderWatchController(orderWatchService)
+	customerController := controllers.NewCustomerController(customerService)
 
-	httpServer, err := web.New(logger, cfg.Server.RESTPort, orderController)
+	httpServer, err := web.New(
+		logger,
+		cfg.Server.RESTPort,
+		verifier,
+		auth.DefaultPolicy,
+		cfg.Tenant.Default,
+		orderController,
+		productController,
+		promotionController,
+		taxController,
+		shippingMethodController,
+		priceScheduleController,
+		shipmentController,
+		returnController,
+		paymentController,
+		invoiceController,
+		orderWatchController,
+		customerController,
+	)
 	if err != nil {
 		logger.Fatal().Err(err).Send()
 		return
@@ -110,7 +262,7 @@ func main() {
 	go func() {
 		serverErrorChan <- httpServer.ListenAndServe()
 	}()
-	logger.Info().Msgf("Server started on port: %s", cfg.Server.RESTPort)
+	logger.Info().Msgf("Server started on port: %d", cfg.Server.RESTPort)
 
 	select {
 	case <-mainCtx.Done():
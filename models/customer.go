@@ -0,0 +1,76 @@
+package models
+
+type CustomerFilter struct {
+	Limit  int `json:"limit" form:"limit" validate:"min=1,max=100"`
+	Offset int `json:"offset" form:"offset" validate:"min=0"`
+}
+
+// Address is a postal address, Country is the ISO 3166-1 alpha-2 code
+type Address struct {
+	Label      string `json:"label,omitempty" validate:"max=64"`
+	Recipient  string `json:"recipient" validate:"required,max=120"`
+	Line1      string `json:"line1" validate:"required,max=200"`
+	Line2      string `json:"line2,omitempty" validate:"max=200"`
+	City       string `json:"city" validate:"required,max=100"`
+	Region     string `json:"region,omitempty" validate:"max=100"`
+	PostalCode string `json:"postal_code,omitempty" validate:"max=20"`
+	Country    string `json:"country" validate:"required,iso3166_1_alpha2"`
+	Phone      string `json:"phone,omitempty" validate:"max=32"`
+}
+
+// CustomerCreateRequest registers the user orders are placed for. Customers
+// can only register themselves, UserID is ignored for them.
+type CustomerCreateRequest struct {
+	UserID string `json:"user_id" validate:"max=64"`
+	Name   string `json:"name" validate:"max=120"`
+	Email  string `json:"email,omitempty" validate:"omitempty,email,max=254"`
+	Phone  string `json:"phone,omitempty" validate:"max=32"`
+}
+
+type CustomerUpdateRequest struct {
+	Name  *string `json:"name,omitempty" validate:"omitempty,max=120"`
+	Email *string `json:"email,omitempty" validate:"omitempty,email,max=254"`
+	Phone *string `json:"phone,omitempty" validate:"omitempty,max=32"`
+	// DefaultShippingAddressID must be one of the customer addresses
+	DefaultShippingAddressID *string `json:"default_shipping_address_id,omitempty" validate:"omitempty,uuid"`
+}
+
+type CustomerAddressRequest struct {
+	Address
+	// DefaultShipping makes the address the default shipping address
+	DefaultShipping bool `json:"default_shipping"`
+}
+
+type AddressResponse struct {
+	ID string `json:"id"`
+	Address
+	DefaultShipping bool   `json:"default_shipping"`
+	CreatedAt       string `json:"created_at"`
+}
+
+type CustomerResponse struct {
+	ID                       string             `json:"id"`
+	UserID                   string             `json:"user_id"`
+	Name                     string             `json:"name"`
+	Email                    string             `json:"email"`
+	Phone                    string             `json:"phone"`
+	DefaultShippingAddressID *string            `json:"default_shipping_address_id"`
+	Addresses                []*AddressResponse `json:"addresses"`
+	CreatedAt                string             `json:"created_at"`
+	UpdatedAt                string             `json:"updated_at"`
+}
+
+type CurrencyAmount struct {
+	Currency string `json:"currency"`
+	Amount   Money  `json:"amount"`
+}
+
+// CustomerStatsResponse is the lifetime of a customer. Pending orders are not
+// counted, TotalSpent leaves out cancelled orders and refunds and has an
+// amount per order currency.
+type CustomerStatsResponse struct {
+	UserID      string           `json:"user_id"`
+	OrderCount  int              `json:"order_count"`
+	TotalSpent  []CurrencyAmount `json:"total_spent"`
+	LastOrderAt *string          `json:"last_order_at"`
+}
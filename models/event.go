@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+type OrderEventType string
+
+const (
+	OrderEventCreated       OrderEventType = "OrderCreated"
+	OrderEventUpdated       OrderEventType = "OrderUpdated"
+	OrderEventStatusChanged OrderEventType = "OrderStatusChanged"
+	OrderEventDeleted       OrderEventType = "OrderDeleted"
+)
+
+// OrderEvent is published to downstream consumers when an order changes
+type OrderEvent struct {
+	ID             string         `json:"id,omitempty"`
+	Type           OrderEventType `json:"type"`
+	TenantID       string         `json:"tenant_id"`
+	OrderID        string         `json:"order_id"`
+	UserID         string         `json:"user_id"`
+	StaffID        string         `json:"staff_id"`
+	Status         OrderStatus    `json:"status"`
+	PreviousStatus OrderStatus    `json:"previous_status,omitempty"`
+	OccurredAt     time.Time      `json:"occurred_at"`
+}
+
+// OrderWatchFilter selects the order changes pushed to a watcher. Empty fields
+// match every order.
+type OrderWatchFilter struct {
+	Status  OrderStatus `json:"status,omitempty" form:"status" validate:"omitempty,oneof=pending new processing partially_shipped shipped completed cancelled"`
+	UserID  string      `json:"user_id,omitempty" form:"user_id" validate:"max=64"`
+	StaffID string      `json:"staff_id,omitempty" form:"staff_id" validate:"max=64"`
+}
+
+// OrderChange is pushed to order watchers. Order is the order after the
+// change and is empty for deleted orders.
+type OrderChange struct {
+	OrderEvent
+	Order *OrderResponse `json:"order,omitempty"`
+}
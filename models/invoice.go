@@ -0,0 +1,32 @@
+package models
+
+type InvoiceFormat string
+
+const (
+	InvoiceFormatJSON InvoiceFormat = "json"
+	InvoiceFormatHTML InvoiceFormat = "html"
+	InvoiceFormatPDF  InvoiceFormat = "pdf"
+)
+
+// InvoiceQuery selects how the invoice is returned, PDF by default
+type InvoiceQuery struct {
+	Format InvoiceFormat `form:"format" validate:"omitempty,oneof=json html pdf"`
+}
+
+// InvoiceSeller is the issuer printed on invoices
+type InvoiceSeller struct {
+	Name    string `json:"name"`
+	Address string `json:"address,omitempty"`
+	TaxID   string `json:"tax_id,omitempty"`
+}
+
+// InvoiceResponse is an issued invoice. Seller and Order are kept as they were
+// when the invoice was issued and don't follow later changes.
+type InvoiceResponse struct {
+	ID       string        `json:"id"`
+	Number   string        `json:"number"`
+	OrderID  string        `json:"order_id"`
+	IssuedAt string        `json:"issued_at"`
+	Seller   InvoiceSeller `json:"seller"`
+	Order    OrderResponse `json:"order"`
+}
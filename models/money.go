@@ -0,0 +1,182 @@
+package models
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// moneyScale is the number of minor units in one major unit, matching the
+// DECIMAL(10, 2) columns
+const moneyScale = 100
+
+var ErrInvalidMoney = errors.New("invalid money amount")
+
+// Money is an exact amount with two decimal places stored in minor units.
+// It is encoded as a JSON string so cents are never lost to float rounding.
+type Money struct {
+	cents int64
+}
+
+func MoneyFromCents(cents int64) Money {
+	return Money{cents: cents}
+}
+
+// MoneyFromFloat rounds f to the nearest cent. It is meant only for APIs that
+// carry money as floats, such as the protobuf messages.
+func MoneyFromFloat(f float64) Money {
+	return Money{cents: int64(math.Round(f * moneyScale))}
+}
+
+// ParseMoney parses a decimal string such as "12", "-0.5" or "1999.99".
+// More than two significant decimal places are rejected.
+func ParseMoney(s string) (Money, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Money{}, ErrInvalidMoney
+	}
+
+	negative := false
+	switch s[0] {
+	case '-':
+		negative = true
+		s = s[1:]
+	case '+':
+		s = s[1:]
+	}
+
+	whole, frac, _ := strings.Cut(s, ".")
+	frac = strings.TrimRight(frac, "0")
+	if whole == "" && frac == "" || len(frac) > 2 || !isDigits(whole) || !isDigits(frac) {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidMoney, s)
+	}
+	for len(frac) < 2 {
+		frac += "0"
+	}
+
+	var units int64
+	if whole != "" {
+		var err error
+		units, err = strconv.ParseInt(whole, 10, 64)
+		if err != nil || units > math.MaxInt64/moneyScale-1 {
+			return Money{}, fmt.Errorf("%w: %q", ErrInvalidMoney, s)
+		}
+	}
+
+	cents, err := strconv.ParseInt(frac, 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidMoney, s)
+	}
+
+	m := Money{cents: units*moneyScale + cents}
+	if negative {
+		m.cents = -m.cents
+	}
+	return m, nil
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func (m Money) Cents() int64 {
+	return m.cents
+}
+
+func (m Money) Add(o Money) Money {
+	return Money{cents: m.cents + o.cents}
+}
+
+func (m Money) Sub(o Money) Money {
+	return Money{cents: m.cents - o.cents}
+}
+
+// Mul multiplies the amount by a quantity
+func (m Money) Mul(n int64) Money {
+	return Money{cents: m.cents * n}
+}
+
+// MulRat multiplies the amount by a rate, rounding half away from zero to cents
+func (m Money) MulRat(r *big.Rat) Money {
+	product := new(big.Rat).Mul(new(big.Rat).SetInt64(m.cents), r)
+
+	var rem big.Int
+	quo, _ := new(big.Int).QuoRem(product.Num(), product.Denom(), &rem)
+	// |rem| * 2 >= denom means the fraction is at least one half
+	if rem.Sign() != 0 && new(big.Int).Mul(new(big.Int).Abs(&rem), big.NewInt(2)).Cmp(product.Denom()) >= 0 {
+		quo.Add(quo, big.NewInt(int64(rem.Sign())))
+	}
+	return Money{cents: quo.Int64()}
+}
+
+func (m Money) Cmp(o Money) int {
+	switch {
+	case m.cents < o.cents:
+		return -1
+	case m.cents > o.cents:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func (m Money) Sign() int {
+	return m.Cmp(Money{})
+}
+
+func (m Money) IsZero() bool {
+	return m.cents == 0
+}
+
+// Float64 is the closest float to the amount, for protobuf doubles only
+func (m Money) Float64() float64 {
+	return float64(m.cents) / moneyScale
+}
+
+func (m Money) String() string {
+	cents := m.cents
+	sign := ""
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, cents/moneyScale, cents%moneyScale)
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(m.String())), nil
+}
+
+// UnmarshalJSON accepts both strings and bare numbers. Numbers are parsed from
+// their text so no float conversion happens.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	text := string(data)
+	if len(data) > 0 && data[0] == '"' {
+		var err error
+		text, err = strconv.Unquote(text)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidMoney, data)
+		}
+	}
+
+	parsed, err := ParseMoney(text)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
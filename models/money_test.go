@@ -0,0 +1,149 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		in      string
+		cents   int64
+		wantErr bool
+	}{
+		{in: "12", cents: 1200},
+		{in: "1999.99", cents: 199999},
+		{in: "-0.5", cents: -50},
+		{in: "+3.10", cents: 310},
+		{in: ".75", cents: 75},
+		{in: "2.500", cents: 250},
+		{in: " 7 ", cents: 700},
+		{in: "", wantErr: true},
+		{in: "1.234", wantErr: true},
+		{in: "1,5", wantErr: true},
+		{in: "abc", wantErr: true},
+		{in: "-", wantErr: true},
+		{in: "99999999999999999999", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseMoney(tt.in)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidMoney) {
+					t.Fatalf("ParseMoney(%q) error = %v, want ErrInvalidMoney", tt.in, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseMoney(%q) error = %v", tt.in, err)
+			}
+			if got.Cents() != tt.cents {
+				t.Errorf("ParseMoney(%q) = %d cents, want %d", tt.in, got.Cents(), tt.cents)
+			}
+		})
+	}
+}
+
+func TestMoneyArithmetic(t *testing.T) {
+	tests := []struct {
+		name string
+		got  Money
+		want int64
+	}{
+		{name: "add", got: MoneyFromCents(1050).Add(MoneyFromCents(275)), want: 1325},
+		{name: "sub below zero", got: MoneyFromCents(100).Sub(MoneyFromCents(250)), want: -150},
+		{name: "mul", got: MoneyFromCents(333).Mul(3), want: 999},
+		{name: "mul rat exact", got: MoneyFromCents(1000).MulRat(big.NewRat(1, 4)), want: 250},
+		{name: "mul rat rounds up", got: MoneyFromCents(999).MulRat(big.NewRat(1, 8)), want: 125},
+		{name: "mul rat rounds down", got: MoneyFromCents(1001).MulRat(big.NewRat(1, 3)), want: 334},
+		{name: "mul rat rounds half away from zero", got: MoneyFromCents(-5).MulRat(big.NewRat(1, 2)), want: -3},
+		{name: "from float", got: MoneyFromFloat(0.1 + 0.2), want: 30},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got.Cents() != tt.want {
+				t.Errorf("got %d cents, want %d", tt.got.Cents(), tt.want)
+			}
+		})
+	}
+}
+
+func TestMoneyCmp(t *testing.T) {
+	tests := []struct {
+		a, b int64
+		want int
+	}{
+		{a: 1, b: 2, want: -1},
+		{a: 2, b: 1, want: 1},
+		{a: -3, b: -3, want: 0},
+	}
+
+	for _, tt := range tests {
+		if got := MoneyFromCents(tt.a).Cmp(MoneyFromCents(tt.b)); got != tt.want {
+			t.Errorf("Cmp(%d, %d) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestMoneyString(t *testing.T) {
+	tests := []struct {
+		cents int64
+		want  string
+	}{
+		{cents: 0, want: "0.00"},
+		{cents: 5, want: "0.05"},
+		{cents: 123456, want: "1234.56"},
+		{cents: -75, want: "-0.75"},
+	}
+
+	for _, tt := range tests {
+		if got := MoneyFromCents(tt.cents).String(); got != tt.want {
+			t.Errorf("String() of %d cents = %q, want %q", tt.cents, got, tt.want)
+		}
+	}
+}
+
+func TestMoneyJSON(t *testing.T) {
+	tests := []struct {
+		in      string
+		cents   int64
+		wantErr bool
+	}{
+		{in: `"12.30"`, cents: 1230},
+		{in: `12.3`, cents: 1230},
+		{in: `null`, cents: 0},
+		{in: `"0.001"`, wantErr: true},
+		{in: `true`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			var m Money
+			err := json.Unmarshal([]byte(tt.in), &m)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Unmarshal(%s) = %s, want error", tt.in, m)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unmarshal(%s) error = %v", tt.in, err)
+			}
+			if m.Cents() != tt.cents {
+				t.Errorf("Unmarshal(%s) = %d cents, want %d", tt.in, m.Cents(), tt.cents)
+			}
+		})
+	}
+
+	data, err := json.Marshal(MoneyFromCents(-1005))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `"-10.05"` {
+		t.Errorf("Marshal = %s, want \"-10.05\"", data)
+	}
+}
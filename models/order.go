@@ -9,10 +9,16 @@ import (
 type OrderStatus string
 
 const (
+	// OrderStatusPending is an order reserved by a TCC Try that is not confirmed yet
+	OrderStatusPending    OrderStatus = "pending"
 	OrderStatusNew        OrderStatus = "new"
 	OrderStatusProcessing OrderStatus = "processing"
-	OrderStatusCompleted  OrderStatus = "completed"
-	OrderStatusCancelled  OrderStatus = "cancelled"
+	// OrderStatusPartiallyShipped and OrderStatusShipped are derived from the
+	// order shipments and can't be set directly
+	OrderStatusPartiallyShipped OrderStatus = "partially_shipped"
+	OrderStatusShipped          OrderStatus = "shipped"
+	OrderStatusCompleted        OrderStatus = "completed"
+	OrderStatusCancelled        OrderStatus = "cancelled"
 )
 
 type Order struct {
@@ -20,7 +26,8 @@ type Order struct {
 	Comment      string
 	UserID       string
 	StaffID      string
-	OrderCost    float64
+	OrderCost    Money
+	Currency     string
 	Status       OrderStatus
 	CreationDate time.Time
 	FinishDate   *time.Time
@@ -28,23 +35,35 @@ type Order struct {
 }
 
 type OrderFilter struct {
-	Limit  int         `json:"limit" validate:"min=1,max=100"`
-	Offset int         `json:"offset" validate:"min=0"`
-	Status OrderStatus `json:"status,omitempty" validate:"omitempty,oneof=new processing completed cancelled"`
+	Limit  int         `json:"limit" form:"limit" validate:"min=1,max=100"`
+	Offset int         `json:"offset" form:"offset" validate:"min=0"`
+	Status OrderStatus `json:"status,omitempty" form:"status" validate:"omitempty,oneof=pending new processing partially_shipped shipped completed cancelled"`
+	// UserID is ignored for customers, they only see their own orders
+	UserID string `json:"user_id,omitempty" form:"user_id" validate:"max=64"`
 }
 type OrderProduct struct {
 	ProductID   uuid.UUID
 	OrderID     uuid.UUID
-	ResultPrice float64
+	ResultPrice Money
 	Amount      int
 	ProductName string // denormalized for convenience
 }
 
 type OrderCreateRequest struct {
-	UserID   string              `json:"user_id" validate:"required,uuid"`
-	StaffID  string              `json:"staff_id" validate:"required,uuid"`
+	UserID   string              `json:"user_id" validate:"required,max=64"`
+	StaffID  string              `json:"staff_id" validate:"required,max=64"`
 	Comment  string              `json:"comment" validate:"max=500"`
+	Currency string              `json:"currency,omitempty" validate:"omitempty,iso4217"`
+	Coupons  []string            `json:"coupons,omitempty" validate:"max=10,dive,min=1,max=64"`
+	Region   string              `json:"region,omitempty" validate:"max=16"`
 	Products []OrderProductInput `json:"products" validate:"required,min=1,dive"`
+	// ShippingMethod is the code of the shipping method, orders without one
+	// are not shipped and cost no shipping
+	ShippingMethod string `json:"shipping_method,omitempty" validate:"max=32"`
+	// ShippingAddress defaults to the default shipping address of the
+	// customer, BillingAddress to the shipping address
+	ShippingAddress *Address `json:"shipping_address,omitempty" validate:"omitempty"`
+	BillingAddress  *Address `json:"billing_address,omitempty" validate:"omitempty"`
 }
 
 type OrderProductInput struct {
@@ -52,28 +71,76 @@ type OrderProductInput struct {
 	Amount    int       `json:"amount" validate:"required,min=1,max=100"`
 }
 
+// OrderProductAmountRequest sets the quantity of a product in an order
+type OrderProductAmountRequest struct {
+	Amount int `json:"amount" validate:"required,min=1,max=100"`
+}
+
 type OrderUpdateRequest struct {
 	Comment *string      `json:"comment,omitempty" validate:"omitempty,max=500"`
 	Status  *OrderStatus `json:"status,omitempty" validate:"omitempty,oneof=new processing completed cancelled"`
+	Reason  string       `json:"reason,omitempty" validate:"max=500"`
+}
+
+type OrderStatusChangeRequest struct {
+	Status OrderStatus `json:"status" validate:"required,oneof=new processing completed cancelled"`
+	Reason string      `json:"reason" validate:"max=500"`
+}
+
+type OrderCancelRequest struct {
+	Reason string `json:"reason" validate:"max=500"`
+}
+
+type OrderStatusHistoryResponse struct {
+	ID         string      `json:"id"`
+	OrderID    string      `json:"order_id"`
+	FromStatus OrderStatus `json:"from_status"`
+	ToStatus   OrderStatus `json:"to_status"`
+	Actor      string      `json:"actor"`
+	Reason     string      `json:"reason,omitempty"`
+	ChangedAt  string      `json:"changed_at"`
 }
 
+// OrderResponse is an order with its totals. OrderCost is the gross amount,
+// NetAmount plus TaxAmount.
 type OrderResponse struct {
-	ID           string          `json:"id"`
-	Comment      string          `json:"comment"`
-	UserID       string          `json:"user_id"`
-	StaffID      string          `json:"staff_id"`
-	OrderCost    float64         `json:"order_cost"`
-	Status       OrderStatus     `json:"status"`
-	CreationDate string          `json:"creation_date"`
-	FinishDate   *string         `json:"finish_date,omitempty"`
-	Products     []ProductDetail `json:"products"`
+	ID               string          `json:"id"`
+	Comment          string          `json:"comment"`
+	UserID           string          `json:"user_id"`
+	StaffID          string          `json:"staff_id"`
+	OrderCost        Money           `json:"order_cost"`
+	NetAmount        Money           `json:"net_amount"`
+	TaxAmount        Money           `json:"tax_amount"`
+	Region           string          `json:"region,omitempty"`
+	Discount         Money           `json:"discount"`
+	ShippingCost     Money           `json:"shipping_cost"`
+	ShippingMethodID string          `json:"shipping_method_id,omitempty"`
+	ShippingAddress  *Address        `json:"shipping_address,omitempty"`
+	BillingAddress   *Address        `json:"billing_address,omitempty"`
+	Promotions       []string        `json:"promotions,omitempty"`
+	Currency         string          `json:"currency"`
+	Status           OrderStatus     `json:"status"`
+	CreationDate     string          `json:"creation_date"`
+	FinishDate       *string         `json:"finish_date,omitempty"`
+	Products         []ProductDetail `json:"products"`
 }
 
+// ProductDetail is an order line. Price is the unit price after per-unit
+// promotions and TotalPrice is net of the line discount. Tax is added to
+// TotalPrice unless TaxInclusive is set. PriceHistoryID is the price history
+// entry the line was priced from.
 type ProductDetail struct {
-	ID          string  `json:"id"`
-	Name        string  `json:"name"`
-	Price       float64 `json:"price"`
-	ProductCode string  `json:"product_code"`
-	Amount      int     `json:"amount"`
-	TotalPrice  float64 `json:"total_price"`
+	ID             string   `json:"id"`
+	Name           string   `json:"name"`
+	ListPrice      Money    `json:"list_price"`
+	Price          Money    `json:"price"`
+	ProductCode    string   `json:"product_code"`
+	Amount         int      `json:"amount"`
+	Discount       Money    `json:"discount"`
+	TotalPrice     Money    `json:"total_price"`
+	TaxRate        Money    `json:"tax_rate"`
+	Tax            Money    `json:"tax"`
+	TaxInclusive   bool     `json:"tax_inclusive"`
+	Promotions     []string `json:"promotions,omitempty"`
+	PriceHistoryID string   `json:"price_history_id,omitempty"`
 }
@@ -0,0 +1,28 @@
+package models
+
+type PaymentStatus string
+
+const (
+	PaymentStatusAuthorized PaymentStatus = "authorized"
+	PaymentStatusCaptured   PaymentStatus = "captured"
+	PaymentStatusVoided     PaymentStatus = "voided"
+	PaymentStatusRefunded   PaymentStatus = "refunded"
+	PaymentStatusFailed     PaymentStatus = "failed"
+)
+
+// PaymentResponse is a payment of an order. Failed payments are kept with the
+// provider error.
+type PaymentResponse struct {
+	ID             string        `json:"id"`
+	OrderID        string        `json:"order_id"`
+	Provider       string        `json:"provider"`
+	Reference      string        `json:"reference,omitempty"`
+	Status         PaymentStatus `json:"status"`
+	Amount         Money         `json:"amount"`
+	Currency       string        `json:"currency"`
+	CapturedAmount Money         `json:"captured_amount"`
+	RefundedAmount Money         `json:"refunded_amount"`
+	Error          string        `json:"error,omitempty"`
+	CreatedAt      string        `json:"created_at"`
+	UpdatedAt      string        `json:"updated_at"`
+}
@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+type PriceChangeStatus string
+
+const (
+	PriceChangeStatusPending   PriceChangeStatus = "pending"
+	PriceChangeStatusApplied   PriceChangeStatus = "applied"
+	PriceChangeStatusCancelled PriceChangeStatus = "cancelled"
+	PriceChangeStatusFailed    PriceChangeStatus = "failed"
+)
+
+// PriceChangeCreateRequest schedules a new product price. When RevertAt is
+// set the price in effect before the change is restored at that time.
+type PriceChangeCreateRequest struct {
+	ProductCode  string     `json:"product_code" validate:"required,uuid"`
+	CustomerCost Money      `json:"customer_cost" validate:"required,gt=0"`
+	Currency     string     `json:"currency,omitempty" validate:"omitempty,iso4217"`
+	RunAt        time.Time  `json:"run_at" validate:"required"`
+	RevertAt     *time.Time `json:"revert_at,omitempty"`
+	Reason       string     `json:"reason,omitempty" validate:"max=500"`
+}
+
+type PriceChangeFilter struct {
+	Limit       int               `json:"limit" form:"limit" validate:"min=1,max=100"`
+	Offset      int               `json:"offset" form:"offset" validate:"min=0"`
+	Status      PriceChangeStatus `json:"status,omitempty" form:"status" validate:"omitempty,oneof=pending applied cancelled failed"`
+	ProductCode string            `json:"product_code,omitempty" form:"product_code" validate:"omitempty,uuid"`
+}
+
+// PriceChangeResponse is a scheduled price change. Reverts have no price of
+// their own and point to the change they revert.
+type PriceChangeResponse struct {
+	ID           string            `json:"id"`
+	ProductID    string            `json:"product_id"`
+	CustomerCost *Money            `json:"customer_cost,omitempty"`
+	Currency     string            `json:"currency,omitempty"`
+	RevertOf     string            `json:"revert_of,omitempty"`
+	RunAt        string            `json:"run_at"`
+	Status       PriceChangeStatus `json:"status"`
+	Actor        string            `json:"actor,omitempty"`
+	Reason       string            `json:"reason,omitempty"`
+	Error        string            `json:"error,omitempty"`
+	CreatedAt    string            `json:"created_at"`
+	AppliedAt    *string           `json:"applied_at,omitempty"`
+}
@@ -11,36 +11,79 @@ type Product struct {
 	ID           uuid.UUID
 	Name         string
 	ProductCode  string
-	CustomerCost float64
+	CustomerCost Money
+	Currency     string
+	TaxClass     string
 	CreatedAt    time.Time
 	UpdatedAt    time.Time
 }
 
 type ProductWriteOffRequest struct {
-	Uuid   string
-	Amount float64
+	Uuid   string  `json:"uuid"`
+	Amount float64 `json:"amount"`
+}
+
+// ProductFilter represents pagination input for product listing
+type ProductFilter struct {
+	Limit  int `json:"limit" form:"limit" validate:"min=1,max=100"`
+	Offset int `json:"offset" form:"offset" validate:"min=0"`
 }
 
 // ProductCreateRequest represents input for product creation
 type ProductCreateRequest struct {
-	Name         string  `json:"name" validate:"required,min=2,max=80"`
-	ProductCode  string  `json:"product_code" validate:"required,uuid"`
-	CustomerCost float64 `json:"customer_cost" validate:"required,gt=0"`
+	Name         string `json:"name" validate:"required,min=2,max=80"`
+	ProductCode  string `json:"product_code" validate:"required,uuid"`
+	CustomerCost Money  `json:"customer_cost" validate:"required,gt=0"`
+	Currency     string `json:"currency,omitempty" validate:"omitempty,iso4217"`
+	TaxClass     string `json:"tax_class,omitempty" validate:"max=32"`
+	// WeightGrams is the shipping weight of one unit
+	WeightGrams int `json:"weight_grams,omitempty" validate:"min=0,max=1000000"`
 }
 
 // ProductUpdateRequest represents input for product updates
 type ProductUpdateRequest struct {
-	Name         *string  `json:"name,omitempty" validate:"omitempty,min=2,max=80"`
-	ProductCode  *string  `json:"product_code,omitempty" validate:"omitempty,uuid"`
-	CustomerCost *float64 `json:"customer_cost,omitempty" validate:"omitempty,gt=0"`
+	Name         *string `json:"name,omitempty" validate:"omitempty,min=2,max=80"`
+	ProductCode  *string `json:"product_code,omitempty" validate:"omitempty,uuid"`
+	CustomerCost *Money  `json:"customer_cost,omitempty" validate:"omitempty,gt=0"`
+	Currency     *string `json:"currency,omitempty" validate:"omitempty,iso4217"`
+	TaxClass     *string `json:"tax_class,omitempty" validate:"omitempty,min=1,max=32"`
+	WeightGrams  *int    `json:"weight_grams,omitempty" validate:"omitempty,min=0,max=1000000"`
+	// Reason is recorded in the price history when the price changes
+	Reason string `json:"reason,omitempty" validate:"max=500"`
 }
 
 // ProductResponse represents output for product data
 type ProductResponse struct {
-	ID           string  `json:"id"`
-	Name         string  `json:"name"`
-	ProductCode  string  `json:"product_code"`
-	CustomerCost float64 `json:"customer_cost"`
-	CreatedAt    string  `json:"created_at"`
-	UpdatedAt    string  `json:"updated_at"`
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	ProductCode  string `json:"product_code"`
+	CustomerCost Money  `json:"customer_cost"`
+	Currency     string `json:"currency"`
+	TaxClass     string `json:"tax_class"`
+	WeightGrams  int    `json:"weight_grams"`
+	CreatedAt    string `json:"created_at"`
+	UpdatedAt    string `json:"updated_at"`
+}
+
+type ProductPriceFilter struct {
+	Limit  int `json:"limit" form:"limit" validate:"min=1,max=100"`
+	Offset int `json:"offset" form:"offset" validate:"min=0"`
+}
+
+// ProductPriceQuery asks for the price in effect at a moment
+type ProductPriceQuery struct {
+	At time.Time `form:"at" time_format:"2006-01-02T15:04:05Z07:00" validate:"required"`
+}
+
+// ProductPriceResponse is one entry of the product price history. EffectiveTo
+// is empty for the price in effect now.
+type ProductPriceResponse struct {
+	ID            string  `json:"id"`
+	ProductID     string  `json:"product_id"`
+	CustomerCost  Money   `json:"customer_cost"`
+	Currency      string  `json:"currency"`
+	EffectiveFrom string  `json:"effective_from"`
+	EffectiveTo   *string `json:"effective_to,omitempty"`
+	ChangedBy     string  `json:"changed_by,omitempty"`
+	Reason        string  `json:"reason,omitempty"`
 }
@@ -0,0 +1,74 @@
+package models
+
+import "time"
+
+type PromotionKind string
+
+const (
+	// PromotionKindPercentage takes a percent off the price
+	PromotionKindPercentage PromotionKind = "percentage"
+	// PromotionKindFixed takes a fixed amount off the price
+	PromotionKindFixed PromotionKind = "fixed"
+	// PromotionKindBuyXGetY gives free_quantity units for every buy_quantity units bought
+	PromotionKindBuyXGetY PromotionKind = "buy_x_get_y"
+)
+
+type PromotionScope string
+
+const (
+	// PromotionScopeOrder discounts the order subtotal
+	PromotionScopeOrder PromotionScope = "order"
+	// PromotionScopeProduct discounts every unit of one product
+	PromotionScopeProduct PromotionScope = "product"
+)
+
+type PromotionFilter struct {
+	Limit  int `json:"limit" form:"limit" validate:"min=1,max=100"`
+	Offset int `json:"offset" form:"offset" validate:"min=0"`
+}
+
+// PromotionCreateRequest describes a promotion. Promotions without a code are
+// applied automatically, coupons only when their code is sent with the order.
+type PromotionCreateRequest struct {
+	Code         string         `json:"code,omitempty" validate:"omitempty,min=3,max=64"`
+	Name         string         `json:"name" validate:"required,max=120"`
+	Kind         PromotionKind  `json:"kind" validate:"required,oneof=percentage fixed buy_x_get_y"`
+	Scope        PromotionScope `json:"scope" validate:"required,oneof=order product"`
+	ProductCode  string         `json:"product_code,omitempty" validate:"required_if=Scope product,omitempty,uuid"`
+	Percent      *Money         `json:"percent,omitempty" validate:"required_if=Kind percentage,omitempty,gt=0,max=10000"`
+	Amount       *Money         `json:"amount,omitempty" validate:"required_if=Kind fixed,omitempty,gt=0"`
+	Currency     string         `json:"currency,omitempty" validate:"omitempty,iso4217"`
+	BuyQuantity  int            `json:"buy_quantity,omitempty" validate:"required_if=Kind buy_x_get_y,omitempty,min=1,max=100"`
+	FreeQuantity int            `json:"free_quantity,omitempty" validate:"required_if=Kind buy_x_get_y,omitempty,min=1,max=100"`
+	UsageLimit   *int           `json:"usage_limit,omitempty" validate:"omitempty,min=1"`
+	ValidFrom    *time.Time     `json:"valid_from,omitempty"`
+	ValidTo      *time.Time     `json:"valid_to,omitempty"`
+}
+
+type PromotionUpdateRequest struct {
+	Name       *string    `json:"name,omitempty" validate:"omitempty,max=120"`
+	Active     *bool      `json:"active,omitempty"`
+	UsageLimit *int       `json:"usage_limit,omitempty" validate:"omitempty,min=1"`
+	ValidFrom  *time.Time `json:"valid_from,omitempty"`
+	ValidTo    *time.Time `json:"valid_to,omitempty"`
+}
+
+type PromotionResponse struct {
+	ID           string         `json:"id"`
+	Code         string         `json:"code,omitempty"`
+	Name         string         `json:"name"`
+	Kind         PromotionKind  `json:"kind"`
+	Scope        PromotionScope `json:"scope"`
+	ProductCode  string         `json:"product_code,omitempty"`
+	Percent      *Money         `json:"percent,omitempty"`
+	Amount       *Money         `json:"amount,omitempty"`
+	Currency     string         `json:"currency,omitempty"`
+	BuyQuantity  int            `json:"buy_quantity,omitempty"`
+	FreeQuantity int            `json:"free_quantity,omitempty"`
+	UsageLimit   *int           `json:"usage_limit,omitempty"`
+	UsageCount   int            `json:"usage_count"`
+	ValidFrom    *string        `json:"valid_from,omitempty"`
+	ValidTo      *string        `json:"valid_to,omitempty"`
+	Active       bool           `json:"active"`
+	CreatedAt    string         `json:"created_at"`
+}
@@ -0,0 +1,60 @@
+package models
+
+import "github.com/google/uuid"
+
+type ReturnStatus string
+
+const (
+	ReturnStatusRequested ReturnStatus = "requested"
+	ReturnStatusApproved  ReturnStatus = "approved"
+	ReturnStatusRejected  ReturnStatus = "rejected"
+	ReturnStatusReceived  ReturnStatus = "received"
+	ReturnStatusRefunded  ReturnStatus = "refunded"
+)
+
+// ReturnCreateRequest asks to return some units of the lines of a completed order
+type ReturnCreateRequest struct {
+	Lines  []ReturnLineInput `json:"lines" validate:"required,min=1,dive"`
+	Reason string            `json:"reason,omitempty" validate:"max=500"`
+}
+
+type ReturnLineInput struct {
+	ProductID uuid.UUID `json:"product_id" validate:"required,uuid"`
+	Amount    int       `json:"amount" validate:"required,min=1,max=100"`
+}
+
+type ReturnStatusChangeRequest struct {
+	Status ReturnStatus `json:"status" validate:"required,oneof=approved rejected received refunded"`
+}
+
+type ReturnFilter struct {
+	Limit   int          `json:"limit" form:"limit" validate:"min=1,max=100"`
+	Offset  int          `json:"offset" form:"offset" validate:"min=0"`
+	Status  ReturnStatus `json:"status,omitempty" form:"status" validate:"omitempty,oneof=requested approved rejected received refunded"`
+	OrderID string       `json:"order_id,omitempty" form:"order_id" validate:"omitempty,uuid"`
+}
+
+// ReturnResponse is a return with the amount refunded to the customer.
+// StockReturned is set once the products were written back to SMS.
+type ReturnResponse struct {
+	ID            string               `json:"id"`
+	OrderID       string               `json:"order_id"`
+	Status        ReturnStatus         `json:"status"`
+	RefundAmount  Money                `json:"refund_amount"`
+	Currency      string               `json:"currency"`
+	StockReturned bool                 `json:"stock_returned"`
+	Actor         string               `json:"actor,omitempty"`
+	Reason        string               `json:"reason,omitempty"`
+	CreatedAt     string               `json:"created_at"`
+	UpdatedAt     string               `json:"updated_at"`
+	RefundedAt    *string              `json:"refunded_at,omitempty"`
+	Lines         []ReturnLineResponse `json:"lines"`
+}
+
+type ReturnLineResponse struct {
+	ProductID    string `json:"product_id"`
+	ProductCode  string `json:"product_code"`
+	Name         string `json:"name"`
+	Amount       int    `json:"amount"`
+	RefundAmount Money  `json:"refund_amount"`
+}
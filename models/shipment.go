@@ -0,0 +1,52 @@
+package models
+
+import "github.com/google/uuid"
+
+type ShipmentStatus string
+
+const (
+	ShipmentStatusPending   ShipmentStatus = "pending"
+	ShipmentStatusShipped   ShipmentStatus = "shipped"
+	ShipmentStatusDelivered ShipmentStatus = "delivered"
+	ShipmentStatusCancelled ShipmentStatus = "cancelled"
+)
+
+// ShipmentCreateRequest is a parcel with some units of the order products
+type ShipmentCreateRequest struct {
+	Carrier        string              `json:"carrier,omitempty" validate:"max=64"`
+	TrackingNumber string              `json:"tracking_number,omitempty" validate:"max=128"`
+	Lines          []ShipmentLineInput `json:"lines" validate:"required,min=1,dive"`
+}
+
+type ShipmentLineInput struct {
+	ProductID uuid.UUID `json:"product_id" validate:"required,uuid"`
+	Amount    int       `json:"amount" validate:"required,min=1,max=100"`
+}
+
+// ShipmentStatusChangeRequest moves a shipment forward. The carrier and
+// tracking number are usually known once the parcel is shipped.
+type ShipmentStatusChangeRequest struct {
+	Status         ShipmentStatus `json:"status" validate:"required,oneof=shipped delivered cancelled"`
+	Carrier        *string        `json:"carrier,omitempty" validate:"omitempty,max=64"`
+	TrackingNumber *string        `json:"tracking_number,omitempty" validate:"omitempty,max=128"`
+	Reason         string         `json:"reason" validate:"max=500"`
+}
+
+type ShipmentResponse struct {
+	ID             string                 `json:"id"`
+	OrderID        string                 `json:"order_id"`
+	Carrier        string                 `json:"carrier,omitempty"`
+	TrackingNumber string                 `json:"tracking_number,omitempty"`
+	Status         ShipmentStatus         `json:"status"`
+	CreatedAt      string                 `json:"created_at"`
+	ShippedAt      *string                `json:"shipped_at,omitempty"`
+	DeliveredAt    *string                `json:"delivered_at,omitempty"`
+	Lines          []ShipmentLineResponse `json:"lines"`
+}
+
+type ShipmentLineResponse struct {
+	ProductID   string `json:"product_id"`
+	ProductCode string `json:"product_code"`
+	Name        string `json:"name"`
+	Amount      int    `json:"amount"`
+}
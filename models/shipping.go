@@ -0,0 +1,56 @@
+package models
+
+// ShippingRule is how the cost of a shipping method is calculated
+type ShippingRule string
+
+const (
+	// ShippingRuleFlat costs the same for every order
+	ShippingRuleFlat ShippingRule = "flat"
+	// ShippingRuleWeight adds the cost per kilogram of the order products to
+	// the base cost
+	ShippingRuleWeight ShippingRule = "weight"
+	// ShippingRuleFreeOver is free for orders of at least the threshold and
+	// costs the base cost below it
+	ShippingRuleFreeOver ShippingRule = "free_over"
+)
+
+type ShippingMethodFilter struct {
+	Limit  int `json:"limit" form:"limit" validate:"min=1,max=100"`
+	Offset int `json:"offset" form:"offset" validate:"min=0"`
+	// Active lists only the methods orders can be placed with
+	Active bool `json:"active" form:"active"`
+}
+
+// ShippingMethodCreateRequest adds a shipping method orders can select by
+// code. Amounts are in Currency, the default currency when it is empty.
+type ShippingMethodCreateRequest struct {
+	Code          string       `json:"code" validate:"required,max=32"`
+	Name          string       `json:"name" validate:"required,max=80"`
+	Rule          ShippingRule `json:"rule" validate:"required,oneof=flat weight free_over"`
+	Currency      string       `json:"currency,omitempty" validate:"omitempty,iso4217"`
+	Cost          *Money       `json:"cost" validate:"required,gte=0"`
+	CostPerKg     *Money       `json:"cost_per_kg,omitempty" validate:"required_if=Rule weight,omitempty,gte=0"`
+	FreeThreshold *Money       `json:"free_threshold,omitempty" validate:"required_if=Rule free_over,omitempty,gt=0"`
+	Active        *bool        `json:"active,omitempty"`
+}
+
+type ShippingMethodUpdateRequest struct {
+	Name          *string `json:"name,omitempty" validate:"omitempty,min=1,max=80"`
+	Cost          *Money  `json:"cost,omitempty" validate:"omitempty,gte=0"`
+	CostPerKg     *Money  `json:"cost_per_kg,omitempty" validate:"omitempty,gte=0"`
+	FreeThreshold *Money  `json:"free_threshold,omitempty" validate:"omitempty,gt=0"`
+	Active        *bool   `json:"active,omitempty"`
+}
+
+type ShippingMethodResponse struct {
+	ID            string       `json:"id"`
+	Code          string       `json:"code"`
+	Name          string       `json:"name"`
+	Rule          ShippingRule `json:"rule"`
+	Currency      string       `json:"currency"`
+	Cost          Money        `json:"cost"`
+	CostPerKg     *Money       `json:"cost_per_kg,omitempty"`
+	FreeThreshold *Money       `json:"free_threshold,omitempty"`
+	Active        bool         `json:"active"`
+	CreatedAt     string       `json:"created_at"`
+}
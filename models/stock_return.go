@@ -0,0 +1,27 @@
+package models
+
+type StockReturnStatus string
+
+const (
+	StockReturnStatusPending    StockReturnStatus = "pending"
+	StockReturnStatusInProgress StockReturnStatus = "in_progress"
+	StockReturnStatusCompleted  StockReturnStatus = "completed"
+	StockReturnStatusFailed     StockReturnStatus = "failed"
+)
+
+// StockReturnFilter represents input for listing stock returns of cancelled orders
+type StockReturnFilter struct {
+	Limit  int               `json:"limit" form:"limit" validate:"min=1,max=100"`
+	Offset int               `json:"offset" form:"offset" validate:"min=0"`
+	Status StockReturnStatus `json:"status" form:"status" validate:"required,oneof=pending in_progress completed failed"`
+}
+
+// StockReturnResponse represents the state of returning cancelled order products to SMS
+type StockReturnResponse struct {
+	OrderID   string            `json:"order_id"`
+	Status    StockReturnStatus `json:"status"`
+	Attempts  int               `json:"attempts"`
+	LastError string            `json:"last_error,omitempty"`
+	CreatedAt string            `json:"created_at"`
+	UpdatedAt string            `json:"updated_at"`
+}
@@ -0,0 +1,33 @@
+package models
+
+// DefaultTaxClass is the tax class of products created without one
+const DefaultTaxClass = "standard"
+
+type TaxRuleFilter struct {
+	Limit  int `json:"limit" form:"limit" validate:"min=1,max=100"`
+	Offset int `json:"offset" form:"offset" validate:"min=0"`
+}
+
+// TaxRuleCreateRequest sets the tax rate of a tax class in a region. A rule
+// with an empty region applies to the regions without their own rule.
+// Inclusive rates are already part of the product prices.
+type TaxRuleCreateRequest struct {
+	TaxClass  string `json:"tax_class" validate:"required,max=32"`
+	Region    string `json:"region" validate:"max=16"`
+	Rate      *Money `json:"rate" validate:"required,gte=0,max=10000"`
+	Inclusive bool   `json:"inclusive"`
+}
+
+type TaxRuleUpdateRequest struct {
+	Rate      *Money `json:"rate,omitempty" validate:"omitempty,gte=0,max=10000"`
+	Inclusive *bool  `json:"inclusive,omitempty"`
+}
+
+type TaxRuleResponse struct {
+	ID        string `json:"id"`
+	TaxClass  string `json:"tax_class"`
+	Region    string `json:"region"`
+	Rate      Money  `json:"rate"`
+	Inclusive bool   `json:"inclusive"`
+	CreatedAt string `json:"created_at"`
+}
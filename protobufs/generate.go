@@ -0,0 +1,3 @@
+package protobufs
+
+//go:generate protoc --go_out=./oms_ext_pb/ --go_opt=paths=source_relative --go-grpc_out=./oms_ext_pb/ --go-grpc_opt=paths=source_relative oms_ext.proto
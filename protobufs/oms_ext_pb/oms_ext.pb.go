@@ -0,0 +1,1528 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.31.0
+// source: oms_ext.proto
+
+package oms_ext_pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type OrderStatusHistoryEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	OrderUuid     string                 `protobuf:"bytes,2,opt,name=order_uuid,json=orderUuid,proto3" json:"order_uuid,omitempty"`
+	FromStatus    string                 `protobuf:"bytes,3,opt,name=from_status,json=fromStatus,proto3" json:"from_status,omitempty"`
+	ToStatus      string                 `protobuf:"bytes,4,opt,name=to_status,json=toStatus,proto3" json:"to_status,omitempty"`
+	Actor         string                 `protobuf:"bytes,5,opt,name=actor,proto3" json:"actor,omitempty"`
+	Reason        string                 `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`
+	ChangedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=changed_at,json=changedAt,proto3" json:"changed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderStatusHistoryEntry) Reset() {
+	*x = OrderStatusHistoryEntry{}
+	mi := &file_oms_ext_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderStatusHistoryEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderStatusHistoryEntry) ProtoMessage() {}
+
+func (x *OrderStatusHistoryEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_oms_ext_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderStatusHistoryEntry.ProtoReflect.Descriptor instead.
+func (*OrderStatusHistoryEntry) Descriptor() ([]byte, []int) {
+	return file_oms_ext_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *OrderStatusHistoryEntry) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *OrderStatusHistoryEntry) GetOrderUuid() string {
+	if x != nil {
+		return x.OrderUuid
+	}
+	return ""
+}
+
+func (x *OrderStatusHistoryEntry) GetFromStatus() string {
+	if x != nil {
+		return x.FromStatus
+	}
+	return ""
+}
+
+func (x *OrderStatusHistoryEntry) GetToStatus() string {
+	if x != nil {
+		return x.ToStatus
+	}
+	return ""
+}
+
+func (x *OrderStatusHistoryEntry) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *OrderStatusHistoryEntry) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *OrderStatusHistoryEntry) GetChangedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ChangedAt
+	}
+	return nil
+}
+
+type ChangeOrderStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderUuid     string                 `protobuf:"bytes,1,opt,name=order_uuid,json=orderUuid,proto3" json:"order_uuid,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Actor         string                 `protobuf:"bytes,3,opt,name=actor,proto3" json:"actor,omitempty"`
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangeOrderStatusRequest) Reset() {
+	*x = ChangeOrderStatusRequest{}
+	mi := &file_oms_ext_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangeOrderStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeOrderStatusRequest) ProtoMessage() {}
+
+func (x *ChangeOrderStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_oms_ext_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeOrderStatusRequest.ProtoReflect.Descriptor instead.
+func (*ChangeOrderStatusRequest) Descriptor() ([]byte, []int) {
+	return file_oms_ext_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ChangeOrderStatusRequest) GetOrderUuid() string {
+	if x != nil {
+		return x.OrderUuid
+	}
+	return ""
+}
+
+func (x *ChangeOrderStatusRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ChangeOrderStatusRequest) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *ChangeOrderStatusRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ChangeOrderStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderUuid     string                 `protobuf:"bytes,1,opt,name=order_uuid,json=orderUuid,proto3" json:"order_uuid,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	FinishDate    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=finish_date,json=finishDate,proto3" json:"finish_date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangeOrderStatusResponse) Reset() {
+	*x = ChangeOrderStatusResponse{}
+	mi := &file_oms_ext_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangeOrderStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeOrderStatusResponse) ProtoMessage() {}
+
+func (x *ChangeOrderStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_oms_ext_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeOrderStatusResponse.ProtoReflect.Descriptor instead.
+func (*ChangeOrderStatusResponse) Descriptor() ([]byte, []int) {
+	return file_oms_ext_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ChangeOrderStatusResponse) GetOrderUuid() string {
+	if x != nil {
+		return x.OrderUuid
+	}
+	return ""
+}
+
+func (x *ChangeOrderStatusResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ChangeOrderStatusResponse) GetFinishDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FinishDate
+	}
+	return nil
+}
+
+type GetOrderStatusHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderUuid     string                 `protobuf:"bytes,1,opt,name=order_uuid,json=orderUuid,proto3" json:"order_uuid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderStatusHistoryRequest) Reset() {
+	*x = GetOrderStatusHistoryRequest{}
+	mi := &file_oms_ext_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderStatusHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderStatusHistoryRequest) ProtoMessage() {}
+
+func (x *GetOrderStatusHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_oms_ext_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderStatusHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetOrderStatusHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_oms_ext_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetOrderStatusHistoryRequest) GetOrderUuid() string {
+	if x != nil {
+		return x.OrderUuid
+	}
+	return ""
+}
+
+type GetOrderStatusHistoryResponse struct {
+	state         protoimpl.MessageState     `protogen:"open.v1"`
+	Entries       []*OrderStatusHistoryEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderStatusHistoryResponse) Reset() {
+	*x = GetOrderStatusHistoryResponse{}
+	mi := &file_oms_ext_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderStatusHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderStatusHistoryResponse) ProtoMessage() {}
+
+func (x *GetOrderStatusHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_oms_ext_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderStatusHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetOrderStatusHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_oms_ext_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetOrderStatusHistoryResponse) GetEntries() []*OrderStatusHistoryEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type Promotion struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Kind          string                 `protobuf:"bytes,4,opt,name=kind,proto3" json:"kind,omitempty"`
+	Scope         string                 `protobuf:"bytes,5,opt,name=scope,proto3" json:"scope,omitempty"`
+	ProductCode   string                 `protobuf:"bytes,6,opt,name=product_code,json=productCode,proto3" json:"product_code,omitempty"`
+	Percent       string                 `protobuf:"bytes,7,opt,name=percent,proto3" json:"percent,omitempty"`
+	Amount        string                 `protobuf:"bytes,8,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency      string                 `protobuf:"bytes,9,opt,name=currency,proto3" json:"currency,omitempty"`
+	BuyQuantity   int32                  `protobuf:"varint,10,opt,name=buy_quantity,json=buyQuantity,proto3" json:"buy_quantity,omitempty"`
+	FreeQuantity  int32                  `protobuf:"varint,11,opt,name=free_quantity,json=freeQuantity,proto3" json:"free_quantity,omitempty"`
+	UsageLimit    *int32                 `protobuf:"varint,12,opt,name=usage_limit,json=usageLimit,proto3,oneof" json:"usage_limit,omitempty"`
+	UsageCount    int32                  `protobuf:"varint,13,opt,name=usage_count,json=usageCount,proto3" json:"usage_count,omitempty"`
+	ValidFrom     *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=valid_from,json=validFrom,proto3" json:"valid_from,omitempty"`
+	ValidTo       *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=valid_to,json=validTo,proto3" json:"valid_to,omitempty"`
+	Active        bool                   `protobuf:"varint,16,opt,name=active,proto3" json:"active,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Promotion) Reset() {
+	*x = Promotion{}
+	mi := &file_oms_ext_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Promotion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Promotion) ProtoMessage() {}
+
+func (x *Promotion) ProtoReflect() protoreflect.Message {
+	mi := &file_oms_ext_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Promotion.ProtoReflect.Descriptor instead.
+func (*Promotion) Descriptor() ([]byte, []int) {
+	return file_oms_ext_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Promotion) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *Promotion) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Promotion) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Promotion) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Promotion) GetScope() string {
+	if x != nil {
+		return x.Scope
+	}
+	return ""
+}
+
+func (x *Promotion) GetProductCode() string {
+	if x != nil {
+		return x.ProductCode
+	}
+	return ""
+}
+
+func (x *Promotion) GetPercent() string {
+	if x != nil {
+		return x.Percent
+	}
+	return ""
+}
+
+func (x *Promotion) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *Promotion) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Promotion) GetBuyQuantity() int32 {
+	if x != nil {
+		return x.BuyQuantity
+	}
+	return 0
+}
+
+func (x *Promotion) GetFreeQuantity() int32 {
+	if x != nil {
+		return x.FreeQuantity
+	}
+	return 0
+}
+
+func (x *Promotion) GetUsageLimit() int32 {
+	if x != nil && x.UsageLimit != nil {
+		return *x.UsageLimit
+	}
+	return 0
+}
+
+func (x *Promotion) GetUsageCount() int32 {
+	if x != nil {
+		return x.UsageCount
+	}
+	return 0
+}
+
+func (x *Promotion) GetValidFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ValidFrom
+	}
+	return nil
+}
+
+func (x *Promotion) GetValidTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ValidTo
+	}
+	return nil
+}
+
+func (x *Promotion) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+func (x *Promotion) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type CreatePromotionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Kind          string                 `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`
+	Scope         string                 `protobuf:"bytes,4,opt,name=scope,proto3" json:"scope,omitempty"`
+	ProductCode   string                 `protobuf:"bytes,5,opt,name=product_code,json=productCode,proto3" json:"product_code,omitempty"`
+	Percent       string                 `protobuf:"bytes,6,opt,name=percent,proto3" json:"percent,omitempty"`
+	Amount        string                 `protobuf:"bytes,7,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency      string                 `protobuf:"bytes,8,opt,name=currency,proto3" json:"currency,omitempty"`
+	BuyQuantity   int32                  `protobuf:"varint,9,opt,name=buy_quantity,json=buyQuantity,proto3" json:"buy_quantity,omitempty"`
+	FreeQuantity  int32                  `protobuf:"varint,10,opt,name=free_quantity,json=freeQuantity,proto3" json:"free_quantity,omitempty"`
+	UsageLimit    *int32                 `protobuf:"varint,11,opt,name=usage_limit,json=usageLimit,proto3,oneof" json:"usage_limit,omitempty"`
+	ValidFrom     *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=valid_from,json=validFrom,proto3" json:"valid_from,omitempty"`
+	ValidTo       *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=valid_to,json=validTo,proto3" json:"valid_to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePromotionRequest) Reset() {
+	*x = CreatePromotionRequest{}
+	mi := &file_oms_ext_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePromotionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePromotionRequest) ProtoMessage() {}
+
+func (x *CreatePromotionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_oms_ext_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePromotionRequest.ProtoReflect.Descriptor instead.
+func (*CreatePromotionRequest) Descriptor() ([]byte, []int) {
+	return file_oms_ext_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CreatePromotionRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *CreatePromotionRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreatePromotionRequest) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *CreatePromotionRequest) GetScope() string {
+	if x != nil {
+		return x.Scope
+	}
+	return ""
+}
+
+func (x *CreatePromotionRequest) GetProductCode() string {
+	if x != nil {
+		return x.ProductCode
+	}
+	return ""
+}
+
+func (x *CreatePromotionRequest) GetPercent() string {
+	if x != nil {
+		return x.Percent
+	}
+	return ""
+}
+
+func (x *CreatePromotionRequest) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *CreatePromotionRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *CreatePromotionRequest) GetBuyQuantity() int32 {
+	if x != nil {
+		return x.BuyQuantity
+	}
+	return 0
+}
+
+func (x *CreatePromotionRequest) GetFreeQuantity() int32 {
+	if x != nil {
+		return x.FreeQuantity
+	}
+	return 0
+}
+
+func (x *CreatePromotionRequest) GetUsageLimit() int32 {
+	if x != nil && x.UsageLimit != nil {
+		return *x.UsageLimit
+	}
+	return 0
+}
+
+func (x *CreatePromotionRequest) GetValidFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ValidFrom
+	}
+	return nil
+}
+
+func (x *CreatePromotionRequest) GetValidTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ValidTo
+	}
+	return nil
+}
+
+type GetPromotionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPromotionRequest) Reset() {
+	*x = GetPromotionRequest{}
+	mi := &file_oms_ext_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPromotionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPromotionRequest) ProtoMessage() {}
+
+func (x *GetPromotionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_oms_ext_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPromotionRequest.ProtoReflect.Descriptor instead.
+func (*GetPromotionRequest) Descriptor() ([]byte, []int) {
+	return file_oms_ext_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetPromotionRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+type ListPromotionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPromotionsRequest) Reset() {
+	*x = ListPromotionsRequest{}
+	mi := &file_oms_ext_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPromotionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPromotionsRequest) ProtoMessage() {}
+
+func (x *ListPromotionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_oms_ext_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPromotionsRequest.ProtoReflect.Descriptor instead.
+func (*ListPromotionsRequest) Descriptor() ([]byte, []int) {
+	return file_oms_ext_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListPromotionsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListPromotionsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type ListPromotionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Promotions    []*Promotion           `protobuf:"bytes,1,rep,name=promotions,proto3" json:"promotions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPromotionsResponse) Reset() {
+	*x = ListPromotionsResponse{}
+	mi := &file_oms_ext_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPromotionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPromotionsResponse) ProtoMessage() {}
+
+func (x *ListPromotionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_oms_ext_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPromotionsResponse.ProtoReflect.Descriptor instead.
+func (*ListPromotionsResponse) Descriptor() ([]byte, []int) {
+	return file_oms_ext_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListPromotionsResponse) GetPromotions() []*Promotion {
+	if x != nil {
+		return x.Promotions
+	}
+	return nil
+}
+
+type UpdatePromotionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	Name          *string                `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	Active        *bool                  `protobuf:"varint,3,opt,name=active,proto3,oneof" json:"active,omitempty"`
+	UsageLimit    *int32                 `protobuf:"varint,4,opt,name=usage_limit,json=usageLimit,proto3,oneof" json:"usage_limit,omitempty"`
+	ValidFrom     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=valid_from,json=validFrom,proto3" json:"valid_from,omitempty"`
+	ValidTo       *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=valid_to,json=validTo,proto3" json:"valid_to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdatePromotionRequest) Reset() {
+	*x = UpdatePromotionRequest{}
+	mi := &file_oms_ext_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePromotionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePromotionRequest) ProtoMessage() {}
+
+func (x *UpdatePromotionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_oms_ext_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePromotionRequest.ProtoReflect.Descriptor instead.
+func (*UpdatePromotionRequest) Descriptor() ([]byte, []int) {
+	return file_oms_ext_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *UpdatePromotionRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *UpdatePromotionRequest) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *UpdatePromotionRequest) GetActive() bool {
+	if x != nil && x.Active != nil {
+		return *x.Active
+	}
+	return false
+}
+
+func (x *UpdatePromotionRequest) GetUsageLimit() int32 {
+	if x != nil && x.UsageLimit != nil {
+		return *x.UsageLimit
+	}
+	return 0
+}
+
+func (x *UpdatePromotionRequest) GetValidFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ValidFrom
+	}
+	return nil
+}
+
+func (x *UpdatePromotionRequest) GetValidTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ValidTo
+	}
+	return nil
+}
+
+type DeletePromotionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletePromotionRequest) Reset() {
+	*x = DeletePromotionRequest{}
+	mi := &file_oms_ext_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePromotionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePromotionRequest) ProtoMessage() {}
+
+func (x *DeletePromotionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_oms_ext_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePromotionRequest.ProtoReflect.Descriptor instead.
+func (*DeletePromotionRequest) Descriptor() ([]byte, []int) {
+	return file_oms_ext_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *DeletePromotionRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+type DeletePromotionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletePromotionResponse) Reset() {
+	*x = DeletePromotionResponse{}
+	mi := &file_oms_ext_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePromotionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePromotionResponse) ProtoMessage() {}
+
+func (x *DeletePromotionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_oms_ext_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePromotionResponse.ProtoReflect.Descriptor instead.
+func (*DeletePromotionResponse) Descriptor() ([]byte, []int) {
+	return file_oms_ext_proto_rawDescGZIP(), []int{12}
+}
+
+type GetInvoiceRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	OrderUuid string                 `protobuf:"bytes,1,opt,name=order_uuid,json=orderUuid,proto3" json:"order_uuid,omitempty"`
+	// html or pdf, pdf when empty
+	Format        string `protobuf:"bytes,2,opt,name=format,proto3" json:"format,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetInvoiceRequest) Reset() {
+	*x = GetInvoiceRequest{}
+	mi := &file_oms_ext_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInvoiceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInvoiceRequest) ProtoMessage() {}
+
+func (x *GetInvoiceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_oms_ext_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInvoiceRequest.ProtoReflect.Descriptor instead.
+func (*GetInvoiceRequest) Descriptor() ([]byte, []int) {
+	return file_oms_ext_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetInvoiceRequest) GetOrderUuid() string {
+	if x != nil {
+		return x.OrderUuid
+	}
+	return ""
+}
+
+func (x *GetInvoiceRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+type Invoice struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	Number        string                 `protobuf:"bytes,2,opt,name=number,proto3" json:"number,omitempty"`
+	OrderUuid     string                 `protobuf:"bytes,3,opt,name=order_uuid,json=orderUuid,proto3" json:"order_uuid,omitempty"`
+	IssuedAt      *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=issued_at,json=issuedAt,proto3" json:"issued_at,omitempty"`
+	ContentType   string                 `protobuf:"bytes,5,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Content       []byte                 `protobuf:"bytes,6,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Invoice) Reset() {
+	*x = Invoice{}
+	mi := &file_oms_ext_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Invoice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Invoice) ProtoMessage() {}
+
+func (x *Invoice) ProtoReflect() protoreflect.Message {
+	mi := &file_oms_ext_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Invoice.ProtoReflect.Descriptor instead.
+func (*Invoice) Descriptor() ([]byte, []int) {
+	return file_oms_ext_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Invoice) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *Invoice) GetNumber() string {
+	if x != nil {
+		return x.Number
+	}
+	return ""
+}
+
+func (x *Invoice) GetOrderUuid() string {
+	if x != nil {
+		return x.OrderUuid
+	}
+	return ""
+}
+
+func (x *Invoice) GetIssuedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.IssuedAt
+	}
+	return nil
+}
+
+func (x *Invoice) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *Invoice) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+type WatchOrdersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	StaffId       string                 `protobuf:"bytes,3,opt,name=staff_id,json=staffId,proto3" json:"staff_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchOrdersRequest) Reset() {
+	*x = WatchOrdersRequest{}
+	mi := &file_oms_ext_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchOrdersRequest) ProtoMessage() {}
+
+func (x *WatchOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_oms_ext_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchOrdersRequest.ProtoReflect.Descriptor instead.
+func (*WatchOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_oms_ext_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *WatchOrdersRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *WatchOrdersRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *WatchOrdersRequest) GetStaffId() string {
+	if x != nil {
+		return x.StaffId
+	}
+	return ""
+}
+
+type OrderChange struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// OrderCreated, OrderUpdated or OrderDeleted
+	Type           string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	OrderUuid      string                 `protobuf:"bytes,2,opt,name=order_uuid,json=orderUuid,proto3" json:"order_uuid,omitempty"`
+	UserId         string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	StaffId        string                 `protobuf:"bytes,4,opt,name=staff_id,json=staffId,proto3" json:"staff_id,omitempty"`
+	Status         string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	PreviousStatus string                 `protobuf:"bytes,6,opt,name=previous_status,json=previousStatus,proto3" json:"previous_status,omitempty"`
+	OccurredAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	// the order after the change, not set for deleted orders
+	Comment       string                 `protobuf:"bytes,8,opt,name=comment,proto3" json:"comment,omitempty"`
+	OrderCost     string                 `protobuf:"bytes,9,opt,name=order_cost,json=orderCost,proto3" json:"order_cost,omitempty"`
+	Currency      string                 `protobuf:"bytes,10,opt,name=currency,proto3" json:"currency,omitempty"`
+	CreationDate  *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=creation_date,json=creationDate,proto3" json:"creation_date,omitempty"`
+	FinishDate    *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=finish_date,json=finishDate,proto3" json:"finish_date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderChange) Reset() {
+	*x = OrderChange{}
+	mi := &file_oms_ext_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderChange) ProtoMessage() {}
+
+func (x *OrderChange) ProtoReflect() protoreflect.Message {
+	mi := &file_oms_ext_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderChange.ProtoReflect.Descriptor instead.
+func (*OrderChange) Descriptor() ([]byte, []int) {
+	return file_oms_ext_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *OrderChange) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *OrderChange) GetOrderUuid() string {
+	if x != nil {
+		return x.OrderUuid
+	}
+	return ""
+}
+
+func (x *OrderChange) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *OrderChange) GetStaffId() string {
+	if x != nil {
+		return x.StaffId
+	}
+	return ""
+}
+
+func (x *OrderChange) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *OrderChange) GetPreviousStatus() string {
+	if x != nil {
+		return x.PreviousStatus
+	}
+	return ""
+}
+
+func (x *OrderChange) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+func (x *OrderChange) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+func (x *OrderChange) GetOrderCost() string {
+	if x != nil {
+		return x.OrderCost
+	}
+	return ""
+}
+
+func (x *OrderChange) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *OrderChange) GetCreationDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreationDate
+	}
+	return nil
+}
+
+func (x *OrderChange) GetFinishDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FinishDate
+	}
+	return nil
+}
+
+var File_oms_ext_proto protoreflect.FileDescriptor
+
+const file_oms_ext_proto_rawDesc = "" +
+	"\n" +
+	"\roms_ext.proto\x12\aoms_ext\x1a\x1fgoogle/protobuf/timestamp.proto\"\xf3\x01\n" +
+	"\x17OrderStatusHistoryEntry\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12\x1d\n" +
+	"\n" +
+	"order_uuid\x18\x02 \x01(\tR\torderUuid\x12\x1f\n" +
+	"\vfrom_status\x18\x03 \x01(\tR\n" +
+	"fromStatus\x12\x1b\n" +
+	"\tto_status\x18\x04 \x01(\tR\btoStatus\x12\x14\n" +
+	"\x05actor\x18\x05 \x01(\tR\x05actor\x12\x16\n" +
+	"\x06reason\x18\x06 \x01(\tR\x06reason\x129\n" +
+	"\n" +
+	"changed_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tchangedAt\"\x7f\n" +
+	"\x18ChangeOrderStatusRequest\x12\x1d\n" +
+	"\n" +
+	"order_uuid\x18\x01 \x01(\tR\torderUuid\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x14\n" +
+	"\x05actor\x18\x03 \x01(\tR\x05actor\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\"\x8f\x01\n" +
+	"\x19ChangeOrderStatusResponse\x12\x1d\n" +
+	"\n" +
+	"order_uuid\x18\x01 \x01(\tR\torderUuid\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12;\n" +
+	"\vfinish_date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"finishDate\"=\n" +
+	"\x1cGetOrderStatusHistoryRequest\x12\x1d\n" +
+	"\n" +
+	"order_uuid\x18\x01 \x01(\tR\torderUuid\"[\n" +
+	"\x1dGetOrderStatusHistoryResponse\x12:\n" +
+	"\aentries\x18\x01 \x03(\v2 .oms_ext.OrderStatusHistoryEntryR\aentries\"\xc6\x04\n" +
+	"\tPromotion\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x12\n" +
+	"\x04kind\x18\x04 \x01(\tR\x04kind\x12\x14\n" +
+	"\x05scope\x18\x05 \x01(\tR\x05scope\x12!\n" +
+	"\fproduct_code\x18\x06 \x01(\tR\vproductCode\x12\x18\n" +
+	"\apercent\x18\a \x01(\tR\apercent\x12\x16\n" +
+	"\x06amount\x18\b \x01(\tR\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\t \x01(\tR\bcurrency\x12!\n" +
+	"\fbuy_quantity\x18\n" +
+	" \x01(\x05R\vbuyQuantity\x12#\n" +
+	"\rfree_quantity\x18\v \x01(\x05R\ffreeQuantity\x12$\n" +
+	"\vusage_limit\x18\f \x01(\x05H\x00R\n" +
+	"usageLimit\x88\x01\x01\x12\x1f\n" +
+	"\vusage_count\x18\r \x01(\x05R\n" +
+	"usageCount\x129\n" +
+	"\n" +
+	"valid_from\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tvalidFrom\x125\n" +
+	"\bvalid_to\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\avalidTo\x12\x16\n" +
+	"\x06active\x18\x10 \x01(\bR\x06active\x129\n" +
+	"\n" +
+	"created_at\x18\x11 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAtB\x0e\n" +
+	"\f_usage_limit\"\xcb\x03\n" +
+	"\x16CreatePromotionRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04kind\x18\x03 \x01(\tR\x04kind\x12\x14\n" +
+	"\x05scope\x18\x04 \x01(\tR\x05scope\x12!\n" +
+	"\fproduct_code\x18\x05 \x01(\tR\vproductCode\x12\x18\n" +
+	"\apercent\x18\x06 \x01(\tR\apercent\x12\x16\n" +
+	"\x06amount\x18\a \x01(\tR\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\b \x01(\tR\bcurrency\x12!\n" +
+	"\fbuy_quantity\x18\t \x01(\x05R\vbuyQuantity\x12#\n" +
+	"\rfree_quantity\x18\n" +
+	" \x01(\x05R\ffreeQuantity\x12$\n" +
+	"\vusage_limit\x18\v \x01(\x05H\x00R\n" +
+	"usageLimit\x88\x01\x01\x129\n" +
+	"\n" +
+	"valid_from\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tvalidFrom\x125\n" +
+	"\bvalid_to\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\avalidToB\x0e\n" +
+	"\f_usage_limit\")\n" +
+	"\x13GetPromotionRequest\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\"E\n" +
+	"\x15ListPromotionsRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x05R\x06offset\"L\n" +
+	"\x16ListPromotionsResponse\x122\n" +
+	"\n" +
+	"promotions\x18\x01 \x03(\v2\x12.oms_ext.PromotionR\n" +
+	"promotions\"\x9e\x02\n" +
+	"\x16UpdatePromotionRequest\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12\x17\n" +
+	"\x04name\x18\x02 \x01(\tH\x00R\x04name\x88\x01\x01\x12\x1b\n" +
+	"\x06active\x18\x03 \x01(\bH\x01R\x06active\x88\x01\x01\x12$\n" +
+	"\vusage_limit\x18\x04 \x01(\x05H\x02R\n" +
+	"usageLimit\x88\x01\x01\x129\n" +
+	"\n" +
+	"valid_from\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tvalidFrom\x125\n" +
+	"\bvalid_to\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\avalidToB\a\n" +
+	"\x05_nameB\t\n" +
+	"\a_activeB\x0e\n" +
+	"\f_usage_limit\",\n" +
+	"\x16DeletePromotionRequest\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\"\x19\n" +
+	"\x17DeletePromotionResponse\"J\n" +
+	"\x11GetInvoiceRequest\x12\x1d\n" +
+	"\n" +
+	"order_uuid\x18\x01 \x01(\tR\torderUuid\x12\x16\n" +
+	"\x06format\x18\x02 \x01(\tR\x06format\"\xca\x01\n" +
+	"\aInvoice\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12\x16\n" +
+	"\x06number\x18\x02 \x01(\tR\x06number\x12\x1d\n" +
+	"\n" +
+	"order_uuid\x18\x03 \x01(\tR\torderUuid\x127\n" +
+	"\tissued_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\bissuedAt\x12!\n" +
+	"\fcontent_type\x18\x05 \x01(\tR\vcontentType\x12\x18\n" +
+	"\acontent\x18\x06 \x01(\fR\acontent\"`\n" +
+	"\x12WatchOrdersRequest\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x19\n" +
+	"\bstaff_id\x18\x03 \x01(\tR\astaffId\"\xc5\x03\n" +
+	"\vOrderChange\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x1d\n" +
+	"\n" +
+	"order_uuid\x18\x02 \x01(\tR\torderUuid\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x19\n" +
+	"\bstaff_id\x18\x04 \x01(\tR\astaffId\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12'\n" +
+	"\x0fprevious_status\x18\x06 \x01(\tR\x0epreviousStatus\x12;\n" +
+	"\voccurred_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\x12\x18\n" +
+	"\acomment\x18\b \x01(\tR\acomment\x12\x1d\n" +
+	"\n" +
+	"order_cost\x18\t \x01(\tR\torderCost\x12\x1a\n" +
+	"\bcurrency\x18\n" +
+	" \x01(\tR\bcurrency\x12?\n" +
+	"\rcreation_date\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\fcreationDate\x12;\n" +
+	"\vfinish_date\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"finishDate2\xc8\x01\n" +
+	"\x12OrderStatusService\x12U\n" +
+	"\fChangeStatus\x12!.oms_ext.ChangeOrderStatusRequest\x1a\".oms_ext.ChangeOrderStatusResponse\x12[\n" +
+	"\n" +
+	"GetHistory\x12%.oms_ext.GetOrderStatusHistoryRequest\x1a&.oms_ext.GetOrderStatusHistoryResponse2\xdf\x02\n" +
+	"\x10PromotionService\x12=\n" +
+	"\x06Create\x12\x1f.oms_ext.CreatePromotionRequest\x1a\x12.oms_ext.Promotion\x127\n" +
+	"\x03Get\x12\x1c.oms_ext.GetPromotionRequest\x1a\x12.oms_ext.Promotion\x12G\n" +
+	"\x04List\x12\x1e.oms_ext.ListPromotionsRequest\x1a\x1f.oms_ext.ListPromotionsResponse\x12=\n" +
+	"\x06Update\x12\x1f.oms_ext.UpdatePromotionRequest\x1a\x12.oms_ext.Promotion\x12K\n" +
+	"\x06Delete\x12\x1f.oms_ext.DeletePromotionRequest\x1a .oms_ext.DeletePromotionResponse2L\n" +
+	"\x0eInvoiceService\x12:\n" +
+	"\n" +
+	"GetInvoice\x12\x1a.oms_ext.GetInvoiceRequest\x1a\x10.oms_ext.Invoice2W\n" +
+	"\x11OrderWatchService\x12B\n" +
+	"\vWatchOrders\x12\x1b.oms_ext.WatchOrdersRequest\x1a\x14.oms_ext.OrderChange0\x01B3Z1github.com/igntnk/stocky-oms/protobufs/oms_ext_pbb\x06proto3"
+
+var (
+	file_oms_ext_proto_rawDescOnce sync.Once
+	file_oms_ext_proto_rawDescData []byte
+)
+
+func file_oms_ext_proto_rawDescGZIP() []byte {
+	file_oms_ext_proto_rawDescOnce.Do(func() {
+		file_oms_ext_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_oms_ext_proto_rawDesc), len(file_oms_ext_proto_rawDesc)))
+	})
+	return file_oms_ext_proto_rawDescData
+}
+
+var file_oms_ext_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_oms_ext_proto_goTypes = []any{
+	(*OrderStatusHistoryEntry)(nil),       // 0: oms_ext.OrderStatusHistoryEntry
+	(*ChangeOrderStatusRequest)(nil),      // 1: oms_ext.ChangeOrderStatusRequest
+	(*ChangeOrderStatusResponse)(nil),     // 2: oms_ext.ChangeOrderStatusResponse
+	(*GetOrderStatusHistoryRequest)(nil),  // 3: oms_ext.GetOrderStatusHistoryRequest
+	(*GetOrderStatusHistoryResponse)(nil), // 4: oms_ext.GetOrderStatusHistoryResponse
+	(*Promotion)(nil),                     // 5: oms_ext.Promotion
+	(*CreatePromotionRequest)(nil),        // 6: oms_ext.CreatePromotionRequest
+	(*GetPromotionRequest)(nil),           // 7: oms_ext.GetPromotionRequest
+	(*ListPromotionsRequest)(nil),         // 8: oms_ext.ListPromotionsRequest
+	(*ListPromotionsResponse)(nil),        // 9: oms_ext.ListPromotionsResponse
+	(*UpdatePromotionRequest)(nil),        // 10: oms_ext.UpdatePromotionRequest
+	(*DeletePromotionRequest)(nil),        // 11: oms_ext.DeletePromotionRequest
+	(*DeletePromotionResponse)(nil),       // 12: oms_ext.DeletePromotionResponse
+	(*GetInvoiceRequest)(nil),             // 13: oms_ext.GetInvoiceRequest
+	(*Invoice)(nil),                       // 14: oms_ext.Invoice
+	(*WatchOrdersRequest)(nil),            // 15: oms_ext.WatchOrdersRequest
+	(*OrderChange)(nil),                   // 16: oms_ext.OrderChange
+	(*timestamppb.Timestamp)(nil),         // 17: google.protobuf.Timestamp
+}
+var file_oms_ext_proto_depIdxs = []int32{
+	17, // 0: oms_ext.OrderStatusHistoryEntry.changed_at:type_name -> google.protobuf.Timestamp
+	17, // 1: oms_ext.ChangeOrderStatusResponse.finish_date:type_name -> google.protobuf.Timestamp
+	0,  // 2: oms_ext.GetOrderStatusHistoryResponse.entries:type_name -> oms_ext.OrderStatusHistoryEntry
+	17, // 3: oms_ext.Promotion.valid_from:type_name -> google.protobuf.Timestamp
+	17, // 4: oms_ext.Promotion.valid_to:type_name -> google.protobuf.Timestamp
+	17, // 5: oms_ext.Promotion.created_at:type_name -> google.protobuf.Timestamp
+	17, // 6: oms_ext.CreatePromotionRequest.valid_from:type_name -> google.protobuf.Timestamp
+	17, // 7: oms_ext.CreatePromotionRequest.valid_to:type_name -> google.protobuf.Timestamp
+	5,  // 8: oms_ext.ListPromotionsResponse.promotions:type_name -> oms_ext.Promotion
+	17, // 9: oms_ext.UpdatePromotionRequest.valid_from:type_name -> google.protobuf.Timestamp
+	17, // 10: oms_ext.UpdatePromotionRequest.valid_to:type_name -> google.protobuf.Timestamp
+	17, // 11: oms_ext.Invoice.issued_at:type_name -> google.protobuf.Timestamp
+	17, // 12: oms_ext.OrderChange.occurred_at:type_name -> google.protobuf.Timestamp
+	17, // 13: oms_ext.OrderChange.creation_date:type_name -> google.protobuf.Timestamp
+	17, // 14: oms_ext.OrderChange.finish_date:type_name -> google.protobuf.Timestamp
+	1,  // 15: oms_ext.OrderStatusService.ChangeStatus:input_type -> oms_ext.ChangeOrderStatusRequest
+	3,  // 16: oms_ext.OrderStatusService.GetHistory:input_type -> oms_ext.GetOrderStatusHistoryRequest
+	6,  // 17: oms_ext.PromotionService.Create:input_type -> oms_ext.CreatePromotionRequest
+	7,  // 18: oms_ext.PromotionService.Get:input_type -> oms_ext.GetPromotionRequest
+	8,  // 19: oms_ext.PromotionService.List:input_type -> oms_ext.ListPromotionsRequest
+	10, // 20: oms_ext.PromotionService.Update:input_type -> oms_ext.UpdatePromotionRequest
+	11, // 21: oms_ext.PromotionService.Delete:input_type -> oms_ext.DeletePromotionRequest
+	13, // 22: oms_ext.InvoiceService.GetInvoice:input_type -> oms_ext.GetInvoiceRequest
+	15, // 23: oms_ext.OrderWatchService.WatchOrders:input_type -> oms_ext.WatchOrdersRequest
+	2,  // 24: oms_ext.OrderStatusService.ChangeStatus:output_type -> oms_ext.ChangeOrderStatusResponse
+	4,  // 25: oms_ext.OrderStatusService.GetHistory:output_type -> oms_ext.GetOrderStatusHistoryResponse
+	5,  // 26: oms_ext.PromotionService.Create:output_type -> oms_ext.Promotion
+	5,  // 27: oms_ext.PromotionService.Get:output_type -> oms_ext.Promotion
+	9,  // 28: oms_ext.PromotionService.List:output_type -> oms_ext.ListPromotionsResponse
+	5,  // 29: oms_ext.PromotionService.Update:output_type -> oms_ext.Promotion
+	12, // 30: oms_ext.PromotionService.Delete:output_type -> oms_ext.DeletePromotionResponse
+	14, // 31: oms_ext.InvoiceService.GetInvoice:output_type -> oms_ext.Invoice
+	16, // 32: oms_ext.OrderWatchService.WatchOrders:output_type -> oms_ext.OrderChange
+	24, // [24:33] is the sub-list for method output_type
+	15, // [15:24] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
+}
+
+func init() { file_oms_ext_proto_init() }
+func file_oms_ext_proto_init() {
+	if File_oms_ext_proto != nil {
+		return
+	}
+	file_oms_ext_proto_msgTypes[5].OneofWrappers = []any{}
+	file_oms_ext_proto_msgTypes[6].OneofWrappers = []any{}
+	file_oms_ext_proto_msgTypes[10].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_oms_ext_proto_rawDesc), len(file_oms_ext_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   17,
+			NumExtensions: 0,
+			NumServices:   4,
+		},
+		GoTypes:           file_oms_ext_proto_goTypes,
+		DependencyIndexes: file_oms_ext_proto_depIdxs,
+		MessageInfos:      file_oms_ext_proto_msgTypes,
+	}.Build()
+	File_oms_ext_proto = out.File
+	file_oms_ext_proto_goTypes = nil
+	file_oms_ext_proto_depIdxs = nil
+}
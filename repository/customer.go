@@ -0,0 +1,234 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/igntnk/stocky-oms/db"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type CustomerRepository interface {
+	Create(ctx context.Context, arg db.CreateCustomerParams) (db.Customer, error)
+	// Get returns the customer orders are placed for as userID
+	Get(ctx context.Context, userID string) (db.Customer, error)
+	List(ctx context.Context, limit, offset int32) ([]db.Customer, error)
+	Update(ctx context.Context, arg db.UpdateCustomerParams) (db.Customer, error)
+	ListAddresses(ctx context.Context, customerUUID pgtype.UUID) ([]db.CustomerAddress, error)
+	GetAddress(ctx context.Context, arg db.GetCustomerAddressParams) (db.CustomerAddress, error)
+	// AddAddress stores a new address of the customer, makeDefault also makes
+	// it the default shipping address
+	AddAddress(ctx context.Context, arg db.AddCustomerAddressParams, makeDefault bool) (db.CustomerAddress, error)
+	UpdateAddress(ctx context.Context, arg db.UpdateCustomerAddressParams) (db.CustomerAddress, error)
+	DeleteAddress(ctx context.Context, arg db.DeleteCustomerAddressParams) error
+	// OrderStats counts the placed orders of userID, pending orders are not
+	// placed yet
+	OrderStats(ctx context.Context, userID string) (db.GetCustomerOrderStatsRow, error)
+	// Spending sums what userID paid per currency, net of refunded returns
+	Spending(ctx context.Context, userID string) ([]db.ListCustomerSpendingRow, error)
+}
+
+type customerRepository struct {
+	queries *db.Queries
+	pool    *pgxpool.Pool
+}
+
+func NewCustomerRepository(pool *pgxpool.Pool) CustomerRepository {
+	return &customerRepository{
+		queries: db.New(pool),
+		pool:    pool,
+	}
+}
+
+func (r *customerRepository) Create(ctx context.Context, arg db.CreateCustomerParams) (db.Customer, error) {
+	tenant, err := ownerTenantID(ctx)
+	if err != nil {
+		return db.Customer{}, err
+	}
+	arg.TenantID = tenant
+
+	customer, err := r.queries.CreateCustomer(ctx, arg)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode {
+			return db.Customer{}, ErrCustomerExists
+		}
+		return db.Customer{}, err
+	}
+	return customer, nil
+}
+
+func (r *customerRepository) Get(ctx context.Context, userID string) (db.Customer, error) {
+	tenant, err := tenantID(ctx)
+	if err != nil {
+		return db.Customer{}, err
+	}
+
+	customer, err := r.queries.GetCustomer(ctx, db.GetCustomerParams{
+		UserID:   userID,
+		TenantID: tenant,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.Customer{}, ErrCustomerNotFound
+		}
+		return db.Customer{}, err
+	}
+	return customer, nil
+}
+
+func (r *customerRepository) List(ctx context.Context, limit, offset int32) ([]db.Customer, error) {
+	tenant, err := tenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.queries.ListCustomers(ctx, db.ListCustomersParams{
+		TenantID: tenant,
+		Limit:    limit,
+		Offset:   offset,
+	})
+}
+
+func (r *customerRepository) Update(ctx context.Context, arg db.UpdateCustomerParams) (db.Customer, error) {
+	tenant, err := tenantID(ctx)
+	if err != nil {
+		return db.Customer{}, err
+	}
+	arg.TenantID = tenant
+
+	customer, err := r.queries.UpdateCustomer(ctx, arg)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.Customer{}, ErrCustomerNotFound
+		}
+		return db.Customer{}, err
+	}
+	return customer, nil
+}
+
+func (r *customerRepository) ListAddresses(ctx context.Context, customerUUID pgtype.UUID) ([]db.CustomerAddress, error) {
+	return r.queries.ListCustomerAddresses(ctx, customerUUID)
+}
+
+func (r *customerRepository) GetAddress(ctx context.Context, arg db.GetCustomerAddressParams) (db.CustomerAddress, error) {
+	address, err := r.queries.GetCustomerAddress(ctx, arg)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.CustomerAddress{}, ErrAddressNotFound
+		}
+		return db.CustomerAddress{}, err
+	}
+	return address, nil
+}
+
+func (r *customerRepository) AddAddress(ctx context.Context, arg db.AddCustomerAddressParams, makeDefault bool) (db.CustomerAddress, error) {
+	tenant, err := tenantID(ctx)
+	if err != nil {
+		return db.CustomerAddress{}, err
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return db.CustomerAddress{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+
+	address, err := qtx.AddCustomerAddress(ctx, arg)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolationCode {
+			return db.CustomerAddress{}, ErrCustomerNotFound
+		}
+		return db.CustomerAddress{}, fmt.Errorf("failed to add address: %w", err)
+	}
+
+	if makeDefault {
+		_, err = qtx.UpdateCustomer(ctx, db.UpdateCustomerParams{
+			DefaultShippingAddressUuid: address.Uuid,
+			Uuid:                       arg.CustomerUuid,
+			TenantID:                   tenant,
+		})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return db.CustomerAddress{}, ErrCustomerNotFound
+			}
+			return db.CustomerAddress{}, fmt.Errorf("failed to set default address: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return db.CustomerAddress{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return address, nil
+}
+
+func (r *customerRepository) UpdateAddress(ctx context.Context, arg db.UpdateCustomerAddressParams) (db.CustomerAddress, error) {
+	address, err := r.queries.UpdateCustomerAddress(ctx, arg)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.CustomerAddress{}, ErrAddressNotFound
+		}
+		return db.CustomerAddress{}, err
+	}
+	return address, nil
+}
+
+func (r *customerRepository) DeleteAddress(ctx context.Context, arg db.DeleteCustomerAddressParams) error {
+	rows, err := r.queries.DeleteCustomerAddress(ctx, arg)
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrAddressNotFound
+	}
+	return nil
+}
+
+func (r *customerRepository) OrderStats(ctx context.Context, userID string) (db.GetCustomerOrderStatsRow, error) {
+	tenant, err := tenantID(ctx)
+	if err != nil {
+		return db.GetCustomerOrderStatsRow{}, err
+	}
+
+	return r.queries.GetCustomerOrderStats(ctx, db.GetCustomerOrderStatsParams{
+		UserID:   userID,
+		TenantID: tenant,
+	})
+}
+
+func (r *customerRepository) Spending(ctx context.Context, userID string) ([]db.ListCustomerSpendingRow, error) {
+	tenant, err := tenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.queries.ListCustomerSpending(ctx, db.ListCustomerSpendingParams{
+		UserID:   userID,
+		TenantID: tenant,
+	})
+}
+
+// ensureCustomer registers the user an order is placed for as a customer of
+// the shop, orders can only reference registered customers
+func ensureCustomer(ctx context.Context, qtx *db.Queries, tenant, userID string) error {
+	err := qtx.EnsureCustomer(ctx, db.EnsureCustomerParams{
+		Uuid: pgtype.UUID{
+			Bytes: uuid.New(),
+			Valid: true,
+		},
+		UserID:   userID,
+		TenantID: tenant,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register customer: %w", err)
+	}
+	return nil
+}
@@ -3,39 +3,108 @@ package repository
 import (
 	"errors"
 	"fmt"
+	"github.com/igntnk/stocky-oms/models"
 	"github.com/jackc/pgx/v5/pgtype"
-	"strconv"
+	"math/big"
 )
 
 var (
-	ErrProductNotFound   = errors.New("product not found")
-	ErrOrderNotFound     = errors.New("order not found")
-	ErrEmptyOrder        = errors.New("order must contain at least one product")
-	ErrInvalidOrderTotal = errors.New("order total doesn't match products sum")
+	ErrTenantRequired = errors.New("the call is not scoped to a tenant")
+
+	ErrProductNotFound    = errors.New("product not found")
+	ErrOrderNotFound      = errors.New("order not found")
+	ErrEmptyOrder         = errors.New("order must contain at least one product")
+	ErrInvalidOrderTotal  = errors.New("order total doesn't match products sum")
+	ErrOrderStatusChanged = errors.New("order status was changed concurrently")
+	ErrOrderNotEditable   = errors.New("order products can only be changed while the order is new")
+	ErrOrderLinesChanged  = errors.New("order products were changed concurrently")
+	ErrStockReturnClaimed = errors.New("stock return is in progress")
+	ErrStockReturnDone    = errors.New("stock return is completed")
+	ErrNoStockReturn      = errors.New("order has no stock return")
+	ErrIdempotencyKeyUsed = errors.New("idempotency key is already used")
+	ErrReservationExpired = errors.New("order reservation expired")
+	ErrNoUnfinishedSaga   = errors.New("no saga is left unfinished")
+
+	ErrCustomerNotFound = errors.New("customer not found")
+	ErrCustomerExists   = errors.New("customer already exists")
+	ErrAddressNotFound  = errors.New("customer address not found")
+
+	ErrShippingMethodNotFound = errors.New("shipping method not found")
+	ErrShippingMethodExists   = errors.New("shipping method with the code already exists")
+	ErrShippingMethodInUse    = errors.New("shipping method is used by orders")
+
+	ErrShipmentNotFound          = errors.New("shipment not found")
+	ErrShipmentStatusChanged     = errors.New("shipment status was changed concurrently")
+	ErrOrderNotShippable         = errors.New("only processing orders can be shipped")
+	ErrShipmentProductNotInOrder = errors.New("shipped product is not in the order")
+	ErrShipmentExceedsOrder      = errors.New("shipment exceeds the order amount left to ship")
+
+	ErrReturnNotFound          = errors.New("return not found")
+	ErrReturnStatusChanged     = errors.New("return status was changed concurrently")
+	ErrReturnStockClaimed      = errors.New("return products were already written back")
+	ErrOrderNotReturnable      = errors.New("only completed orders can be returned")
+	ErrReturnProductNotInOrder = errors.New("returned product is not in the order")
+	ErrReturnExceedsOrder      = errors.New("return exceeds what is left of the order")
+
+	ErrPaymentNotFound      = errors.New("order has no active payment")
+	ErrPaymentExists        = errors.New("order already has an active payment")
+	ErrPaymentStatusChanged = errors.New("payment status was changed concurrently")
+
+	ErrInvoiceNotFound = errors.New("order has no invoice")
+	ErrInvoiceExists   = errors.New("order is already invoiced")
+	ErrOrderInvoiced   = errors.New("invoiced orders can not be deleted")
+
+	ErrPromotionNotFound    = errors.New("promotion not found")
+	ErrPromotionCodeUsed    = errors.New("promotion code is already used")
+	ErrPromotionUnavailable = errors.New("promotion is no longer available")
+
+	ErrTaxRuleNotFound = errors.New("tax rule not found")
+	ErrTaxRuleExists   = errors.New("tax rule for the class and region already exists")
+
+	ErrPriceNotFound         = errors.New("product has no price at this time")
+	ErrPriceChangeNotFound   = errors.New("scheduled price change not found")
+	ErrPriceChangeNotPending = errors.New("scheduled price change is not pending")
+	ErrNoDuePriceChange      = errors.New("no scheduled price change is due")
+)
+
+const (
+	// uniqueViolationCode is the postgres error code for unique constraint violations
+	uniqueViolationCode = "23505"
+	// foreignKeyViolationCode is the postgres error code for foreign key violations
+	foreignKeyViolationCode = "23503"
 )
 
-func NumericToFloat64(n pgtype.Numeric) (float64, error) {
-	val, err := n.Value()
-	if err != nil {
-		return 0, err
+var tenInt = big.NewInt(10)
+
+// NumericToMoney converts a DECIMAL value to money. Values with more than two
+// significant decimal places are rejected instead of being rounded.
+func NumericToMoney(n pgtype.Numeric) (models.Money, error) {
+	if !n.Valid || n.NaN || n.InfinityModifier != pgtype.Finite || n.Int == nil {
+		return models.Money{}, fmt.Errorf("%w: not a finite number", models.ErrInvalidMoney)
 	}
-	switch v := val.(type) {
-	case float64:
-		return v, nil
-	case string:
-		return strconv.ParseFloat(v, 64)
-	default:
-		return 0, fmt.Errorf("неожиданный тип данных: %T", v)
+
+	cents := new(big.Int).Set(n.Int)
+	exp := int64(n.Exp) + 2
+	if exp >= 0 {
+		cents.Mul(cents, new(big.Int).Exp(tenInt, big.NewInt(exp), nil))
+	} else {
+		var rem big.Int
+		cents.QuoRem(cents, new(big.Int).Exp(tenInt, big.NewInt(-exp), nil), &rem)
+		if rem.Sign() != 0 {
+			return models.Money{}, fmt.Errorf("%w: more than two decimal places", models.ErrInvalidMoney)
+		}
 	}
-}
 
-func Float64ToNumericWithPrecision(f float64) (pgtype.Numeric, error) {
-	s := strconv.FormatFloat(f, 'g', -1, 64)
+	if !cents.IsInt64() {
+		return models.Money{}, fmt.Errorf("%w: out of range", models.ErrInvalidMoney)
+	}
+	return models.MoneyFromCents(cents.Int64()), nil
+}
 
-	n := pgtype.Numeric{}
-	err := n.Scan(s)
-	if err != nil {
-		return pgtype.Numeric{}, fmt.Errorf("scan error: %w", err)
+func MoneyToNumeric(m models.Money) pgtype.Numeric {
+	return pgtype.Numeric{
+		Int:   big.NewInt(m.Cents()),
+		Exp:   -2,
+		Valid: true,
 	}
-	return n, nil
 }
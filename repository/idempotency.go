@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/igntnk/stocky-oms/db"
+	"github.com/jackc/pgx/v5"
+	"time"
+)
+
+type IdempotencyRepository interface {
+	Claim(ctx context.Context, scope, key, fingerprint string, ttl time.Duration) error
+	Get(ctx context.Context, scope, key string) (db.IdempotencyKey, error)
+	Complete(ctx context.Context, scope, key string, response []byte) error
+	Release(ctx context.Context, scope, key string) error
+	DeleteExpired(ctx context.Context) (int64, error)
+}
+
+type idempotencyRepository struct {
+	queries *db.Queries
+}
+
+func NewIdempotencyRepository(conn db.DBTX) IdempotencyRepository {
+	return &idempotencyRepository{
+		queries: db.New(conn),
+	}
+}
+
+// Claim reserves the key for ttl. Returns ErrIdempotencyKeyUsed when the key
+// exists and is not expired yet.
+func (r *idempotencyRepository) Claim(ctx context.Context, scope, key, fingerprint string, ttl time.Duration) error {
+	_, err := r.queries.ClaimIdempotencyKey(ctx, db.ClaimIdempotencyKeyParams{
+		Scope:       scope,
+		Key:         key,
+		Fingerprint: fingerprint,
+		TtlSeconds:  ttl.Seconds(),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrIdempotencyKeyUsed
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *idempotencyRepository) Get(ctx context.Context, scope, key string) (db.IdempotencyKey, error) {
+	return r.queries.GetIdempotencyKey(ctx, db.GetIdempotencyKeyParams{
+		Scope: scope,
+		Key:   key,
+	})
+}
+
+func (r *idempotencyRepository) Complete(ctx context.Context, scope, key string, response []byte) error {
+	return r.queries.CompleteIdempotencyKey(ctx, db.CompleteIdempotencyKeyParams{
+		Scope:    scope,
+		Key:      key,
+		Response: response,
+	})
+}
+
+// Release frees a key whose request failed so it can be retried
+func (r *idempotencyRepository) Release(ctx context.Context, scope, key string) error {
+	return r.queries.ReleaseIdempotencyKey(ctx, db.ReleaseIdempotencyKeyParams{
+		Scope: scope,
+		Key:   key,
+	})
+}
+
+func (r *idempotencyRepository) DeleteExpired(ctx context.Context) (int64, error) {
+	return r.queries.DeleteExpiredIdempotencyKeys(ctx)
+}
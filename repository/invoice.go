@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/igntnk/stocky-oms/db"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type InvoiceRepository interface {
+	// Create issues the invoice under the next invoice number of the caller
+	// shop. snapshot builds the stored snapshot for the number. Returns
+	// ErrInvoiceExists if the order is already invoiced, the number is not
+	// used then.
+	Create(ctx context.Context, arg db.CreateInvoiceParams, snapshot func(number int64) ([]byte, error)) (db.Invoice, error)
+	GetByOrder(ctx context.Context, orderUUID pgtype.UUID) (db.Invoice, error)
+}
+
+type invoiceRepository struct {
+	pool    *pgxpool.Pool
+	queries *db.Queries
+}
+
+func NewInvoiceRepository(pool *pgxpool.Pool) InvoiceRepository {
+	return &invoiceRepository{
+		pool:    pool,
+		queries: db.New(pool),
+	}
+}
+
+func (r *invoiceRepository) Create(ctx context.Context, arg db.CreateInvoiceParams, snapshot func(number int64) ([]byte, error)) (db.Invoice, error) {
+	tenant, err := ownerTenantID(ctx)
+	if err != nil {
+		return db.Invoice{}, err
+	}
+	arg.TenantID = tenant
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return db.Invoice{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+
+	arg.Number, err = qtx.NextInvoiceNumber(ctx, tenant)
+	if err != nil {
+		return db.Invoice{}, fmt.Errorf("failed to take invoice number: %w", err)
+	}
+
+	arg.Snapshot, err = snapshot(arg.Number)
+	if err != nil {
+		return db.Invoice{}, err
+	}
+
+	invoice, err := qtx.CreateInvoice(ctx, arg)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode {
+			return db.Invoice{}, ErrInvoiceExists
+		}
+		return db.Invoice{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return db.Invoice{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return invoice, nil
+}
+
+func (r *invoiceRepository) GetByOrder(ctx context.Context, orderUUID pgtype.UUID) (db.Invoice, error) {
+	tenant, err := tenantID(ctx)
+	if err != nil {
+		return db.Invoice{}, err
+	}
+
+	invoice, err := r.queries.GetOrderInvoice(ctx, db.GetOrderInvoiceParams{
+		OrderUuid: orderUUID,
+		TenantID:  tenant,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.Invoice{}, ErrInvoiceNotFound
+		}
+		return db.Invoice{}, err
+	}
+	return invoice, nil
+}
@@ -4,12 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"github.com/google/uuid"
+	"github.com/igntnk/stocky-oms/auth"
 	"github.com/igntnk/stocky-oms/models"
 	"github.com/jackc/pgx/v5/pgtype"
+	"time"
 
 	"github.com/igntnk/stocky-oms/db"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -17,13 +19,31 @@ type OrderRepository interface {
 	CreateNakedOrder(ctx context.Context, orderParams db.CreateOrderParams) (db.Order, error)
 	CreateWithProducts(ctx context.Context, orderParams db.CreateOrderParams, products []db.AddProductToOrderParams) (db.Order, error)
 	Get(ctx context.Context, uuid string) (db.Order, error)
-	List(ctx context.Context, limit, offset int32, status db.OrderStatus) ([]db.Order, error)
-	UpdateStatus(ctx context.Context, uuid string, status db.OrderStatus) (db.Order, error)
+	// List returns orders newest first, status and userID filter when set
+	List(ctx context.Context, limit, offset int32, status db.OrderStatus, userID string) ([]db.Order, error)
+	UpdateStatus(ctx context.Context, history db.AddOrderStatusHistoryParams) (db.Order, error)
+	ListStatusHistory(ctx context.Context, orderUUID string) ([]db.OrderStatusHistory, error)
+	Cancel(ctx context.Context, history db.AddOrderStatusHistoryParams) (db.Order, error)
+	CreatePending(ctx context.Context, orderParams db.CreateOrderParams, products []db.AddProductToOrderParams, ttl time.Duration) (db.Order, error)
+	HoldStock(ctx context.Context, orderUUID string) error
+	ConfirmPending(ctx context.Context, history db.AddOrderStatusHistoryParams) (db.Order, error)
+	CancelPending(ctx context.Context, history db.AddOrderStatusHistoryParams) (db.Order, error)
+	ListExpiredPending(ctx context.Context, limit int32) ([]string, error)
 	UpdateOrder(ctx context.Context, order db.UpdateOrderParams) (db.Order, error)
 	Delete(ctx context.Context, uuid string) error
 	GetOrderProducts(ctx context.Context, orderUUID string) ([]db.GetOrderProductsRow, error)
-	CalculateOrderTotal(ctx context.Context, orderUUID string) (int64, error)
-	AddOrderProduct(ctx context.Context, orderID string, productID string, amount float64) (*models.ProductDetail, error)
+	// CalculateOrderTotal sums the lines of the order, net of line discounts
+	// and with exclusive tax added
+	CalculateOrderTotal(ctx context.Context, orderUUID string) (models.Money, error)
+	// SetOrderProducts replaces the lines of a new order with products and
+	// stores the recalculated totals. Returns ErrOrderLinesChanged if the
+	// lines are no longer the previous ones the products were derived from.
+	SetOrderProducts(
+		ctx context.Context,
+		totals db.UpdateOrderTotalsParams,
+		previous []db.GetOrderProductsRow,
+		products []db.AddProductToOrderParams,
+	) (db.Order, error)
 }
 
 type orderRepository struct {
@@ -38,47 +58,31 @@ func NewOrderRepository(pool *pgxpool.Pool) OrderRepository {
 	}
 }
 
-func (r *orderRepository) AddOrderProduct(ctx context.Context, orderID string, productID string, amount float64) (*models.ProductDetail, error) {
-	prUuid, err := uuid.Parse(productID)
+func (r *orderRepository) UpdateOrder(ctx context.Context, order db.UpdateOrderParams) (db.Order, error) {
+	tenant, err := tenantID(ctx)
 	if err != nil {
-		return nil, err
+		return db.Order{}, err
 	}
+	order.TenantID = tenant
 
-	orUuid, err := uuid.Parse(orderID)
+	tx, err := r.pool.Begin(ctx)
 	if err != nil {
-		return nil, err
+		return db.Order{}, fmt.Errorf("failed to begin transaction: %w", err)
 	}
+	defer tx.Rollback(ctx)
 
-	cost, err := Float64ToNumericWithPrecision(200)
-	if err != nil {
-		return nil, err
-	}
+	qtx := r.queries.WithTx(tx)
 
-	_, err = r.queries.AddProductToOrder(ctx, db.AddProductToOrderParams{
-		ProductCode: pgtype.UUID{
-			Bytes: prUuid,
-			Valid: true,
-		},
-		OrderUuid: pgtype.UUID{
-			Bytes: orUuid,
-			Valid: true,
-		},
-		ResultPrice: cost,
-		Amount:      int32(amount),
-	})
-	if err != nil {
-		return nil, err
+	// internal callers don't know the shop of the order before the update,
+	// they can only move it to a registered customer
+	if order.UserID.Valid && tenant != auth.AllTenants {
+		err = ensureCustomer(ctx, qtx, tenant, order.UserID.String)
+		if err != nil {
+			return db.Order{}, err
+		}
 	}
 
-	return &models.ProductDetail{
-		Price:       100,
-		ProductCode: productID,
-		Amount:      int(amount),
-	}, nil
-}
-
-func (r *orderRepository) UpdateOrder(ctx context.Context, order db.UpdateOrderParams) (db.Order, error) {
-	resOrder, err := r.queries.UpdateOrder(ctx, order)
+	resOrder, err := qtx.UpdateOrder(ctx, order)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return db.Order{}, ErrOrderNotFound
@@ -86,16 +90,53 @@ func (r *orderRepository) UpdateOrder(ctx context.Context, order db.UpdateOrderP
 		return db.Order{}, err
 	}
 
+	err = addOrderEvent(ctx, qtx, models.OrderEventUpdated, resOrder, "")
+	if err != nil {
+		return db.Order{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return db.Order{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
 	return resOrder, nil
 }
 
 func (r *orderRepository) CreateNakedOrder(ctx context.Context, orderParams db.CreateOrderParams) (db.Order, error) {
+	tenant, err := ownerTenantID(ctx)
+	if err != nil {
+		return db.Order{}, err
+	}
+	orderParams.TenantID = tenant
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return db.Order{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+
+	err = ensureCustomer(ctx, qtx, tenant, orderParams.UserID)
+	if err != nil {
+		return db.Order{}, err
+	}
+
 	// Создаем заказ
-	order, err := r.queries.CreateOrder(ctx, orderParams)
+	order, err := qtx.CreateOrder(ctx, orderParams)
 	if err != nil {
 		return db.Order{}, fmt.Errorf("failed to create order: %w", err)
 	}
 
+	err = addOrderEvent(ctx, qtx, models.OrderEventCreated, order, "")
+	if err != nil {
+		return db.Order{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return db.Order{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
 	return order, nil
 }
 
@@ -108,6 +149,12 @@ func (r *orderRepository) CreateWithProducts(
 		return db.Order{}, ErrEmptyOrder
 	}
 
+	tenant, err := ownerTenantID(ctx)
+	if err != nil {
+		return db.Order{}, err
+	}
+	orderParams.TenantID = tenant
+
 	tx, err := r.pool.Begin(ctx)
 	if err != nil {
 		return db.Order{}, fmt.Errorf("failed to begin transaction: %w", err)
@@ -116,19 +163,53 @@ func (r *orderRepository) CreateWithProducts(
 
 	qtx := r.queries.WithTx(tx)
 
+	err = ensureCustomer(ctx, qtx, tenant, orderParams.UserID)
+	if err != nil {
+		return db.Order{}, err
+	}
+
 	// Создаем заказ
 	order, err := qtx.CreateOrder(ctx, orderParams)
 	if err != nil {
 		return db.Order{}, fmt.Errorf("failed to create order: %w", err)
 	}
 
+	order, err = addOrderProducts(ctx, qtx, order, products)
+	if err != nil {
+		return db.Order{}, err
+	}
+
+	err = addOrderEvent(ctx, qtx, models.OrderEventCreated, order, "")
+	if err != nil {
+		return db.Order{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return db.Order{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return order, nil
+}
+
+// addOrderProducts adds the products to a just created order, checks or
+// fills in the order total and counts the uses of the applied promotions
+func addOrderProducts(
+	ctx context.Context,
+	qtx *db.Queries,
+	order db.Order,
+	products []db.AddProductToOrderParams,
+) (db.Order, error) {
 	// Добавляем продукты к заказу
-	var total float64
+	var total models.Money
 	for _, product := range products {
 		product.OrderUuid = order.Uuid
+		product.TenantID = order.TenantID
 
 		// Проверяем существование продукта
-		_, err := qtx.GetProduct(ctx, product.ProductCode)
+		_, err := qtx.GetProduct(ctx, db.GetProductParams{
+			ProductCode: product.ProductCode,
+			TenantID:    order.TenantID,
+		})
 		if err != nil {
 			if errors.Is(err, pgx.ErrNoRows) {
 				return db.Order{}, ErrProductNotFound
@@ -142,42 +223,71 @@ func (r *orderRepository) CreateWithProducts(
 			return db.Order{}, fmt.Errorf("failed to add product to order: %w", err)
 		}
 
-		resPrice, err := NumericToFloat64(product.ResultPrice)
+		resPrice, err := NumericToMoney(product.ResultPrice)
 		if err != nil {
 			return db.Order{}, fmt.Errorf("failed to convert result price: %w", err)
 		}
 
-		total += resPrice * float64(product.Amount)
+		total = total.Add(resPrice.Mul(int64(product.Amount)))
+
+		if product.Discount.Valid {
+			discount, err := NumericToMoney(product.Discount)
+			if err != nil {
+				return db.Order{}, fmt.Errorf("failed to convert discount: %w", err)
+			}
+			total = total.Sub(discount)
+		}
+
+		// inclusive tax is already part of the price
+		if product.TaxAmount.Valid && !product.TaxInclusive.Bool {
+			tax, err := NumericToMoney(product.TaxAmount)
+			if err != nil {
+				return db.Order{}, fmt.Errorf("failed to convert tax: %w", err)
+			}
+			total = total.Add(tax)
+		}
 	}
 
-	// Проверяем соответствие суммы заказа и суммы продуктов
-	orCost, err := NumericToFloat64(order.OrderCost)
+	orDiscount, err := NumericToMoney(order.Discount)
 	if err != nil {
-		return db.Order{}, fmt.Errorf("failed to convert order cost: %w", err)
+		return db.Order{}, fmt.Errorf("failed to convert order discount: %w", err)
 	}
-	if orCost != total {
-		return db.Order{}, ErrInvalidOrderTotal
+	total = total.Sub(orDiscount)
+
+	shipping, err := NumericToMoney(order.ShippingCost)
+	if err != nil {
+		return db.Order{}, fmt.Errorf("failed to convert shipping cost: %w", err)
 	}
+	total = total.Add(shipping)
 
-	// Обновляем сумму заказа (на случай если она не была указана)
-	if orCost == 0 {
-		totalNum, err := Float64ToNumericWithPrecision(total)
-		if err != nil {
-			return db.Order{}, fmt.Errorf("failed to convert total price: %w", err)
-		}
+	orCost, err := NumericToMoney(order.OrderCost)
+	if err != nil {
+		return db.Order{}, fmt.Errorf("failed to convert order cost: %w", err)
+	}
 
+	// Обновляем сумму заказа (на случай если она не была указана)
+	if orCost.IsZero() {
+		totalNum := MoneyToNumeric(total)
 		_, err = qtx.UpdateOrder(ctx, db.UpdateOrderParams{
 			Uuid:      order.Uuid,
 			OrderCost: totalNum,
+			TenantID:  order.TenantID,
 		})
 		if err != nil {
 			return db.Order{}, fmt.Errorf("failed to update order total: %w", err)
 		}
 		order.OrderCost = totalNum
+		orCost = total
 	}
 
-	if err := tx.Commit(ctx); err != nil {
-		return db.Order{}, fmt.Errorf("failed to commit transaction: %w", err)
+	// Проверяем соответствие суммы заказа и суммы продуктов
+	if orCost.Cmp(total) != 0 {
+		return db.Order{}, ErrInvalidOrderTotal
+	}
+
+	err = usePromotions(ctx, qtx, order, products)
+	if err != nil {
+		return db.Order{}, err
 	}
 
 	return order, nil
@@ -190,7 +300,15 @@ func (r *orderRepository) Get(ctx context.Context, orderUuid string) (db.Order,
 		return db.Order{}, err
 	}
 
-	order, err := r.queries.GetOrder(ctx, resUuid)
+	tenant, err := tenantID(ctx)
+	if err != nil {
+		return db.Order{}, err
+	}
+
+	order, err := r.queries.GetOrder(ctx, db.GetOrderParams{
+		Uuid:     resUuid,
+		TenantID: tenant,
+	})
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return db.Order{}, ErrOrderNotFound
@@ -204,39 +322,154 @@ func (r *orderRepository) List(
 	ctx context.Context,
 	limit, offset int32,
 	status db.OrderStatus,
+	userID string,
 ) ([]db.Order, error) {
+	tenant, err := tenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	return r.queries.ListOrders(ctx, db.ListOrdersParams{
 		Limit:  limit,
 		Offset: offset,
-		Status: status,
+		Status: db.NullOrderStatus{
+			OrderStatus: status,
+			Valid:       status != "",
+		},
+		UserID: pgtype.Text{
+			String: userID,
+			Valid:  userID != "",
+		},
+		TenantID: tenant,
 	})
 }
 
+// UpdateStatus moves the order from history.FromStatus to history.ToStatus and
+// records the transition. The update only applies while the order is still in
+// FromStatus, so concurrent transitions result in ErrOrderStatusChanged.
 func (r *orderRepository) UpdateStatus(
 	ctx context.Context,
-	orderUuid string,
-	status db.OrderStatus,
+	history db.AddOrderStatusHistoryParams,
 ) (db.Order, error) {
-	var resUuid pgtype.UUID
-	err := resUuid.Scan(orderUuid)
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return db.Order{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+
+	order, err := updateOrderStatus(ctx, qtx, history)
 	if err != nil {
 		return db.Order{}, err
 	}
 
-	order, err := r.queries.UpdateOrderStatus(ctx, db.UpdateOrderStatusParams{
-		Uuid:   resUuid,
-		Status: status,
-	})
+	if err := tx.Commit(ctx); err != nil {
+		return db.Order{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return order, nil
+}
+
+// Cancel moves the order to cancelled like UpdateStatus and, if SMS wrote its
+// products off, registers a pending stock return for them in the same
+// transaction. Shipments that were not sent yet are cancelled with the order.
+func (r *orderRepository) Cancel(
+	ctx context.Context,
+	history db.AddOrderStatusHistoryParams,
+) (db.Order, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return db.Order{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+
+	history.ToStatus = db.OrderStatusCancelled
+	order, err := updateOrderStatus(ctx, qtx, history)
+	if err != nil {
+		return db.Order{}, err
+	}
+
+	if order.StockTaken {
+		_, err = qtx.CreateStockReturn(ctx, db.CreateStockReturnParams{
+			OrderUuid: order.Uuid,
+			TenantID:  order.TenantID,
+		})
+		if err != nil {
+			return db.Order{}, fmt.Errorf("failed to create stock return: %w", err)
+		}
+	}
+
+	err = qtx.CancelOrderShipments(ctx, order.Uuid)
+	if err != nil {
+		return db.Order{}, fmt.Errorf("failed to cancel shipments: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return db.Order{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return order, nil
+}
+
+// updateOrderStatus moves the order from history.FromStatus to
+// history.ToStatus and records the transition and the order event
+func updateOrderStatus(
+	ctx context.Context,
+	qtx *db.Queries,
+	history db.AddOrderStatusHistoryParams,
+) (db.Order, error) {
+	tenant, err := tenantID(ctx)
+	if err != nil {
+		return db.Order{}, err
+	}
 
+	order, err := qtx.UpdateOrderStatus(ctx, db.UpdateOrderStatusParams{
+		Status:     history.ToStatus,
+		Uuid:       history.OrderUuid,
+		FromStatus: history.FromStatus,
+		TenantID:   tenant,
+	})
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return db.Order{}, ErrOrderNotFound
+			return db.Order{}, ErrOrderStatusChanged
 		}
 		return db.Order{}, err
 	}
+
+	_, err = qtx.AddOrderStatusHistory(ctx, history)
+	if err != nil {
+		return db.Order{}, fmt.Errorf("failed to add status history: %w", err)
+	}
+
+	err = addOrderEvent(ctx, qtx, models.OrderEventStatusChanged, order, history.FromStatus)
+	if err != nil {
+		return db.Order{}, err
+	}
+
 	return order, nil
 }
 
+func (r *orderRepository) ListStatusHistory(ctx context.Context, orderUUID string) ([]db.OrderStatusHistory, error) {
+	var resUuid pgtype.UUID
+	err := resUuid.Scan(orderUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	tenant, err := tenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.queries.ListOrderStatusHistory(ctx, db.ListOrderStatusHistoryParams{
+		OrderUuid: resUuid,
+		TenantID:  tenant,
+	})
+}
+
 func (r *orderRepository) Delete(ctx context.Context, orderUuid string) error {
 	var resUuid pgtype.UUID
 	err := resUuid.Scan(orderUuid)
@@ -244,16 +477,60 @@ func (r *orderRepository) Delete(ctx context.Context, orderUuid string) error {
 		return err
 	}
 
-	err = r.queries.DeleteOrderProducts(ctx, resUuid)
+	tenant, err := tenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+
+	order, err := qtx.GetOrder(ctx, db.GetOrderParams{
+		Uuid:     resUuid,
+		TenantID: tenant,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrOrderNotFound
+		}
+		return err
+	}
+
+	err = qtx.DeleteOrderProducts(ctx, db.DeleteOrderProductsParams{
+		OrderUuid: order.Uuid,
+		TenantID:  order.TenantID,
+	})
 	if err != nil {
 		return err
 	}
 
-	err = r.queries.DeleteOrder(ctx, resUuid)
-	if errors.Is(err, pgx.ErrNoRows) {
-		return ErrOrderNotFound
+	err = qtx.DeleteOrder(ctx, db.DeleteOrderParams{
+		Uuid:     order.Uuid,
+		TenantID: order.TenantID,
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolationCode && pgErr.TableName == "invoices" {
+			return ErrOrderInvoiced
+		}
+		return err
 	}
-	return err
+
+	err = addOrderEvent(ctx, qtx, models.OrderEventDeleted, order, "")
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
 }
 
 func (r *orderRepository) GetOrderProducts(
@@ -266,22 +543,217 @@ func (r *orderRepository) GetOrderProducts(
 		return nil, err
 	}
 
-	return r.queries.GetOrderProducts(ctx, resUuid)
+	tenant, err := tenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.queries.GetOrderProducts(ctx, db.GetOrderProductsParams{
+		OrderUuid: resUuid,
+		TenantID:  tenant,
+	})
 }
 
 func (r *orderRepository) CalculateOrderTotal(
 	ctx context.Context,
 	orderUUID string,
-) (int64, error) {
+) (models.Money, error) {
 	var resUuid pgtype.UUID
 	err := resUuid.Scan(orderUUID)
 	if err != nil {
-		return 0, err
+		return models.Money{}, err
 	}
 
-	total, err := r.queries.CalculateOrderTotal(ctx, resUuid)
+	tenant, err := tenantID(ctx)
 	if err != nil {
-		return 0, fmt.Errorf("failed to calculate order total: %w", err)
+		return models.Money{}, err
+	}
+
+	total, err := r.queries.CalculateOrderTotal(ctx, db.CalculateOrderTotalParams{
+		OrderUuid: resUuid,
+		TenantID:  tenant,
+	})
+	if err != nil {
+		return models.Money{}, fmt.Errorf("failed to calculate order total: %w", err)
+	}
+	return NumericToMoney(total)
+}
+
+// SetOrderProducts updates the lines present in products, adds the missing
+// ones and removes the lines not in products. The order must still be new.
+// Updating the totals locks the order, so concurrent changes are serialized
+// and the later one finds the lines differ from previous. The stored totals
+// are checked against the sum of the lines and shipping.
+func (r *orderRepository) SetOrderProducts(
+	ctx context.Context,
+	totals db.UpdateOrderTotalsParams,
+	previous []db.GetOrderProductsRow,
+	products []db.AddProductToOrderParams,
+) (db.Order, error) {
+	if len(products) == 0 {
+		return db.Order{}, ErrEmptyOrder
+	}
+
+	tenant, err := tenantID(ctx)
+	if err != nil {
+		return db.Order{}, err
+	}
+	totals.TenantID = tenant
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return db.Order{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+
+	order, err := qtx.UpdateOrderTotals(ctx, totals)
+	if err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return db.Order{}, fmt.Errorf("failed to update order totals: %w", err)
+		}
+
+		_, err = qtx.GetOrder(ctx, db.GetOrderParams{
+			Uuid:     totals.Uuid,
+			TenantID: tenant,
+		})
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.Order{}, ErrOrderNotFound
+		}
+		if err != nil {
+			return db.Order{}, err
+		}
+		return db.Order{}, ErrOrderNotEditable
+	}
+
+	current, err := qtx.GetOrderProducts(ctx, db.GetOrderProductsParams{
+		OrderUuid: order.Uuid,
+		TenantID:  order.TenantID,
+	})
+	if err != nil {
+		return db.Order{}, fmt.Errorf("failed to get order products: %w", err)
+	}
+
+	if !sameOrderLines(current, previous) {
+		return db.Order{}, ErrOrderLinesChanged
+	}
+
+	removed := make(map[pgtype.UUID]pgtype.UUID, len(current))
+	for _, line := range current {
+		removed[line.ProductCode] = line.ProductUuid
+	}
+
+	for _, product := range products {
+		product.OrderUuid = order.Uuid
+		product.TenantID = order.TenantID
+
+		if _, ok := removed[product.ProductCode]; ok {
+			delete(removed, product.ProductCode)
+			_, err = qtx.UpdateOrderProduct(ctx, db.UpdateOrderProductParams{
+				Amount:       product.Amount,
+				Discount:     product.Discount,
+				TaxRate:      product.TaxRate,
+				TaxAmount:    product.TaxAmount,
+				TaxInclusive: product.TaxInclusive.Bool,
+				OrderUuid:    order.Uuid,
+				ProductCode:  product.ProductCode,
+				TenantID:     order.TenantID,
+			})
+			if err != nil {
+				return db.Order{}, fmt.Errorf("failed to update order product: %w", err)
+			}
+			continue
+		}
+
+		_, err = qtx.GetProduct(ctx, db.GetProductParams{
+			ProductCode: product.ProductCode,
+			TenantID:    order.TenantID,
+		})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return db.Order{}, ErrProductNotFound
+			}
+			return db.Order{}, fmt.Errorf("failed to get product: %w", err)
+		}
+
+		_, err = qtx.AddProductToOrder(ctx, product)
+		if err != nil {
+			return db.Order{}, fmt.Errorf("failed to add product to order: %w", err)
+		}
+	}
+
+	for _, productUUID := range removed {
+		err = qtx.RemoveProductFromOrder(ctx, db.RemoveProductFromOrderParams{
+			ProductUuid: productUUID,
+			OrderUuid:   order.Uuid,
+			TenantID:    order.TenantID,
+		})
+		if err != nil {
+			return db.Order{}, fmt.Errorf("failed to remove product from order: %w", err)
+		}
+	}
+
+	linesNum, err := qtx.CalculateOrderTotal(ctx, db.CalculateOrderTotalParams{
+		OrderUuid: order.Uuid,
+		TenantID:  order.TenantID,
+	})
+	if err != nil {
+		return db.Order{}, fmt.Errorf("failed to calculate order total: %w", err)
+	}
+
+	lines, err := NumericToMoney(linesNum)
+	if err != nil {
+		return db.Order{}, fmt.Errorf("failed to convert order total: %w", err)
+	}
+
+	discount, err := NumericToMoney(order.Discount)
+	if err != nil {
+		return db.Order{}, fmt.Errorf("failed to convert order discount: %w", err)
+	}
+
+	shipping, err := NumericToMoney(order.ShippingCost)
+	if err != nil {
+		return db.Order{}, fmt.Errorf("failed to convert shipping cost: %w", err)
+	}
+
+	orCost, err := NumericToMoney(order.OrderCost)
+	if err != nil {
+		return db.Order{}, fmt.Errorf("failed to convert order cost: %w", err)
+	}
+
+	if orCost.Cmp(lines.Sub(discount).Add(shipping)) != 0 {
+		return db.Order{}, ErrInvalidOrderTotal
+	}
+
+	err = addOrderEvent(ctx, qtx, models.OrderEventUpdated, order, "")
+	if err != nil {
+		return db.Order{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return db.Order{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return order, nil
+}
+
+// sameOrderLines reports whether both hold the same products in the same
+// amounts
+func sameOrderLines(a, b []db.GetOrderProductsRow) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	amounts := make(map[pgtype.UUID]int32, len(a))
+	for _, line := range a {
+		amounts[line.ProductCode] = line.Amount
+	}
+	for _, line := range b {
+		amount, ok := amounts[line.ProductCode]
+		if !ok || amount != line.Amount {
+			return false
+		}
 	}
-	return total, nil
+	return true
 }
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/igntnk/stocky-oms/models"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// orderChangesChannel is notified by the orders table trigger
+const orderChangesChannel = "order_changes"
+
+type OrderNotifier interface {
+	// Listen calls handle with every order change committed while it listens.
+	// It returns when ctx is done or the connection fails.
+	Listen(ctx context.Context, handle func(ctx context.Context, event models.OrderEvent)) error
+}
+
+type orderNotifier struct {
+	pool *pgxpool.Pool
+}
+
+func NewOrderNotifier(pool *pgxpool.Pool) OrderNotifier {
+	return &orderNotifier{
+		pool: pool,
+	}
+}
+
+func (n *orderNotifier) Listen(ctx context.Context, handle func(ctx context.Context, event models.OrderEvent)) error {
+	conn, err := n.pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire connection: %w", err)
+	}
+
+	// The listening connection is taken out of the pool so no other query
+	// gets it with the LISTEN still active
+	pgConn := conn.Hijack()
+	defer pgConn.Close(context.WithoutCancel(ctx))
+
+	_, err = pgConn.Exec(ctx, "LISTEN "+orderChangesChannel)
+	if err != nil {
+		return fmt.Errorf("failed to listen for order changes: %w", err)
+	}
+
+	for {
+		notification, err := pgConn.WaitForNotification(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("failed to wait for order changes: %w", err)
+		}
+
+		var event models.OrderEvent
+		err = json.Unmarshal([]byte(notification.Payload), &event)
+		if err != nil {
+			return fmt.Errorf("failed to decode order change: %w", err)
+		}
+
+		handle(ctx, event)
+	}
+}
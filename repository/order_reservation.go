@@ -0,0 +1,248 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/igntnk/stocky-oms/db"
+	"github.com/igntnk/stocky-oms/models"
+	"github.com/jackc/pgx/v5/pgtype"
+	"time"
+)
+
+// CreatePending stores a pending order with its products and reserves every
+// line until ttl passes
+func (r *orderRepository) CreatePending(
+	ctx context.Context,
+	orderParams db.CreateOrderParams,
+	products []db.AddProductToOrderParams,
+	ttl time.Duration,
+) (db.Order, error) {
+	if len(products) == 0 {
+		return db.Order{}, ErrEmptyOrder
+	}
+
+	tenant, err := ownerTenantID(ctx)
+	if err != nil {
+		return db.Order{}, err
+	}
+	orderParams.TenantID = tenant
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return db.Order{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+
+	err = ensureCustomer(ctx, qtx, tenant, orderParams.UserID)
+	if err != nil {
+		return db.Order{}, err
+	}
+
+	order, err := qtx.CreatePendingOrder(ctx, db.CreatePendingOrderParams(orderParams))
+	if err != nil {
+		return db.Order{}, fmt.Errorf("failed to create order: %w", err)
+	}
+
+	order, err = addOrderProducts(ctx, qtx, order, products)
+	if err != nil {
+		return db.Order{}, err
+	}
+
+	for _, product := range products {
+		err = qtx.AddOrderReservation(ctx, db.AddOrderReservationParams{
+			Uuid: pgtype.UUID{
+				Bytes: uuid.New(),
+				Valid: true,
+			},
+			OrderUuid:   order.Uuid,
+			ProductCode: product.ProductCode,
+			Amount:      product.Amount,
+			TtlSeconds:  ttl.Seconds(),
+			TenantID:    tenant,
+		})
+		if err != nil {
+			return db.Order{}, fmt.Errorf("failed to add reservation: %w", err)
+		}
+	}
+
+	err = addOrderEvent(ctx, qtx, models.OrderEventCreated, order, "")
+	if err != nil {
+		return db.Order{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return db.Order{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return order, nil
+}
+
+// HoldStock records on the reservations and on the order that SMS has
+// written off the reserved products, so cancelling the order has to return
+// them. Returns ErrReservationExpired when the reservations were released in
+// the meantime, nothing will return the products then.
+func (r *orderRepository) HoldStock(ctx context.Context, orderUUID string) error {
+	var resUuid pgtype.UUID
+	err := resUuid.Scan(orderUUID)
+	if err != nil {
+		return err
+	}
+
+	tenant, err := tenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+
+	held, err := qtx.HoldOrderReservations(ctx, db.HoldOrderReservationsParams{
+		OrderUuid: resUuid,
+		TenantID:  tenant,
+	})
+	if err != nil {
+		return err
+	}
+	if held == 0 {
+		return ErrReservationExpired
+	}
+
+	err = qtx.MarkOrderStockTaken(ctx, db.MarkOrderStockTakenParams{
+		Uuid:     resUuid,
+		TenantID: tenant,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to mark stock taken: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// ConfirmPending confirms the reservations and moves the order to new.
+// Returns ErrReservationExpired when the reservations are past their
+// deadline or already released.
+func (r *orderRepository) ConfirmPending(ctx context.Context, history db.AddOrderStatusHistoryParams) (db.Order, error) {
+	tenant, err := tenantID(ctx)
+	if err != nil {
+		return db.Order{}, err
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return db.Order{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+
+	confirmed, err := qtx.ConfirmOrderReservations(ctx, db.ConfirmOrderReservationsParams{
+		OrderUuid: history.OrderUuid,
+		TenantID:  tenant,
+	})
+	if err != nil {
+		return db.Order{}, fmt.Errorf("failed to confirm reservations: %w", err)
+	}
+	if confirmed == 0 {
+		return db.Order{}, ErrReservationExpired
+	}
+
+	history.FromStatus = db.OrderStatusPending
+	history.ToStatus = db.OrderStatusNew
+	order, err := updateOrderStatus(ctx, qtx, history)
+	if err != nil {
+		return db.Order{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return db.Order{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return order, nil
+}
+
+// CancelPending releases the reservations and cancels the order. A stock
+// return is scheduled only when SMS has already written off the products.
+func (r *orderRepository) CancelPending(ctx context.Context, history db.AddOrderStatusHistoryParams) (db.Order, error) {
+	tenant, err := tenantID(ctx)
+	if err != nil {
+		return db.Order{}, err
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return db.Order{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+
+	released, err := qtx.ReleaseOrderReservations(ctx, db.ReleaseOrderReservationsParams{
+		OrderUuid: history.OrderUuid,
+		TenantID:  tenant,
+	})
+	if err != nil {
+		return db.Order{}, fmt.Errorf("failed to release reservations: %w", err)
+	}
+
+	history.FromStatus = db.OrderStatusPending
+	history.ToStatus = db.OrderStatusCancelled
+	order, err := updateOrderStatus(ctx, qtx, history)
+	if err != nil {
+		return db.Order{}, err
+	}
+
+	for _, reservation := range released {
+		if !reservation.StockHeld {
+			continue
+		}
+
+		_, err = qtx.CreateStockReturn(ctx, db.CreateStockReturnParams{
+			OrderUuid: order.Uuid,
+			TenantID:  tenant,
+		})
+		if err != nil {
+			return db.Order{}, fmt.Errorf("failed to create stock return: %w", err)
+		}
+		break
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return db.Order{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return order, nil
+}
+
+// ListExpiredPending returns pending orders whose reservations passed the deadline
+func (r *orderRepository) ListExpiredPending(ctx context.Context, limit int32) ([]string, error) {
+	tenant, err := tenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	orderUUIDs, err := r.queries.ListExpiredReservationOrders(ctx, db.ListExpiredReservationOrdersParams{
+		Limit:    limit,
+		TenantID: tenant,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]string, 0, len(orderUUIDs))
+	for _, orderUUID := range orderUUIDs {
+		result = append(result, orderUUID.String())
+	}
+	return result, nil
+}
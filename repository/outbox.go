@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/igntnk/stocky-oms/db"
+	"github.com/igntnk/stocky-oms/models"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"time"
+)
+
+type OutboxRepository interface {
+	// ProcessPending hands up to limit unpublished events to publish in
+	// insertion order. An event is marked published once publish succeeds;
+	// the first failure is recorded on the event and stops the batch.
+	ProcessPending(ctx context.Context, limit int32, publish func(event models.OrderEvent) error) (int, error)
+}
+
+type outboxRepository struct {
+	queries *db.Queries
+	pool    *pgxpool.Pool
+}
+
+func NewOutboxRepository(pool *pgxpool.Pool) OutboxRepository {
+	return &outboxRepository{
+		queries: db.New(pool),
+		pool:    pool,
+	}
+}
+
+func (r *outboxRepository) ProcessPending(
+	ctx context.Context,
+	limit int32,
+	publish func(event models.OrderEvent) error,
+) (int, error) {
+	tenant, err := tenantID(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+
+	pending, err := qtx.ListPendingOutboxEvents(ctx, db.ListPendingOutboxEventsParams{
+		Limit:    limit,
+		TenantID: tenant,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to list pending events: %w", err)
+	}
+
+	var published int
+	var publishErr error
+	for _, row := range pending {
+		var event models.OrderEvent
+		publishErr = json.Unmarshal(row.Payload, &event)
+		if publishErr == nil {
+			publishErr = publish(event)
+		}
+		if publishErr != nil {
+			err = qtx.MarkOutboxEventFailed(ctx, db.MarkOutboxEventFailedParams{
+				ID: row.ID,
+				LastError: pgtype.Text{
+					String: publishErr.Error(),
+					Valid:  true,
+				},
+				TenantID: tenant,
+			})
+			if err != nil {
+				return published, fmt.Errorf("failed to mark event failed: %w", err)
+			}
+			break
+		}
+
+		err = qtx.MarkOutboxEventPublished(ctx, db.MarkOutboxEventPublishedParams{
+			ID:       row.ID,
+			TenantID: tenant,
+		})
+		if err != nil {
+			return published, fmt.Errorf("failed to mark event published: %w", err)
+		}
+		published++
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	if publishErr != nil {
+		return published, fmt.Errorf("failed to publish event: %w", publishErr)
+	}
+	return published, nil
+}
+
+// addOrderEvent stores an order event in the outbox using the caller's transaction
+func addOrderEvent(
+	ctx context.Context,
+	qtx *db.Queries,
+	eventType models.OrderEventType,
+	order db.Order,
+	previousStatus db.OrderStatus,
+) error {
+	eventUUID := uuid.New()
+	payload, err := json.Marshal(models.OrderEvent{
+		ID:             eventUUID.String(),
+		Type:           eventType,
+		TenantID:       order.TenantID,
+		OrderID:        order.Uuid.String(),
+		UserID:         order.UserID,
+		StaffID:        order.StaffID,
+		Status:         models.OrderStatus(order.Status),
+		PreviousStatus: models.OrderStatus(previousStatus),
+		OccurredAt:     time.Now().UTC(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode order event: %w", err)
+	}
+
+	err = qtx.AddOutboxEvent(ctx, db.AddOutboxEventParams{
+		EventUuid: pgtype.UUID{
+			Bytes: eventUUID,
+			Valid: true,
+		},
+		EventType: string(eventType),
+		OrderUuid: order.Uuid,
+		Payload:   payload,
+		TenantID:  order.TenantID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add order event: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/igntnk/stocky-oms/db"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type PaymentRepository interface {
+	// Create records a payment. Returns ErrPaymentExists if the order already
+	// has an authorized or captured payment.
+	Create(ctx context.Context, arg db.CreatePaymentParams) (db.Payment, error)
+	// GetActive returns the authorized or captured payment of the order
+	GetActive(ctx context.Context, orderUUID pgtype.UUID) (db.Payment, error)
+	ListByOrder(ctx context.Context, orderUUID pgtype.UUID) ([]db.Payment, error)
+	Capture(ctx context.Context, arg db.CapturePaymentParams) (db.Payment, error)
+	Void(ctx context.Context, paymentUUID pgtype.UUID) (db.Payment, error)
+	AddRefund(ctx context.Context, arg db.AddPaymentRefundParams) (db.Payment, error)
+}
+
+type paymentRepository struct {
+	queries *db.Queries
+}
+
+func NewPaymentRepository(conn db.DBTX) PaymentRepository {
+	return &paymentRepository{
+		queries: db.New(conn),
+	}
+}
+
+func (r *paymentRepository) Create(ctx context.Context, arg db.CreatePaymentParams) (db.Payment, error) {
+	tenant, err := tenantID(ctx)
+	if err != nil {
+		return db.Payment{}, err
+	}
+	arg.TenantID = tenant
+
+	payment, err := r.queries.CreatePayment(ctx, arg)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode {
+			return db.Payment{}, ErrPaymentExists
+		}
+		return db.Payment{}, err
+	}
+	return payment, nil
+}
+
+func (r *paymentRepository) GetActive(ctx context.Context, orderUUID pgtype.UUID) (db.Payment, error) {
+	tenant, err := tenantID(ctx)
+	if err != nil {
+		return db.Payment{}, err
+	}
+
+	payment, err := r.queries.GetActivePayment(ctx, db.GetActivePaymentParams{
+		OrderUuid: orderUUID,
+		TenantID:  tenant,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.Payment{}, ErrPaymentNotFound
+		}
+		return db.Payment{}, err
+	}
+	return payment, nil
+}
+
+func (r *paymentRepository) ListByOrder(ctx context.Context, orderUUID pgtype.UUID) ([]db.Payment, error) {
+	tenant, err := tenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.queries.ListOrderPayments(ctx, db.ListOrderPaymentsParams{
+		OrderUuid: orderUUID,
+		TenantID:  tenant,
+	})
+}
+
+func (r *paymentRepository) Capture(ctx context.Context, arg db.CapturePaymentParams) (db.Payment, error) {
+	tenant, err := tenantID(ctx)
+	if err != nil {
+		return db.Payment{}, err
+	}
+	arg.TenantID = tenant
+
+	payment, err := r.queries.CapturePayment(ctx, arg)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.Payment{}, ErrPaymentStatusChanged
+		}
+		return db.Payment{}, err
+	}
+	return payment, nil
+}
+
+func (r *paymentRepository) Void(ctx context.Context, paymentUUID pgtype.UUID) (db.Payment, error) {
+	tenant, err := tenantID(ctx)
+	if err != nil {
+		return db.Payment{}, err
+	}
+
+	payment, err := r.queries.VoidPayment(ctx, db.VoidPaymentParams{
+		Uuid:     paymentUUID,
+		TenantID: tenant,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.Payment{}, ErrPaymentStatusChanged
+		}
+		return db.Payment{}, err
+	}
+	return payment, nil
+}
+
+func (r *paymentRepository) AddRefund(ctx context.Context, arg db.AddPaymentRefundParams) (db.Payment, error) {
+	tenant, err := tenantID(ctx)
+	if err != nil {
+		return db.Payment{}, err
+	}
+	arg.TenantID = tenant
+
+	payment, err := r.queries.AddPaymentRefund(ctx, arg)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.Payment{}, ErrPaymentStatusChanged
+		}
+		return db.Payment{}, err
+	}
+	return payment, nil
+}
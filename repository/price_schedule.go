@@ -0,0 +1,260 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/igntnk/stocky-oms/db"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PriceScheduleRepository interface {
+	// Create stores the change and, if revert is set, the change that reverts it
+	Create(ctx context.Context, change db.CreateScheduledPriceChangeParams, revert *db.CreateScheduledPriceChangeParams) (db.ScheduledPriceChange, error)
+	Get(ctx context.Context, uuid string) (db.ScheduledPriceChange, error)
+	List(ctx context.Context, arg db.ListScheduledPriceChangesParams) ([]db.ScheduledPriceChange, error)
+	// Cancel cancels a pending change together with its pending revert
+	Cancel(ctx context.Context, uuid string) (db.ScheduledPriceChange, error)
+	// ApplyNextDue applies the earliest due change in its own transaction.
+	// Returns ErrNoDuePriceChange when nothing is due.
+	ApplyNextDue(ctx context.Context) (db.ScheduledPriceChange, error)
+}
+
+type priceScheduleRepository struct {
+	queries *db.Queries
+	pool    *pgxpool.Pool
+}
+
+func NewPriceScheduleRepository(pool *pgxpool.Pool) PriceScheduleRepository {
+	return &priceScheduleRepository{
+		queries: db.New(pool),
+		pool:    pool,
+	}
+}
+
+func (r *priceScheduleRepository) Create(
+	ctx context.Context,
+	change db.CreateScheduledPriceChangeParams,
+	revert *db.CreateScheduledPriceChangeParams,
+) (db.ScheduledPriceChange, error) {
+	tenant, err := tenantID(ctx)
+	if err != nil {
+		return db.ScheduledPriceChange{}, err
+	}
+	change.TenantID = tenant
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return db.ScheduledPriceChange{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+
+	res, err := qtx.CreateScheduledPriceChange(ctx, change)
+	if err != nil {
+		return db.ScheduledPriceChange{}, err
+	}
+
+	if revert != nil {
+		revert.RevertOf = res.Uuid
+		revert.TenantID = tenant
+		_, err = qtx.CreateScheduledPriceChange(ctx, *revert)
+		if err != nil {
+			return db.ScheduledPriceChange{}, fmt.Errorf("failed to schedule revert: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return db.ScheduledPriceChange{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return res, nil
+}
+
+func (r *priceScheduleRepository) Get(ctx context.Context, changeUuid string) (db.ScheduledPriceChange, error) {
+	var resUuid pgtype.UUID
+	err := resUuid.Scan(changeUuid)
+	if err != nil {
+		return db.ScheduledPriceChange{}, err
+	}
+
+	tenant, err := tenantID(ctx)
+	if err != nil {
+		return db.ScheduledPriceChange{}, err
+	}
+
+	change, err := r.queries.GetScheduledPriceChange(ctx, db.GetScheduledPriceChangeParams{
+		Uuid:     resUuid,
+		TenantID: tenant,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.ScheduledPriceChange{}, ErrPriceChangeNotFound
+		}
+		return db.ScheduledPriceChange{}, err
+	}
+	return change, nil
+}
+
+func (r *priceScheduleRepository) List(ctx context.Context, arg db.ListScheduledPriceChangesParams) ([]db.ScheduledPriceChange, error) {
+	tenant, err := tenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	arg.TenantID = tenant
+
+	return r.queries.ListScheduledPriceChanges(ctx, arg)
+}
+
+func (r *priceScheduleRepository) Cancel(ctx context.Context, changeUuid string) (db.ScheduledPriceChange, error) {
+	var resUuid pgtype.UUID
+	err := resUuid.Scan(changeUuid)
+	if err != nil {
+		return db.ScheduledPriceChange{}, err
+	}
+
+	tenant, err := tenantID(ctx)
+	if err != nil {
+		return db.ScheduledPriceChange{}, err
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return db.ScheduledPriceChange{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+
+	change, err := qtx.CancelScheduledPriceChange(ctx, db.CancelScheduledPriceChangeParams{
+		Uuid:     resUuid,
+		TenantID: tenant,
+	})
+	if err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return db.ScheduledPriceChange{}, err
+		}
+
+		_, err = qtx.GetScheduledPriceChange(ctx, db.GetScheduledPriceChangeParams{
+			Uuid:     resUuid,
+			TenantID: tenant,
+		})
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.ScheduledPriceChange{}, ErrPriceChangeNotFound
+		}
+		if err != nil {
+			return db.ScheduledPriceChange{}, err
+		}
+		return db.ScheduledPriceChange{}, ErrPriceChangeNotPending
+	}
+
+	err = qtx.CancelScheduledReverts(ctx, db.CancelScheduledRevertsParams{
+		RevertOf: change.Uuid,
+		TenantID: tenant,
+	})
+	if err != nil {
+		return db.ScheduledPriceChange{}, fmt.Errorf("failed to cancel revert: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return db.ScheduledPriceChange{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return change, nil
+}
+
+func (r *priceScheduleRepository) ApplyNextDue(ctx context.Context) (db.ScheduledPriceChange, error) {
+	tenant, err := tenantID(ctx)
+	if err != nil {
+		return db.ScheduledPriceChange{}, err
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return db.ScheduledPriceChange{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+
+	change, err := qtx.ClaimDueScheduledPriceChange(ctx, tenant)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.ScheduledPriceChange{}, ErrNoDuePriceChange
+		}
+		return db.ScheduledPriceChange{}, err
+	}
+
+	res, err := applyPriceChange(ctx, qtx, tenant, change)
+	if err != nil {
+		return db.ScheduledPriceChange{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return db.ScheduledPriceChange{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return res, nil
+}
+
+// applyPriceChange updates the product price and remembers the price it
+// replaced for a later revert. Changes that can't be applied are marked failed.
+func applyPriceChange(ctx context.Context, qtx *db.Queries, tenant string, change db.ScheduledPriceChange) (db.ScheduledPriceChange, error) {
+	cost, currency := change.CustomerCost, change.Currency
+	if change.RevertOf.Valid {
+		reverted, err := qtx.GetScheduledPriceChange(ctx, db.GetScheduledPriceChangeParams{
+			Uuid:     change.RevertOf,
+			TenantID: tenant,
+		})
+		if err != nil {
+			return db.ScheduledPriceChange{}, fmt.Errorf("failed to get reverted change: %w", err)
+		}
+		if reverted.Status != db.ScheduledPriceStatusApplied || !reverted.PreviousCost.Valid {
+			return failPriceChange(ctx, qtx, tenant, change, "reverted change was not applied")
+		}
+		cost, currency = reverted.PreviousCost, reverted.PreviousCurrency
+	}
+
+	previous, err := qtx.GetCurrentPrice(ctx, db.GetCurrentPriceParams{
+		ProductUuid: change.ProductUuid,
+		TenantID:    tenant,
+	})
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return db.ScheduledPriceChange{}, fmt.Errorf("failed to get current price: %w", err)
+	}
+
+	reason := change.Reason
+	if reason == "" {
+		reason = "scheduled price change"
+	}
+
+	_, err = updateProduct(ctx, qtx, db.UpdateProductParams{
+		Uuid:         change.ProductUuid,
+		CustomerCost: cost,
+		Currency:     currency,
+	}, change.CreatedBy, reason)
+	if err != nil {
+		if errors.Is(err, ErrProductNotFound) {
+			return failPriceChange(ctx, qtx, tenant, change, err.Error())
+		}
+		return db.ScheduledPriceChange{}, err
+	}
+
+	params := db.MarkScheduledPriceChangeAppliedParams{Uuid: change.Uuid, TenantID: tenant}
+	if previous.Uuid.Valid {
+		params.PreviousCost = previous.CustomerCost
+		params.PreviousCurrency = pgtype.Text{String: previous.Currency, Valid: true}
+	}
+	return qtx.MarkScheduledPriceChangeApplied(ctx, params)
+}
+
+func failPriceChange(ctx context.Context, qtx *db.Queries, tenant string, change db.ScheduledPriceChange, reason string) (db.ScheduledPriceChange, error) {
+	return qtx.MarkScheduledPriceChangeFailed(ctx, db.MarkScheduledPriceChangeFailedParams{
+		Uuid:     change.Uuid,
+		Error:    reason,
+		TenantID: tenant,
+	})
+}
@@ -3,33 +3,74 @@ package repository
 import (
 	"context"
 	"errors"
+	"fmt"
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"time"
 
 	"github.com/igntnk/stocky-oms/db"
 	"github.com/jackc/pgx/v5"
 )
 
 type ProductRepository interface {
-	Create(ctx context.Context, arg db.CreateProductParams) (db.Product, error)
-	Get(ctx context.Context, uuid string) (db.Product, error)
+	Create(ctx context.Context, arg db.CreateProductParams, actor string) (db.Product, error)
+	// Get returns the product with the product code, orders refer to
+	// products by code
+	Get(ctx context.Context, productCode string) (db.Product, error)
+	GetByUUID(ctx context.Context, uuid string) (db.Product, error)
 	List(ctx context.Context, limit, offset int32) ([]db.Product, error)
-	Update(ctx context.Context, arg db.UpdateProductParams) (db.Product, error)
+	// Update records the price in the price history when the price or the currency changes
+	Update(ctx context.Context, arg db.UpdateProductParams, actor, reason string) (db.Product, error)
 	Delete(ctx context.Context, uuid string) error
 	GetByOrder(ctx context.Context, orderUUID string) ([]db.Product, error)
+	GetCurrentPrice(ctx context.Context, productUUID pgtype.UUID) (db.ProductPriceHistory, error)
+	GetPriceAt(ctx context.Context, productUUID pgtype.UUID, at time.Time) (db.ProductPriceHistory, error)
+	ListPriceHistory(ctx context.Context, productUUID pgtype.UUID, limit, offset int32) ([]db.ProductPriceHistory, error)
 }
 
 type productRepository struct {
 	queries *db.Queries
+	pool    *pgxpool.Pool
 }
 
-func NewProductRepository(conn db.DBTX) ProductRepository {
+func NewProductRepository(pool *pgxpool.Pool) ProductRepository {
 	return &productRepository{
-		queries: db.New(conn),
+		queries: db.New(pool),
+		pool:    pool,
 	}
 }
 
-func (r *productRepository) Create(ctx context.Context, arg db.CreateProductParams) (db.Product, error) {
-	return r.queries.CreateProduct(ctx, arg)
+func (r *productRepository) Create(ctx context.Context, arg db.CreateProductParams, actor string) (db.Product, error) {
+	tenant, err := ownerTenantID(ctx)
+	if err != nil {
+		return db.Product{}, err
+	}
+	arg.TenantID = tenant
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return db.Product{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+
+	product, err := qtx.CreateProduct(ctx, arg)
+	if err != nil {
+		return db.Product{}, err
+	}
+
+	err = addPriceHistory(ctx, qtx, product, actor, "initial price")
+	if err != nil {
+		return db.Product{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return db.Product{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return product, nil
 }
 
 func (r *productRepository) Get(ctx context.Context, productUuid string) (db.Product, error) {
@@ -39,7 +80,40 @@ func (r *productRepository) Get(ctx context.Context, productUuid string) (db.Pro
 		return db.Product{}, err
 	}
 
-	product, err := r.queries.GetProduct(ctx, resUuid)
+	tenant, err := tenantID(ctx)
+	if err != nil {
+		return db.Product{}, err
+	}
+
+	product, err := r.queries.GetProduct(ctx, db.GetProductParams{
+		ProductCode: resUuid,
+		TenantID:    tenant,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.Product{}, ErrProductNotFound
+		}
+		return db.Product{}, err
+	}
+	return product, nil
+}
+
+func (r *productRepository) GetByUUID(ctx context.Context, productUuid string) (db.Product, error) {
+	var resUuid pgtype.UUID
+	err := resUuid.Scan(productUuid)
+	if err != nil {
+		return db.Product{}, err
+	}
+
+	tenant, err := tenantID(ctx)
+	if err != nil {
+		return db.Product{}, err
+	}
+
+	product, err := r.queries.GetProductByUuid(ctx, db.GetProductByUuidParams{
+		Uuid:     resUuid,
+		TenantID: tenant,
+	})
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return db.Product{}, ErrProductNotFound
@@ -50,22 +124,113 @@ func (r *productRepository) Get(ctx context.Context, productUuid string) (db.Pro
 }
 
 func (r *productRepository) List(ctx context.Context, limit, offset int32) ([]db.Product, error) {
+	tenant, err := tenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	return r.queries.ListProducts(ctx, db.ListProductsParams{
-		Limit: limit, Offset: offset,
+		Limit: limit, Offset: offset, TenantID: tenant,
 	})
 }
 
-func (r *productRepository) Update(ctx context.Context, arg db.UpdateProductParams) (db.Product, error) {
-	product, err := r.queries.UpdateProduct(ctx, arg)
+func (r *productRepository) Update(ctx context.Context, arg db.UpdateProductParams, actor, reason string) (db.Product, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return db.Product{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+
+	product, err := updateProduct(ctx, qtx, arg, actor, reason)
+	if err != nil {
+		return db.Product{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return db.Product{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return product, nil
+}
+
+// updateProduct updates the product and starts a new price history entry if
+// its price or currency changed
+func updateProduct(ctx context.Context, qtx *db.Queries, arg db.UpdateProductParams, actor, reason string) (db.Product, error) {
+	tenant, err := tenantID(ctx)
+	if err != nil {
+		return db.Product{}, err
+	}
+	arg.TenantID = tenant
+
+	product, err := qtx.UpdateProduct(ctx, arg)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return db.Product{}, ErrProductNotFound
 		}
 		return db.Product{}, err
 	}
+
+	current, err := qtx.GetCurrentPrice(ctx, db.GetCurrentPriceParams{
+		ProductUuid: product.Uuid,
+		TenantID:    product.TenantID,
+	})
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return db.Product{}, fmt.Errorf("failed to get current price: %w", err)
+	}
+	if err == nil && current.Currency == product.Currency && numericEqual(current.CustomerCost, product.CustomerCost) {
+		return product, nil
+	}
+
+	_, err = qtx.ClosePriceHistory(ctx, db.ClosePriceHistoryParams{
+		ProductUuid: product.Uuid,
+		TenantID:    product.TenantID,
+	})
+	if err != nil {
+		return db.Product{}, fmt.Errorf("failed to close price history: %w", err)
+	}
+
+	err = addPriceHistory(ctx, qtx, product, actor, reason)
+	if err != nil {
+		return db.Product{}, err
+	}
+
 	return product, nil
 }
 
+func addPriceHistory(ctx context.Context, qtx *db.Queries, product db.Product, actor, reason string) error {
+	_, err := qtx.AddPriceHistory(ctx, db.AddPriceHistoryParams{
+		Uuid: pgtype.UUID{
+			Bytes: uuid.New(),
+			Valid: true,
+		},
+		ProductUuid:  product.Uuid,
+		CustomerCost: product.CustomerCost,
+		Currency:     product.Currency,
+		ChangedBy:    actor,
+		Reason:       reason,
+		TenantID:     product.TenantID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add price history: %w", err)
+	}
+	return nil
+}
+
+// numericEqual compares two money values, treating unreadable values as different
+func numericEqual(a, b pgtype.Numeric) bool {
+	am, err := NumericToMoney(a)
+	if err != nil {
+		return false
+	}
+	bm, err := NumericToMoney(b)
+	if err != nil {
+		return false
+	}
+	return am.Cmp(bm) == 0
+}
+
 func (r *productRepository) Delete(ctx context.Context, productUuid string) error {
 	var resUuid pgtype.UUID
 	err := resUuid.Scan(productUuid)
@@ -73,7 +238,15 @@ func (r *productRepository) Delete(ctx context.Context, productUuid string) erro
 		return err
 	}
 
-	err = r.queries.DeleteProduct(ctx, resUuid)
+	tenant, err := tenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = r.queries.DeleteProduct(ctx, db.DeleteProductParams{
+		Uuid:     resUuid,
+		TenantID: tenant,
+	})
 	if errors.Is(err, pgx.ErrNoRows) {
 		return ErrProductNotFound
 	}
@@ -87,5 +260,66 @@ func (r *productRepository) GetByOrder(ctx context.Context, orderUUID string) ([
 		return nil, err
 	}
 
-	return r.queries.GetProductsByOrder(ctx, resUuid)
+	tenant, err := tenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.queries.GetProductsByOrder(ctx, db.GetProductsByOrderParams{
+		OrderUuid: resUuid,
+		TenantID:  tenant,
+	})
+}
+
+func (r *productRepository) GetCurrentPrice(ctx context.Context, productUUID pgtype.UUID) (db.ProductPriceHistory, error) {
+	tenant, err := tenantID(ctx)
+	if err != nil {
+		return db.ProductPriceHistory{}, err
+	}
+
+	price, err := r.queries.GetCurrentPrice(ctx, db.GetCurrentPriceParams{
+		ProductUuid: productUUID,
+		TenantID:    tenant,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.ProductPriceHistory{}, ErrPriceNotFound
+		}
+		return db.ProductPriceHistory{}, err
+	}
+	return price, nil
+}
+
+func (r *productRepository) GetPriceAt(ctx context.Context, productUUID pgtype.UUID, at time.Time) (db.ProductPriceHistory, error) {
+	tenant, err := tenantID(ctx)
+	if err != nil {
+		return db.ProductPriceHistory{}, err
+	}
+
+	price, err := r.queries.GetPriceAt(ctx, db.GetPriceAtParams{
+		ProductUuid: productUUID,
+		At:          pgtype.Timestamp{Time: at, Valid: true},
+		TenantID:    tenant,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.ProductPriceHistory{}, ErrPriceNotFound
+		}
+		return db.ProductPriceHistory{}, err
+	}
+	return price, nil
+}
+
+func (r *productRepository) ListPriceHistory(ctx context.Context, productUUID pgtype.UUID, limit, offset int32) ([]db.ProductPriceHistory, error) {
+	tenant, err := tenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.queries.ListPriceHistory(ctx, db.ListPriceHistoryParams{
+		ProductUuid: productUUID,
+		Limit:       limit,
+		Offset:      offset,
+		TenantID:    tenant,
+	})
 }
@@ -0,0 +1,172 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/igntnk/stocky-oms/db"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type PromotionRepository interface {
+	Create(ctx context.Context, arg db.CreatePromotionParams) (db.Promotion, error)
+	Get(ctx context.Context, uuid string) (db.Promotion, error)
+	List(ctx context.Context, limit, offset int32) ([]db.Promotion, error)
+	Update(ctx context.Context, arg db.UpdatePromotionParams) (db.Promotion, error)
+	Delete(ctx context.Context, uuid string) error
+	// ListApplicable returns active automatic promotions and the active coupons with the given codes
+	ListApplicable(ctx context.Context, codes []string) ([]db.Promotion, error)
+}
+
+type promotionRepository struct {
+	queries *db.Queries
+}
+
+func NewPromotionRepository(conn db.DBTX) PromotionRepository {
+	return &promotionRepository{
+		queries: db.New(conn),
+	}
+}
+
+func (r *promotionRepository) Create(ctx context.Context, arg db.CreatePromotionParams) (db.Promotion, error) {
+	tenant, err := ownerTenantID(ctx)
+	if err != nil {
+		return db.Promotion{}, err
+	}
+	arg.TenantID = tenant
+
+	promotion, err := r.queries.CreatePromotion(ctx, arg)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode {
+			return db.Promotion{}, ErrPromotionCodeUsed
+		}
+		return db.Promotion{}, err
+	}
+	return promotion, nil
+}
+
+func (r *promotionRepository) Get(ctx context.Context, promotionUuid string) (db.Promotion, error) {
+	var resUuid pgtype.UUID
+	err := resUuid.Scan(promotionUuid)
+	if err != nil {
+		return db.Promotion{}, err
+	}
+
+	tenant, err := tenantID(ctx)
+	if err != nil {
+		return db.Promotion{}, err
+	}
+
+	promotion, err := r.queries.GetPromotion(ctx, db.GetPromotionParams{
+		Uuid:     resUuid,
+		TenantID: tenant,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.Promotion{}, ErrPromotionNotFound
+		}
+		return db.Promotion{}, err
+	}
+	return promotion, nil
+}
+
+func (r *promotionRepository) List(ctx context.Context, limit, offset int32) ([]db.Promotion, error) {
+	tenant, err := tenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.queries.ListPromotions(ctx, db.ListPromotionsParams{
+		Limit: limit, Offset: offset, TenantID: tenant,
+	})
+}
+
+func (r *promotionRepository) Update(ctx context.Context, arg db.UpdatePromotionParams) (db.Promotion, error) {
+	tenant, err := tenantID(ctx)
+	if err != nil {
+		return db.Promotion{}, err
+	}
+	arg.TenantID = tenant
+
+	promotion, err := r.queries.UpdatePromotion(ctx, arg)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.Promotion{}, ErrPromotionNotFound
+		}
+		return db.Promotion{}, err
+	}
+	return promotion, nil
+}
+
+func (r *promotionRepository) Delete(ctx context.Context, promotionUuid string) error {
+	var resUuid pgtype.UUID
+	err := resUuid.Scan(promotionUuid)
+	if err != nil {
+		return err
+	}
+
+	tenant, err := tenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	rows, err := r.queries.DeletePromotion(ctx, db.DeletePromotionParams{
+		Uuid:     resUuid,
+		TenantID: tenant,
+	})
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrPromotionNotFound
+	}
+	return nil
+}
+
+func (r *promotionRepository) ListApplicable(ctx context.Context, codes []string) ([]db.Promotion, error) {
+	if codes == nil {
+		codes = []string{}
+	}
+
+	tenant, err := tenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.queries.ListApplicablePromotions(ctx, db.ListApplicablePromotionsParams{
+		Codes:    codes,
+		TenantID: tenant,
+	})
+}
+
+// usePromotions counts one use of every promotion applied to the order. A
+// promotion that ran out of uses or expired since it was priced, or that
+// belongs to another shop, fails the order.
+func usePromotions(ctx context.Context, qtx *db.Queries, order db.Order, products []db.AddProductToOrderParams) error {
+	used := make(map[pgtype.UUID]bool)
+	promotions := append([]pgtype.UUID{}, order.PromotionUuids...)
+	for _, product := range products {
+		promotions = append(promotions, product.PromotionUuids...)
+	}
+
+	for _, promotion := range promotions {
+		if used[promotion] {
+			continue
+		}
+		used[promotion] = true
+
+		rows, err := qtx.UsePromotion(ctx, db.UsePromotionParams{
+			Uuid:     promotion,
+			TenantID: order.TenantID,
+		})
+		if err != nil {
+			return err
+		}
+		if rows == 0 {
+			return ErrPromotionUnavailable
+		}
+	}
+	return nil
+}
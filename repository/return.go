@@ -0,0 +1,213 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/igntnk/stocky-oms/db"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ReturnRepository interface {
+	// Create stores a return of a completed order. The lines must fit in
+	// what was not returned yet and the refunds of the order can't exceed
+	// its cost.
+	Create(ctx context.Context, ret db.CreateReturnParams, lines []db.AddReturnLineParams) (db.OrderReturn, error)
+	Get(ctx context.Context, uuid string) (db.OrderReturn, error)
+	List(ctx context.Context, arg db.ListReturnsParams) ([]db.OrderReturn, error)
+	ListLines(ctx context.Context, returnUUID pgtype.UUID) ([]db.ListReturnLinesRow, error)
+	UpdateStatus(ctx context.Context, arg db.UpdateReturnStatusParams) (db.OrderReturn, error)
+	// ClaimStock marks the products of an accepted return as written back to
+	// SMS. Returns ErrReturnStockClaimed if that was already done.
+	ClaimStock(ctx context.Context, returnUUID pgtype.UUID) (db.OrderReturn, error)
+	// ReleaseStock undoes ClaimStock after SMS failed
+	ReleaseStock(ctx context.Context, returnUUID pgtype.UUID) error
+}
+
+type returnRepository struct {
+	queries *db.Queries
+	pool    *pgxpool.Pool
+}
+
+func NewReturnRepository(pool *pgxpool.Pool) ReturnRepository {
+	return &returnRepository{
+		queries: db.New(pool),
+		pool:    pool,
+	}
+}
+
+func (r *returnRepository) Create(
+	ctx context.Context,
+	ret db.CreateReturnParams,
+	lines []db.AddReturnLineParams,
+) (db.OrderReturn, error) {
+	tenant, err := tenantID(ctx)
+	if err != nil {
+		return db.OrderReturn{}, err
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return db.OrderReturn{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+
+	// the lock keeps concurrent returns from taking back the same products
+	order, err := qtx.LockOrder(ctx, db.LockOrderParams{
+		Uuid:     ret.OrderUuid,
+		TenantID: tenant,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.OrderReturn{}, ErrOrderNotFound
+		}
+		return db.OrderReturn{}, err
+	}
+
+	if order.Status != db.OrderStatusCompleted {
+		return db.OrderReturn{}, ErrOrderNotReturnable
+	}
+
+	products, err := qtx.GetOrderProducts(ctx, db.GetOrderProductsParams{
+		OrderUuid: order.Uuid,
+		TenantID:  order.TenantID,
+	})
+	if err != nil {
+		return db.OrderReturn{}, fmt.Errorf("failed to get order products: %w", err)
+	}
+
+	amounts, err := qtx.ListReturnedAmounts(ctx, order.Uuid)
+	if err != nil {
+		return db.OrderReturn{}, fmt.Errorf("failed to get returned amounts: %w", err)
+	}
+
+	returned := make(map[pgtype.UUID]int32, len(amounts))
+	for _, a := range amounts {
+		returned[a.ProductUuid] = a.Amount
+	}
+
+	for _, line := range lines {
+		product, ok := findOrderProduct(products, line.ProductCode)
+		if !ok {
+			return db.OrderReturn{}, ErrReturnProductNotInOrder
+		}
+
+		returned[product.ProductUuid] += line.Amount
+		if returned[product.ProductUuid] > product.Amount {
+			return db.OrderReturn{}, ErrReturnExceedsOrder
+		}
+	}
+
+	refundedNum, err := qtx.SumOrderRefunds(ctx, order.Uuid)
+	if err != nil {
+		return db.OrderReturn{}, fmt.Errorf("failed to sum order refunds: %w", err)
+	}
+
+	refunded, err := NumericToMoney(refundedNum)
+	if err != nil {
+		return db.OrderReturn{}, fmt.Errorf("failed to convert order refunds: %w", err)
+	}
+
+	refund, err := NumericToMoney(ret.RefundAmount)
+	if err != nil {
+		return db.OrderReturn{}, fmt.Errorf("failed to convert refund: %w", err)
+	}
+
+	orCost, err := NumericToMoney(order.OrderCost)
+	if err != nil {
+		return db.OrderReturn{}, fmt.Errorf("failed to convert order cost: %w", err)
+	}
+
+	if refunded.Add(refund).Cmp(orCost) > 0 {
+		return db.OrderReturn{}, ErrReturnExceedsOrder
+	}
+
+	res, err := qtx.CreateReturn(ctx, ret)
+	if err != nil {
+		return db.OrderReturn{}, fmt.Errorf("failed to create return: %w", err)
+	}
+
+	for _, line := range lines {
+		line.ReturnUuid = res.Uuid
+		line.OrderUuid = order.Uuid
+		_, err = qtx.AddReturnLine(ctx, line)
+		if err != nil {
+			return db.OrderReturn{}, fmt.Errorf("failed to add return line: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return db.OrderReturn{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return res, nil
+}
+
+func (r *returnRepository) Get(ctx context.Context, returnUuid string) (db.OrderReturn, error) {
+	var resUuid pgtype.UUID
+	err := resUuid.Scan(returnUuid)
+	if err != nil {
+		return db.OrderReturn{}, err
+	}
+
+	tenant, err := tenantID(ctx)
+	if err != nil {
+		return db.OrderReturn{}, err
+	}
+
+	ret, err := r.queries.GetReturn(ctx, db.GetReturnParams{
+		Uuid:     resUuid,
+		TenantID: tenant,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.OrderReturn{}, ErrReturnNotFound
+		}
+		return db.OrderReturn{}, err
+	}
+	return ret, nil
+}
+
+func (r *returnRepository) List(ctx context.Context, arg db.ListReturnsParams) ([]db.OrderReturn, error) {
+	tenant, err := tenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	arg.TenantID = tenant
+
+	return r.queries.ListReturns(ctx, arg)
+}
+
+func (r *returnRepository) ListLines(ctx context.Context, returnUUID pgtype.UUID) ([]db.ListReturnLinesRow, error) {
+	return r.queries.ListReturnLines(ctx, returnUUID)
+}
+
+func (r *returnRepository) UpdateStatus(ctx context.Context, arg db.UpdateReturnStatusParams) (db.OrderReturn, error) {
+	ret, err := r.queries.UpdateReturnStatus(ctx, arg)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.OrderReturn{}, ErrReturnStatusChanged
+		}
+		return db.OrderReturn{}, err
+	}
+	return ret, nil
+}
+
+func (r *returnRepository) ClaimStock(ctx context.Context, returnUUID pgtype.UUID) (db.OrderReturn, error) {
+	ret, err := r.queries.ClaimReturnStock(ctx, returnUUID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.OrderReturn{}, ErrReturnStockClaimed
+		}
+		return db.OrderReturn{}, err
+	}
+	return ret, nil
+}
+
+func (r *returnRepository) ReleaseStock(ctx context.Context, returnUUID pgtype.UUID) error {
+	return r.queries.ReleaseReturnStock(ctx, returnUUID)
+}
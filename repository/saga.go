@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/igntnk/stocky-oms/db"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"time"
+)
+
+type SagaRepository interface {
+	Create(ctx context.Context, arg db.CreateSagaParams) (db.OrderSaga, error)
+	SetStep(ctx context.Context, sagaUUID string, step db.SagaStep, lastError string) error
+	// ClaimUnfinished claims the oldest saga that stopped before persisting
+	// the order or compensating and was not touched for at least olderThan.
+	// Returns ErrNoUnfinishedSaga when there is none.
+	ClaimUnfinished(ctx context.Context, olderThan time.Duration) (db.OrderSaga, error)
+}
+
+type sagaRepository struct {
+	queries *db.Queries
+}
+
+func NewSagaRepository(conn db.DBTX) SagaRepository {
+	return &sagaRepository{
+		queries: db.New(conn),
+	}
+}
+
+func (r *sagaRepository) Create(ctx context.Context, arg db.CreateSagaParams) (db.OrderSaga, error) {
+	tenant, err := ownerTenantID(ctx)
+	if err != nil {
+		return db.OrderSaga{}, err
+	}
+	arg.TenantID = tenant
+
+	return r.queries.CreateSaga(ctx, arg)
+}
+
+func (r *sagaRepository) SetStep(ctx context.Context, sagaUUID string, step db.SagaStep, lastError string) error {
+	var resUuid pgtype.UUID
+	err := resUuid.Scan(sagaUUID)
+	if err != nil {
+		return err
+	}
+
+	tenant, err := tenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	return r.queries.UpdateSagaStep(ctx, db.UpdateSagaStepParams{
+		Uuid: resUuid,
+		Step: step,
+		LastError: pgtype.Text{
+			String: lastError,
+			Valid:  lastError != "",
+		},
+		TenantID: tenant,
+	})
+}
+
+// ClaimUnfinished touches the claimed saga, so concurrent recovery runs and
+// other replicas don't pick it up before olderThan passes again
+func (r *sagaRepository) ClaimUnfinished(ctx context.Context, olderThan time.Duration) (db.OrderSaga, error) {
+	tenant, err := tenantID(ctx)
+	if err != nil {
+		return db.OrderSaga{}, err
+	}
+
+	saga, err := r.queries.ClaimUnfinishedSaga(ctx, db.ClaimUnfinishedSagaParams{
+		AgeSeconds: olderThan.Seconds(),
+		TenantID:   tenant,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.OrderSaga{}, ErrNoUnfinishedSaga
+		}
+		return db.OrderSaga{}, err
+	}
+	return saga, nil
+}
@@ -0,0 +1,316 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/igntnk/stocky-oms/db"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ShipmentRepository interface {
+	// Create stores the shipment of an order that is being processed. The
+	// lines must fit in what is left of the order lines after the shipments
+	// that are not cancelled.
+	Create(ctx context.Context, shipment db.CreateShipmentParams, lines []db.AddShipmentLineParams) (db.Shipment, error)
+	Get(ctx context.Context, uuid string) (db.Shipment, error)
+	ListByOrder(ctx context.Context, orderUUID string) ([]db.Shipment, error)
+	ListLines(ctx context.Context, shipmentUUID pgtype.UUID) ([]db.ListShipmentLinesRow, error)
+	// UpdateStatus moves the shipment from arg.FromStatus to arg.Status and
+	// moves the order to the status its shipments add up to. complete is
+	// called before the commit when the order becomes completed, an error
+	// from it rolls the update back.
+	UpdateStatus(
+		ctx context.Context,
+		arg db.UpdateShipmentStatusParams,
+		actor, reason string,
+		complete func(ctx context.Context, order db.Order) error,
+	) (db.Shipment, error)
+}
+
+type shipmentRepository struct {
+	queries *db.Queries
+	pool    *pgxpool.Pool
+}
+
+func NewShipmentRepository(pool *pgxpool.Pool) ShipmentRepository {
+	return &shipmentRepository{
+		queries: db.New(pool),
+		pool:    pool,
+	}
+}
+
+func (r *shipmentRepository) Create(
+	ctx context.Context,
+	shipment db.CreateShipmentParams,
+	lines []db.AddShipmentLineParams,
+) (db.Shipment, error) {
+	tenant, err := tenantID(ctx)
+	if err != nil {
+		return db.Shipment{}, err
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return db.Shipment{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+
+	// the lock keeps concurrent shipments from allocating the same products
+	order, err := qtx.LockOrder(ctx, db.LockOrderParams{
+		Uuid:     shipment.OrderUuid,
+		TenantID: tenant,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.Shipment{}, ErrOrderNotFound
+		}
+		return db.Shipment{}, err
+	}
+
+	if order.Status != db.OrderStatusProcessing && order.Status != db.OrderStatusPartiallyShipped {
+		return db.Shipment{}, ErrOrderNotShippable
+	}
+
+	products, err := qtx.GetOrderProducts(ctx, db.GetOrderProductsParams{
+		OrderUuid: order.Uuid,
+		TenantID:  order.TenantID,
+	})
+	if err != nil {
+		return db.Shipment{}, fmt.Errorf("failed to get order products: %w", err)
+	}
+
+	amounts, err := qtx.ListShipmentAmounts(ctx, order.Uuid)
+	if err != nil {
+		return db.Shipment{}, fmt.Errorf("failed to get shipped amounts: %w", err)
+	}
+
+	allocated := make(map[pgtype.UUID]int32, len(amounts))
+	for _, a := range amounts {
+		allocated[a.ProductUuid] = a.Allocated
+	}
+
+	for _, line := range lines {
+		product, ok := findOrderProduct(products, line.ProductCode)
+		if !ok {
+			return db.Shipment{}, ErrShipmentProductNotInOrder
+		}
+
+		allocated[product.ProductUuid] += line.Amount
+		if allocated[product.ProductUuid] > product.Amount {
+			return db.Shipment{}, ErrShipmentExceedsOrder
+		}
+	}
+
+	res, err := qtx.CreateShipment(ctx, shipment)
+	if err != nil {
+		return db.Shipment{}, fmt.Errorf("failed to create shipment: %w", err)
+	}
+
+	for _, line := range lines {
+		line.ShipmentUuid = res.Uuid
+		line.OrderUuid = order.Uuid
+		_, err = qtx.AddShipmentLine(ctx, line)
+		if err != nil {
+			return db.Shipment{}, fmt.Errorf("failed to add shipment line: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return db.Shipment{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return res, nil
+}
+
+func (r *shipmentRepository) Get(ctx context.Context, shipmentUuid string) (db.Shipment, error) {
+	var resUuid pgtype.UUID
+	err := resUuid.Scan(shipmentUuid)
+	if err != nil {
+		return db.Shipment{}, err
+	}
+
+	tenant, err := tenantID(ctx)
+	if err != nil {
+		return db.Shipment{}, err
+	}
+
+	shipment, err := r.queries.GetShipment(ctx, db.GetShipmentParams{
+		Uuid:     resUuid,
+		TenantID: tenant,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.Shipment{}, ErrShipmentNotFound
+		}
+		return db.Shipment{}, err
+	}
+	return shipment, nil
+}
+
+func (r *shipmentRepository) ListByOrder(ctx context.Context, orderUUID string) ([]db.Shipment, error) {
+	var resUuid pgtype.UUID
+	err := resUuid.Scan(orderUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.queries.ListOrderShipments(ctx, resUuid)
+}
+
+func (r *shipmentRepository) ListLines(ctx context.Context, shipmentUUID pgtype.UUID) ([]db.ListShipmentLinesRow, error) {
+	return r.queries.ListShipmentLines(ctx, shipmentUUID)
+}
+
+func (r *shipmentRepository) UpdateStatus(
+	ctx context.Context,
+	arg db.UpdateShipmentStatusParams,
+	actor, reason string,
+	complete func(ctx context.Context, order db.Order) error,
+) (db.Shipment, error) {
+	tenant, err := tenantID(ctx)
+	if err != nil {
+		return db.Shipment{}, err
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return db.Shipment{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+
+	shipment, err := qtx.GetShipment(ctx, db.GetShipmentParams{
+		Uuid:     arg.Uuid,
+		TenantID: tenant,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.Shipment{}, ErrShipmentNotFound
+		}
+		return db.Shipment{}, err
+	}
+
+	// shipments of orders of other shops are not found either
+	order, err := qtx.LockOrder(ctx, db.LockOrderParams{
+		Uuid:     shipment.OrderUuid,
+		TenantID: tenant,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.Shipment{}, ErrShipmentNotFound
+		}
+		return db.Shipment{}, fmt.Errorf("failed to lock order: %w", err)
+	}
+
+	shipment, err = qtx.UpdateShipmentStatus(ctx, arg)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.Shipment{}, ErrShipmentStatusChanged
+		}
+		return db.Shipment{}, err
+	}
+
+	status, err := shippingOrderStatus(ctx, qtx, order)
+	if err != nil {
+		return db.Shipment{}, err
+	}
+
+	if status != order.Status {
+		_, err = updateOrderStatus(ctx, qtx, db.AddOrderStatusHistoryParams{
+			Uuid: pgtype.UUID{
+				Bytes: uuid.New(),
+				Valid: true,
+			},
+			OrderUuid:  order.Uuid,
+			FromStatus: order.Status,
+			ToStatus:   status,
+			Actor:      actor,
+			Reason: pgtype.Text{
+				String: reason,
+				Valid:  reason != "",
+			},
+		})
+		if err != nil {
+			return db.Shipment{}, err
+		}
+
+		if status == db.OrderStatusCompleted {
+			err = complete(ctx, order)
+			if err != nil {
+				return db.Shipment{}, err
+			}
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return db.Shipment{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return shipment, nil
+}
+
+// shippingOrderStatus derives the order status from its shipments. An order
+// is completed once every unit is delivered, shipped once every unit is on
+// its way and partially shipped while only some are. Orders with nothing
+// shipped and orders outside shipping keep their status.
+func shippingOrderStatus(ctx context.Context, qtx *db.Queries, order db.Order) (db.OrderStatus, error) {
+	switch order.Status {
+	case db.OrderStatusProcessing, db.OrderStatusPartiallyShipped, db.OrderStatusShipped:
+	default:
+		return order.Status, nil
+	}
+
+	products, err := qtx.GetOrderProducts(ctx, db.GetOrderProductsParams{
+		OrderUuid: order.Uuid,
+		TenantID:  order.TenantID,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get order products: %w", err)
+	}
+
+	amounts, err := qtx.ListShipmentAmounts(ctx, order.Uuid)
+	if err != nil {
+		return "", fmt.Errorf("failed to get shipped amounts: %w", err)
+	}
+
+	shipped := make(map[pgtype.UUID]db.ListShipmentAmountsRow, len(amounts))
+	anyShipped := false
+	for _, a := range amounts {
+		shipped[a.ProductUuid] = a
+		anyShipped = anyShipped || a.Shipped > 0
+	}
+
+	allShipped, allDelivered := true, true
+	for _, p := range products {
+		a := shipped[p.ProductUuid]
+		allShipped = allShipped && a.Shipped >= p.Amount
+		allDelivered = allDelivered && a.Delivered >= p.Amount
+	}
+
+	switch {
+	case allDelivered:
+		return db.OrderStatusCompleted, nil
+	case allShipped:
+		return db.OrderStatusShipped, nil
+	case anyShipped:
+		return db.OrderStatusPartiallyShipped, nil
+	default:
+		return order.Status, nil
+	}
+}
+
+func findOrderProduct(products []db.GetOrderProductsRow, productCode pgtype.UUID) (db.GetOrderProductsRow, bool) {
+	for _, p := range products {
+		if p.ProductCode == productCode {
+			return p, true
+		}
+	}
+	return db.GetOrderProductsRow{}, false
+}
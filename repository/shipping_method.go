@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/igntnk/stocky-oms/db"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type ShippingMethodRepository interface {
+	Create(ctx context.Context, arg db.CreateShippingMethodParams) (db.ShippingMethod, error)
+	Get(ctx context.Context, uuid string) (db.ShippingMethod, error)
+	// GetByCode returns the method of the caller shop with the code
+	GetByCode(ctx context.Context, code string) (db.ShippingMethod, error)
+	List(ctx context.Context, limit, offset int32, activeOnly bool) ([]db.ShippingMethod, error)
+	Update(ctx context.Context, arg db.UpdateShippingMethodParams) (db.ShippingMethod, error)
+	// Delete removes a method no order was shipped with, used methods can
+	// only be deactivated
+	Delete(ctx context.Context, uuid string) error
+}
+
+type shippingMethodRepository struct {
+	queries *db.Queries
+}
+
+func NewShippingMethodRepository(conn db.DBTX) ShippingMethodRepository {
+	return &shippingMethodRepository{
+		queries: db.New(conn),
+	}
+}
+
+func (r *shippingMethodRepository) Create(ctx context.Context, arg db.CreateShippingMethodParams) (db.ShippingMethod, error) {
+	tenant, err := ownerTenantID(ctx)
+	if err != nil {
+		return db.ShippingMethod{}, err
+	}
+	arg.TenantID = tenant
+
+	method, err := r.queries.CreateShippingMethod(ctx, arg)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode {
+			return db.ShippingMethod{}, ErrShippingMethodExists
+		}
+		return db.ShippingMethod{}, err
+	}
+	return method, nil
+}
+
+func (r *shippingMethodRepository) Get(ctx context.Context, methodUuid string) (db.ShippingMethod, error) {
+	var resUuid pgtype.UUID
+	err := resUuid.Scan(methodUuid)
+	if err != nil {
+		return db.ShippingMethod{}, err
+	}
+
+	tenant, err := tenantID(ctx)
+	if err != nil {
+		return db.ShippingMethod{}, err
+	}
+
+	method, err := r.queries.GetShippingMethod(ctx, db.GetShippingMethodParams{
+		Uuid:     resUuid,
+		TenantID: tenant,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.ShippingMethod{}, ErrShippingMethodNotFound
+		}
+		return db.ShippingMethod{}, err
+	}
+	return method, nil
+}
+
+func (r *shippingMethodRepository) GetByCode(ctx context.Context, code string) (db.ShippingMethod, error) {
+	tenant, err := tenantID(ctx)
+	if err != nil {
+		return db.ShippingMethod{}, err
+	}
+
+	method, err := r.queries.GetShippingMethodByCode(ctx, db.GetShippingMethodByCodeParams{
+		Code:     code,
+		TenantID: tenant,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.ShippingMethod{}, ErrShippingMethodNotFound
+		}
+		return db.ShippingMethod{}, err
+	}
+	return method, nil
+}
+
+func (r *shippingMethodRepository) List(ctx context.Context, limit, offset int32, activeOnly bool) ([]db.ShippingMethod, error) {
+	tenant, err := tenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.queries.ListShippingMethods(ctx, db.ListShippingMethodsParams{
+		ActiveOnly: activeOnly,
+		TenantID:   tenant,
+		Limit:      limit,
+		Offset:     offset,
+	})
+}
+
+func (r *shippingMethodRepository) Update(ctx context.Context, arg db.UpdateShippingMethodParams) (db.ShippingMethod, error) {
+	tenant, err := tenantID(ctx)
+	if err != nil {
+		return db.ShippingMethod{}, err
+	}
+	arg.TenantID = tenant
+
+	method, err := r.queries.UpdateShippingMethod(ctx, arg)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.ShippingMethod{}, ErrShippingMethodNotFound
+		}
+		return db.ShippingMethod{}, err
+	}
+	return method, nil
+}
+
+func (r *shippingMethodRepository) Delete(ctx context.Context, methodUuid string) error {
+	var resUuid pgtype.UUID
+	err := resUuid.Scan(methodUuid)
+	if err != nil {
+		return err
+	}
+
+	tenant, err := tenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	rows, err := r.queries.DeleteShippingMethod(ctx, db.DeleteShippingMethodParams{
+		Uuid:     resUuid,
+		TenantID: tenant,
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolationCode {
+			return ErrShippingMethodInUse
+		}
+		return err
+	}
+	if rows == 0 {
+		return ErrShippingMethodNotFound
+	}
+	return nil
+}
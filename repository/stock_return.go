@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/igntnk/stocky-oms/db"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"time"
+)
+
+type StockReturnRepository interface {
+	Get(ctx context.Context, orderUUID string) (db.OrderStockReturn, error)
+	Claim(ctx context.Context, orderUUID string, lease time.Duration) (db.OrderStockReturn, error)
+	Complete(ctx context.Context, orderUUID string) error
+	Fail(ctx context.Context, orderUUID string, reason string) error
+	List(ctx context.Context, status db.StockReturnStatus, limit, offset int32) ([]db.OrderStockReturn, error)
+}
+
+type stockReturnRepository struct {
+	queries *db.Queries
+}
+
+func NewStockReturnRepository(conn db.DBTX) StockReturnRepository {
+	return &stockReturnRepository{
+		queries: db.New(conn),
+	}
+}
+
+func (r *stockReturnRepository) Get(ctx context.Context, orderUUID string) (db.OrderStockReturn, error) {
+	var resUuid pgtype.UUID
+	err := resUuid.Scan(orderUUID)
+	if err != nil {
+		return db.OrderStockReturn{}, err
+	}
+
+	tenant, err := tenantID(ctx)
+	if err != nil {
+		return db.OrderStockReturn{}, err
+	}
+
+	return r.queries.GetStockReturn(ctx, db.GetStockReturnParams{
+		OrderUuid: resUuid,
+		TenantID:  tenant,
+	})
+}
+
+// Claim marks a pending or failed stock return as in progress. A return that
+// stayed in progress for longer than lease is claimed again. Returns
+// ErrStockReturnClaimed when another caller is returning the stock,
+// ErrStockReturnDone when it was returned and ErrNoStockReturn when the
+// order has nothing to return.
+func (r *stockReturnRepository) Claim(ctx context.Context, orderUUID string, lease time.Duration) (db.OrderStockReturn, error) {
+	var resUuid pgtype.UUID
+	err := resUuid.Scan(orderUUID)
+	if err != nil {
+		return db.OrderStockReturn{}, err
+	}
+
+	tenant, err := tenantID(ctx)
+	if err != nil {
+		return db.OrderStockReturn{}, err
+	}
+
+	stockReturn, err := r.queries.ClaimStockReturn(ctx, db.ClaimStockReturnParams{
+		OrderUuid:    resUuid,
+		LeaseSeconds: lease.Seconds(),
+		TenantID:     tenant,
+	})
+	if err == nil {
+		return stockReturn, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return db.OrderStockReturn{}, err
+	}
+
+	stockReturn, err = r.queries.GetStockReturn(ctx, db.GetStockReturnParams{
+		OrderUuid: resUuid,
+		TenantID:  tenant,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.OrderStockReturn{}, ErrNoStockReturn
+		}
+		return db.OrderStockReturn{}, err
+	}
+	if stockReturn.Status == db.StockReturnStatusCompleted {
+		return db.OrderStockReturn{}, ErrStockReturnDone
+	}
+	return db.OrderStockReturn{}, ErrStockReturnClaimed
+}
+
+func (r *stockReturnRepository) Complete(ctx context.Context, orderUUID string) error {
+	var resUuid pgtype.UUID
+	err := resUuid.Scan(orderUUID)
+	if err != nil {
+		return err
+	}
+
+	tenant, err := tenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	return r.queries.CompleteStockReturn(ctx, db.CompleteStockReturnParams{
+		OrderUuid: resUuid,
+		TenantID:  tenant,
+	})
+}
+
+func (r *stockReturnRepository) Fail(ctx context.Context, orderUUID string, reason string) error {
+	var resUuid pgtype.UUID
+	err := resUuid.Scan(orderUUID)
+	if err != nil {
+		return err
+	}
+
+	tenant, err := tenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	return r.queries.FailStockReturn(ctx, db.FailStockReturnParams{
+		OrderUuid: resUuid,
+		LastError: pgtype.Text{
+			String: reason,
+			Valid:  true,
+		},
+		TenantID: tenant,
+	})
+}
+
+func (r *stockReturnRepository) List(
+	ctx context.Context,
+	status db.StockReturnStatus,
+	limit, offset int32,
+) ([]db.OrderStockReturn, error) {
+	tenant, err := tenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.queries.ListStockReturns(ctx, db.ListStockReturnsParams{
+		Status:   status,
+		Limit:    limit,
+		Offset:   offset,
+		TenantID: tenant,
+	})
+}
@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/igntnk/stocky-oms/db"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type TaxRuleRepository interface {
+	Create(ctx context.Context, arg db.CreateTaxRuleParams) (db.TaxRule, error)
+	Get(ctx context.Context, uuid string) (db.TaxRule, error)
+	List(ctx context.Context, limit, offset int32) ([]db.TaxRule, error)
+	Update(ctx context.Context, arg db.UpdateTaxRuleParams) (db.TaxRule, error)
+	Delete(ctx context.Context, uuid string) error
+	// Find returns the rule of the tax class for the region, falling back to
+	// the class rule without a region
+	Find(ctx context.Context, taxClass, region string) (db.TaxRule, error)
+}
+
+type taxRuleRepository struct {
+	queries *db.Queries
+}
+
+func NewTaxRuleRepository(conn db.DBTX) TaxRuleRepository {
+	return &taxRuleRepository{
+		queries: db.New(conn),
+	}
+}
+
+func (r *taxRuleRepository) Create(ctx context.Context, arg db.CreateTaxRuleParams) (db.TaxRule, error) {
+	tenant, err := ownerTenantID(ctx)
+	if err != nil {
+		return db.TaxRule{}, err
+	}
+	arg.TenantID = tenant
+
+	rule, err := r.queries.CreateTaxRule(ctx, arg)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode {
+			return db.TaxRule{}, ErrTaxRuleExists
+		}
+		return db.TaxRule{}, err
+	}
+	return rule, nil
+}
+
+func (r *taxRuleRepository) Get(ctx context.Context, ruleUuid string) (db.TaxRule, error) {
+	var resUuid pgtype.UUID
+	err := resUuid.Scan(ruleUuid)
+	if err != nil {
+		return db.TaxRule{}, err
+	}
+
+	tenant, err := tenantID(ctx)
+	if err != nil {
+		return db.TaxRule{}, err
+	}
+
+	rule, err := r.queries.GetTaxRule(ctx, db.GetTaxRuleParams{
+		Uuid:     resUuid,
+		TenantID: tenant,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.TaxRule{}, ErrTaxRuleNotFound
+		}
+		return db.TaxRule{}, err
+	}
+	return rule, nil
+}
+
+func (r *taxRuleRepository) List(ctx context.Context, limit, offset int32) ([]db.TaxRule, error) {
+	tenant, err := tenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.queries.ListTaxRules(ctx, db.ListTaxRulesParams{
+		Limit: limit, Offset: offset, TenantID: tenant,
+	})
+}
+
+func (r *taxRuleRepository) Update(ctx context.Context, arg db.UpdateTaxRuleParams) (db.TaxRule, error) {
+	tenant, err := tenantID(ctx)
+	if err != nil {
+		return db.TaxRule{}, err
+	}
+	arg.TenantID = tenant
+
+	rule, err := r.queries.UpdateTaxRule(ctx, arg)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.TaxRule{}, ErrTaxRuleNotFound
+		}
+		return db.TaxRule{}, err
+	}
+	return rule, nil
+}
+
+func (r *taxRuleRepository) Delete(ctx context.Context, ruleUuid string) error {
+	var resUuid pgtype.UUID
+	err := resUuid.Scan(ruleUuid)
+	if err != nil {
+		return err
+	}
+
+	tenant, err := tenantID(ctx)
+	if err != nil {
+		return err
+	}
+
+	rows, err := r.queries.DeleteTaxRule(ctx, db.DeleteTaxRuleParams{
+		Uuid:     resUuid,
+		TenantID: tenant,
+	})
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrTaxRuleNotFound
+	}
+	return nil
+}
+
+func (r *taxRuleRepository) Find(ctx context.Context, taxClass, region string) (db.TaxRule, error) {
+	tenant, err := tenantID(ctx)
+	if err != nil {
+		return db.TaxRule{}, err
+	}
+
+	rule, err := r.queries.FindTaxRule(ctx, db.FindTaxRuleParams{
+		TaxClass: taxClass,
+		Region:   region,
+		TenantID: tenant,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return db.TaxRule{}, ErrTaxRuleNotFound
+		}
+		return db.TaxRule{}, err
+	}
+	return rule, nil
+}
@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+	"github.com/igntnk/stocky-oms/auth"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ScopeTenant makes the pool set app.tenant_id on every connection it hands
+// out to the tenant of the acquiring context. The row level security policies
+// read it, connections acquired without a tenant see no shop at all.
+func ScopeTenant(config *pgxpool.Config) {
+	config.BeforeAcquire = func(ctx context.Context, conn *pgx.Conn) bool {
+		tenant, _ := auth.TenantFromContext(ctx)
+		_, err := conn.Exec(ctx, "SELECT set_config('app.tenant_id', $1, false)", tenant)
+		return err == nil
+	}
+}
+
+// tenantID returns the tenant the caller acts for, queries are scoped by it
+func tenantID(ctx context.Context) (string, error) {
+	tenant, ok := auth.TenantFromContext(ctx)
+	if !ok || tenant == "" {
+		return "", ErrTenantRequired
+	}
+	return tenant, nil
+}
+
+// ownerTenantID is tenantID for new rows, they must belong to a single shop
+func ownerTenantID(ctx context.Context) (string, error) {
+	tenant, err := tenantID(ctx)
+	if err != nil {
+		return "", err
+	}
+	if tenant == auth.AllTenants {
+		return "", ErrTenantRequired
+	}
+	return tenant, nil
+}
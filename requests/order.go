@@ -2,6 +2,9 @@ package requests
 
 type CreateOrder struct {
 	Comment  string               `json:"comment"`
+	Currency string               `json:"currency"`
+	Coupons  []string             `json:"coupons"`
+	Region   string               `json:"region"`
 	Products []CreateOrderProduct `json:"products"`
 }
 
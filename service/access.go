@@ -0,0 +1,43 @@
+package service
+
+import (
+	"context"
+	"github.com/igntnk/stocky-oms/auth"
+)
+
+// customerID returns the user id a customer caller is limited to. Staff, admins
+// and calls from inside the service such as workers are not limited.
+func customerID(ctx context.Context) (string, bool) {
+	identity, ok := auth.FromContext(ctx)
+	if !ok || identity.Role != string(auth.RoleCustomer) {
+		return "", false
+	}
+	return identity.UserID, true
+}
+
+// callerActor returns the subject of the authenticated caller audit records
+// are attributed to, or fallback for calls from inside the service such as
+// workers
+func callerActor(ctx context.Context, fallback string) string {
+	identity, ok := auth.FromContext(ctx)
+	if !ok || identity.Subject == "" {
+		return fallback
+	}
+	return identity.Subject
+}
+
+// checkOrderAccess returns ErrPermissionDenied if the caller is a customer
+// other than the one the order belongs to
+func checkOrderAccess(ctx context.Context, orderUserID string) error {
+	userID, limited := customerID(ctx)
+	if limited && userID != orderUserID {
+		return ErrPermissionDenied
+	}
+	return nil
+}
+
+// checkCustomerAccess returns ErrPermissionDenied if the caller is a customer
+// other than userID
+func checkCustomerAccess(ctx context.Context, userID string) error {
+	return checkOrderAccess(ctx, userID)
+}
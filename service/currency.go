@@ -0,0 +1,64 @@
+package service
+
+import (
+	"fmt"
+	"github.com/igntnk/stocky-oms/models"
+	"math/big"
+	"strings"
+)
+
+type CurrencyConverter interface {
+	// Default is the currency used when a request does not set one
+	Default() string
+	// Convert returns amount in the to currency. The reverse of a configured
+	// rate is used when only the opposite pair is known.
+	Convert(amount models.Money, from, to string) (models.Money, error)
+}
+
+type currencyConverter struct {
+	defaultCurrency string
+	rates           map[string]*big.Rat
+}
+
+// NewCurrencyConverter builds a converter from rates keyed by currency pair,
+// e.g. "EUR_RUB": "98.75" converts one euro into 98.75 roubles
+func NewCurrencyConverter(defaultCurrency string, rates map[string]string) (CurrencyConverter, error) {
+	c := &currencyConverter{
+		defaultCurrency: strings.ToUpper(defaultCurrency),
+		rates:           make(map[string]*big.Rat, len(rates)),
+	}
+
+	for pair, value := range rates {
+		from, to, ok := strings.Cut(strings.ToUpper(pair), "_")
+		if !ok || len(from) != 3 || len(to) != 3 {
+			return nil, fmt.Errorf("invalid currency pair %q", pair)
+		}
+
+		rate, ok := new(big.Rat).SetString(value)
+		if !ok || rate.Sign() <= 0 {
+			return nil, fmt.Errorf("invalid rate %q for currency pair %q", value, pair)
+		}
+		c.rates[from+"_"+to] = rate
+	}
+
+	return c, nil
+}
+
+func (c *currencyConverter) Default() string {
+	return c.defaultCurrency
+}
+
+func (c *currencyConverter) Convert(amount models.Money, from, to string) (models.Money, error) {
+	if from == to {
+		return amount, nil
+	}
+
+	if rate, ok := c.rates[from+"_"+to]; ok {
+		return amount.MulRat(rate), nil
+	}
+	if rate, ok := c.rates[to+"_"+from]; ok {
+		return amount.MulRat(new(big.Rat).Inv(rate)), nil
+	}
+
+	return models.Money{}, fmt.Errorf("%w: %s -> %s", ErrCurrencyMismatch, from, to)
+}
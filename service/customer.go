@@ -0,0 +1,404 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/igntnk/stocky-oms/db"
+	"github.com/igntnk/stocky-oms/models"
+	"github.com/igntnk/stocky-oms/repository"
+	"github.com/jackc/pgx/v5/pgtype"
+	"time"
+)
+
+// maxCustomerIDLength is the length of orders.user_id
+const maxCustomerIDLength = 64
+
+type CustomerService interface {
+	CreateCustomer(ctx context.Context, req models.CustomerCreateRequest) (*models.CustomerResponse, error)
+	GetCustomer(ctx context.Context, userID string) (*models.CustomerResponse, error)
+	ListCustomers(ctx context.Context, filter models.CustomerFilter) ([]*models.CustomerResponse, error)
+	UpdateCustomer(ctx context.Context, userID string, req models.CustomerUpdateRequest) (*models.CustomerResponse, error)
+	AddCustomerAddress(ctx context.Context, userID string, req models.CustomerAddressRequest) (*models.AddressResponse, error)
+	UpdateCustomerAddress(ctx context.Context, userID, addressID string, req models.CustomerAddressRequest) (*models.AddressResponse, error)
+	DeleteCustomerAddress(ctx context.Context, userID, addressID string) error
+	// ListCustomerOrders lists the orders of the customer newest first
+	ListCustomerOrders(ctx context.Context, userID string, filter models.OrderFilter) ([]*models.OrderResponse, error)
+	GetCustomerStats(ctx context.Context, userID string) (*models.CustomerStatsResponse, error)
+}
+
+type customerService struct {
+	repo   repository.CustomerRepository
+	orders OrderService
+}
+
+func NewCustomerService(repo repository.CustomerRepository, orders OrderService) CustomerService {
+	return &customerService{
+		repo:   repo,
+		orders: orders,
+	}
+}
+
+func (s *customerService) CreateCustomer(ctx context.Context, req models.CustomerCreateRequest) (*models.CustomerResponse, error) {
+	// customers can only register themselves
+	if userID, limited := customerID(ctx); limited {
+		req.UserID = userID
+	}
+	if !validCustomerID(req.UserID) {
+		return nil, ErrInvalidCustomerID
+	}
+
+	customer, err := s.repo.Create(ctx, db.CreateCustomerParams{
+		Uuid: pgtype.UUID{
+			Bytes: uuid.New(),
+			Valid: true,
+		},
+		UserID: req.UserID,
+		Name:   req.Name,
+		Email:  req.Email,
+		Phone:  req.Phone,
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrCustomerExists) {
+			return nil, ErrCustomerExists
+		}
+		return nil, fmt.Errorf("failed to create customer: %w", err)
+	}
+
+	return customerToResponse(customer, nil), nil
+}
+
+func (s *customerService) GetCustomer(ctx context.Context, userID string) (*models.CustomerResponse, error) {
+	customer, err := s.getCustomer(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	addresses, err := s.repo.ListAddresses(ctx, customer.Uuid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list customer addresses: %w", err)
+	}
+
+	return customerToResponse(customer, addresses), nil
+}
+
+func (s *customerService) ListCustomers(ctx context.Context, filter models.CustomerFilter) ([]*models.CustomerResponse, error) {
+	customers, err := s.repo.List(ctx, int32(filter.Limit), int32(filter.Offset))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list customers: %w", err)
+	}
+
+	response := make([]*models.CustomerResponse, 0, len(customers))
+	for _, c := range customers {
+		response = append(response, customerToResponse(c, nil))
+	}
+
+	return response, nil
+}
+
+func (s *customerService) UpdateCustomer(ctx context.Context, userID string, req models.CustomerUpdateRequest) (*models.CustomerResponse, error) {
+	customer, err := s.getCustomer(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	updateParams := db.UpdateCustomerParams{
+		Uuid: customer.Uuid,
+	}
+
+	if req.Name != nil {
+		updateParams.Name = pgtype.Text{String: *req.Name, Valid: true}
+	}
+	if req.Email != nil {
+		updateParams.Email = pgtype.Text{String: *req.Email, Valid: true}
+	}
+	if req.Phone != nil {
+		updateParams.Phone = pgtype.Text{String: *req.Phone, Valid: true}
+	}
+	if req.DefaultShippingAddressID != nil {
+		address, err := s.getAddress(ctx, customer, *req.DefaultShippingAddressID)
+		if err != nil {
+			return nil, err
+		}
+		updateParams.DefaultShippingAddressUuid = address.Uuid
+	}
+
+	customer, err = s.repo.Update(ctx, updateParams)
+	if err != nil {
+		if errors.Is(err, repository.ErrCustomerNotFound) {
+			return nil, ErrCustomerNotFound
+		}
+		return nil, fmt.Errorf("failed to update customer: %w", err)
+	}
+
+	addresses, err := s.repo.ListAddresses(ctx, customer.Uuid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list customer addresses: %w", err)
+	}
+
+	return customerToResponse(customer, addresses), nil
+}
+
+func (s *customerService) AddCustomerAddress(ctx context.Context, userID string, req models.CustomerAddressRequest) (*models.AddressResponse, error) {
+	customer, err := s.getCustomer(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	address, err := s.repo.AddAddress(ctx, db.AddCustomerAddressParams{
+		Uuid: pgtype.UUID{
+			Bytes: uuid.New(),
+			Valid: true,
+		},
+		CustomerUuid: customer.Uuid,
+		Label:        req.Label,
+		Recipient:    req.Recipient,
+		Line1:        req.Line1,
+		Line2:        req.Line2,
+		City:         req.City,
+		Region:       req.Region,
+		PostalCode:   req.PostalCode,
+		Country:      req.Country,
+		Phone:        req.Phone,
+	}, req.DefaultShipping)
+	if err != nil {
+		if errors.Is(err, repository.ErrCustomerNotFound) {
+			return nil, ErrCustomerNotFound
+		}
+		return nil, fmt.Errorf("failed to add customer address: %w", err)
+	}
+
+	defaultShipping := req.DefaultShipping || customer.DefaultShippingAddressUuid == address.Uuid
+	return addressToResponse(address, defaultShipping), nil
+}
+
+func (s *customerService) UpdateCustomerAddress(
+	ctx context.Context,
+	userID, addressID string,
+	req models.CustomerAddressRequest,
+) (*models.AddressResponse, error) {
+	customer, err := s.getCustomer(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	addressUUID, err := uuid.Parse(addressID)
+	if err != nil {
+		return nil, ErrInvalidAddressID
+	}
+
+	address, err := s.repo.UpdateAddress(ctx, db.UpdateCustomerAddressParams{
+		Uuid: pgtype.UUID{
+			Bytes: addressUUID,
+			Valid: true,
+		},
+		CustomerUuid: customer.Uuid,
+		Label:        req.Label,
+		Recipient:    req.Recipient,
+		Line1:        req.Line1,
+		Line2:        req.Line2,
+		City:         req.City,
+		Region:       req.Region,
+		PostalCode:   req.PostalCode,
+		Country:      req.Country,
+		Phone:        req.Phone,
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrAddressNotFound) {
+			return nil, ErrAddressNotFound
+		}
+		return nil, fmt.Errorf("failed to update customer address: %w", err)
+	}
+
+	if req.DefaultShipping && customer.DefaultShippingAddressUuid != address.Uuid {
+		_, err = s.repo.Update(ctx, db.UpdateCustomerParams{
+			Uuid:                       customer.Uuid,
+			DefaultShippingAddressUuid: address.Uuid,
+		})
+		if err != nil {
+			if errors.Is(err, repository.ErrCustomerNotFound) {
+				return nil, ErrCustomerNotFound
+			}
+			return nil, fmt.Errorf("failed to set default address: %w", err)
+		}
+		customer.DefaultShippingAddressUuid = address.Uuid
+	}
+
+	return addressToResponse(address, customer.DefaultShippingAddressUuid == address.Uuid), nil
+}
+
+func (s *customerService) DeleteCustomerAddress(ctx context.Context, userID, addressID string) error {
+	customer, err := s.getCustomer(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	addressUUID, err := uuid.Parse(addressID)
+	if err != nil {
+		return ErrInvalidAddressID
+	}
+
+	err = s.repo.DeleteAddress(ctx, db.DeleteCustomerAddressParams{
+		Uuid: pgtype.UUID{
+			Bytes: addressUUID,
+			Valid: true,
+		},
+		CustomerUuid: customer.Uuid,
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrAddressNotFound) {
+			return ErrAddressNotFound
+		}
+		return fmt.Errorf("failed to delete customer address: %w", err)
+	}
+
+	return nil
+}
+
+func (s *customerService) ListCustomerOrders(ctx context.Context, userID string, filter models.OrderFilter) ([]*models.OrderResponse, error) {
+	customer, err := s.getCustomer(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	filter.UserID = customer.UserID
+	return s.orders.ListOrders(ctx, filter)
+}
+
+func (s *customerService) GetCustomerStats(ctx context.Context, userID string) (*models.CustomerStatsResponse, error) {
+	customer, err := s.getCustomer(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := s.repo.OrderStats(ctx, customer.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get customer order stats: %w", err)
+	}
+
+	spending, err := s.repo.Spending(ctx, customer.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get customer spending: %w", err)
+	}
+
+	response := &models.CustomerStatsResponse{
+		UserID:     customer.UserID,
+		OrderCount: int(stats.OrderCount),
+		TotalSpent: make([]models.CurrencyAmount, 0, len(spending)),
+	}
+
+	for _, row := range spending {
+		amount, err := repository.NumericToMoney(row.TotalSpent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert total spent: %w", err)
+		}
+		response.TotalSpent = append(response.TotalSpent, models.CurrencyAmount{
+			Currency: row.Currency,
+			Amount:   amount,
+		})
+	}
+
+	if stats.LastOrderAt.Valid {
+		lastOrderAt := stats.LastOrderAt.Time.Format(time.RFC3339)
+		response.LastOrderAt = &lastOrderAt
+	}
+
+	return response, nil
+}
+
+// getCustomer returns the customer of userID if the caller may see it
+func (s *customerService) getCustomer(ctx context.Context, userID string) (db.Customer, error) {
+	if !validCustomerID(userID) {
+		return db.Customer{}, ErrInvalidCustomerID
+	}
+
+	err := checkCustomerAccess(ctx, userID)
+	if err != nil {
+		return db.Customer{}, err
+	}
+
+	customer, err := s.repo.Get(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrCustomerNotFound) {
+			return db.Customer{}, ErrCustomerNotFound
+		}
+		return db.Customer{}, fmt.Errorf("failed to get customer: %w", err)
+	}
+
+	return customer, nil
+}
+
+func (s *customerService) getAddress(ctx context.Context, customer db.Customer, addressID string) (db.CustomerAddress, error) {
+	addressUUID, err := uuid.Parse(addressID)
+	if err != nil {
+		return db.CustomerAddress{}, ErrInvalidAddressID
+	}
+
+	address, err := s.repo.GetAddress(ctx, db.GetCustomerAddressParams{
+		Uuid: pgtype.UUID{
+			Bytes: addressUUID,
+			Valid: true,
+		},
+		CustomerUuid: customer.Uuid,
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrAddressNotFound) {
+			return db.CustomerAddress{}, ErrAddressNotFound
+		}
+		return db.CustomerAddress{}, fmt.Errorf("failed to get customer address: %w", err)
+	}
+
+	return address, nil
+}
+
+func validCustomerID(userID string) bool {
+	return userID != "" && len(userID) <= maxCustomerIDLength
+}
+
+func customerToResponse(c db.Customer, addresses []db.CustomerAddress) *models.CustomerResponse {
+	response := &models.CustomerResponse{
+		ID:        c.Uuid.String(),
+		UserID:    c.UserID,
+		Name:      c.Name,
+		Email:     c.Email,
+		Phone:     c.Phone,
+		Addresses: make([]*models.AddressResponse, 0, len(addresses)),
+		CreatedAt: c.CreatedAt.Time.Format(time.RFC3339),
+		UpdatedAt: c.UpdatedAt.Time.Format(time.RFC3339),
+	}
+
+	if c.DefaultShippingAddressUuid.Valid {
+		defaultID := c.DefaultShippingAddressUuid.String()
+		response.DefaultShippingAddressID = &defaultID
+	}
+
+	for _, a := range addresses {
+		response.Addresses = append(response.Addresses, addressToResponse(a, c.DefaultShippingAddressUuid == a.Uuid))
+	}
+
+	return response
+}
+
+func addressToResponse(a db.CustomerAddress, defaultShipping bool) *models.AddressResponse {
+	return &models.AddressResponse{
+		ID:              a.Uuid.String(),
+		Address:         addressFromDB(a),
+		DefaultShipping: defaultShipping,
+		CreatedAt:       a.CreatedAt.Time.Format(time.RFC3339),
+	}
+}
+
+func addressFromDB(a db.CustomerAddress) models.Address {
+	return models.Address{
+		Label:      a.Label,
+		Recipient:  a.Recipient,
+		Line1:      a.Line1,
+		Line2:      a.Line2,
+		City:       a.City,
+		Region:     a.Region,
+		PostalCode: a.PostalCode,
+		Country:    a.Country,
+		Phone:      a.Phone,
+	}
+}
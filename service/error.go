@@ -6,6 +6,81 @@ var (
 	ErrOrderNotFound     = errors.New("order not found")
 	ErrInvalidOrderID    = errors.New("invalid order id")
 	ErrInvalidOrderData  = errors.New("invalid order data")
+	ErrCurrencyMismatch  = errors.New("no exchange rate between order and product currencies")
 	ErrEmptyOrder        = errors.New("order must contain at least one product")
 	ErrOrderUpdateFailed = errors.New("order update failed")
+	ErrOrderNotEditable  = errors.New("order products can only be changed while the order is new")
+	ErrPermissionDenied  = errors.New("permission denied")
+
+	ErrInvalidStatusTransition = errors.New("invalid order status transition")
+	ErrOrderStatusConflict     = errors.New("order status was changed concurrently")
+	ErrOrderLinesConflict      = errors.New("order products were changed concurrently")
+	ErrStockReturnFailed       = errors.New("failed to return order products to warehouse")
+	ErrStockReturnInProgress   = errors.New("order products are being returned to warehouse, retry later")
+	ErrReservationExpired      = errors.New("order reservation expired")
+
+	ErrCustomerNotFound  = errors.New("customer not found")
+	ErrInvalidCustomerID = errors.New("invalid customer id")
+	ErrCustomerExists    = errors.New("customer already exists")
+	ErrAddressNotFound   = errors.New("customer address not found")
+	ErrInvalidAddressID  = errors.New("invalid customer address id")
+
+	ErrShippingMethodNotFound  = errors.New("shipping method not found")
+	ErrInvalidShippingMethodID = errors.New("invalid shipping method id")
+	ErrShippingMethodExists    = errors.New("shipping method with the code already exists")
+	ErrShippingMethodInUse     = errors.New("shipping method is used by orders, deactivate it instead")
+	ErrInvalidShippingMethod   = errors.New("unknown or inactive shipping method")
+	ErrShippingAddressRequired = errors.New("shipped orders need a shipping address")
+
+	ErrShipmentNotFound          = errors.New("shipment not found")
+	ErrInvalidShipmentID         = errors.New("invalid shipment id")
+	ErrInvalidShipmentTransition = errors.New("invalid shipment status transition")
+	ErrShipmentStatusConflict    = errors.New("shipment status was changed concurrently")
+	ErrOrderNotShippable         = errors.New("only processing orders can be shipped")
+	ErrShipmentExceedsOrder      = errors.New("shipment exceeds the order amount left to ship")
+
+	ErrReturnNotFound          = errors.New("return not found")
+	ErrInvalidReturnID         = errors.New("invalid return id")
+	ErrInvalidReturnTransition = errors.New("invalid return status transition")
+	ErrReturnStatusConflict    = errors.New("return status was changed concurrently")
+	ErrOrderNotReturnable      = errors.New("only completed orders can be returned")
+	ErrReturnExceedsOrder      = errors.New("return exceeds what is left of the order")
+
+	ErrPaymentNotFound      = errors.New("order has no payment")
+	ErrPaymentDeclined      = errors.New("payment declined")
+	ErrPaymentNotAuthorized = errors.New("order payment is not authorized")
+	ErrPaymentNotCaptured   = errors.New("order payment is not captured")
+	ErrPaymentConflict      = errors.New("payment was changed concurrently")
+	ErrPaymentFailed        = errors.New("payment provider failed")
+
+	ErrOrderNotInvoiceable = errors.New("only completed orders can be invoiced")
+	ErrInvoiceFontMissing  = errors.New("invoice font is not configured")
+	ErrInvoicePDFDisabled  = errors.New("PDF invoices are disabled, no invoice font is configured")
+	ErrOrderInvoiced       = errors.New("invoiced orders can not be deleted")
+
+	ErrProductNotFound  = errors.New("product not found")
+	ErrInvalidProductID = errors.New("invalid product id")
+	ErrPriceNotFound    = errors.New("product has no price at this time")
+
+	ErrOrderProductNotFound = errors.New("product is not in the order")
+
+	ErrPriceChangeNotFound   = errors.New("scheduled price change not found")
+	ErrInvalidPriceChangeID  = errors.New("invalid scheduled price change id")
+	ErrInvalidPriceChange    = errors.New("invalid scheduled price change")
+	ErrPriceChangeNotPending = errors.New("scheduled price change is not pending")
+
+	ErrInvalidIdempotencyKey    = errors.New("invalid idempotency key")
+	ErrIdempotencyKeyConflict   = errors.New("idempotency key was used with a different request")
+	ErrIdempotencyKeyInProgress = errors.New("request with this idempotency key is still in progress")
+
+	ErrPromotionNotFound    = errors.New("promotion not found")
+	ErrInvalidPromotionID   = errors.New("invalid promotion id")
+	ErrInvalidPromotion     = errors.New("invalid promotion")
+	ErrPromotionCodeUsed    = errors.New("promotion code is already used")
+	ErrInvalidCoupon        = errors.New("invalid coupon")
+	ErrPromotionUnavailable = errors.New("promotion is no longer available")
+
+	ErrTaxRuleNotFound  = errors.New("tax rule not found")
+	ErrInvalidTaxRuleID = errors.New("invalid tax rule id")
+	ErrTaxRuleExists    = errors.New("tax rule for the class and region already exists")
 )
@@ -0,0 +1,335 @@
+package service
+
+import (
+	"context"
+	"github.com/igntnk/stocky-oms/clients"
+	"github.com/igntnk/stocky-oms/db"
+	"github.com/igntnk/stocky-oms/models"
+	"github.com/igntnk/stocky-oms/repository"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"time"
+)
+
+// The fakes keep their rows in memory and implement only the methods the
+// tests reach, the embedded interfaces panic on any other call.
+
+type fakeOrderRepo struct {
+	repository.OrderRepository
+	orders   map[string]db.Order
+	products map[string][]db.GetOrderProductsRow
+	// stockReturns receives the stock returns Cancel registers
+	stockReturns *fakeStockReturnRepo
+}
+
+func newFakeOrderRepo(orders ...db.Order) *fakeOrderRepo {
+	r := &fakeOrderRepo{
+		orders:   make(map[string]db.Order, len(orders)),
+		products: map[string][]db.GetOrderProductsRow{},
+	}
+	for _, o := range orders {
+		r.orders[o.Uuid.String()] = o
+	}
+	return r
+}
+
+func (r *fakeOrderRepo) Get(ctx context.Context, uuid string) (db.Order, error) {
+	order, ok := r.orders[uuid]
+	if !ok {
+		return db.Order{}, repository.ErrOrderNotFound
+	}
+	return order, nil
+}
+
+func (r *fakeOrderRepo) UpdateStatus(ctx context.Context, history db.AddOrderStatusHistoryParams) (db.Order, error) {
+	order, ok := r.orders[history.OrderUuid.String()]
+	if !ok {
+		return db.Order{}, repository.ErrOrderNotFound
+	}
+	if order.Status != history.FromStatus {
+		return db.Order{}, repository.ErrOrderStatusChanged
+	}
+
+	order.Status = history.ToStatus
+	r.orders[order.Uuid.String()] = order
+	return order, nil
+}
+
+func (r *fakeOrderRepo) Cancel(ctx context.Context, history db.AddOrderStatusHistoryParams) (db.Order, error) {
+	history.ToStatus = db.OrderStatusCancelled
+	order, err := r.UpdateStatus(ctx, history)
+	if err != nil {
+		return db.Order{}, err
+	}
+
+	if order.StockTaken && r.stockReturns != nil {
+		r.stockReturns.returns[order.Uuid.String()] = db.OrderStockReturn{
+			OrderUuid: order.Uuid,
+			Status:    db.StockReturnStatusPending,
+		}
+	}
+	return order, nil
+}
+
+func (r *fakeOrderRepo) GetOrderProducts(ctx context.Context, orderUUID string) ([]db.GetOrderProductsRow, error) {
+	return r.products[orderUUID], nil
+}
+
+// fakeStockReturnRepo claims stock returns like the repository, leases never
+// run out
+type fakeStockReturnRepo struct {
+	repository.StockReturnRepository
+	returns map[string]db.OrderStockReturn
+}
+
+func newFakeStockReturnRepo(returns ...db.OrderStockReturn) *fakeStockReturnRepo {
+	r := &fakeStockReturnRepo{returns: make(map[string]db.OrderStockReturn, len(returns))}
+	for _, ret := range returns {
+		r.returns[ret.OrderUuid.String()] = ret
+	}
+	return r
+}
+
+func (r *fakeStockReturnRepo) Claim(ctx context.Context, orderUUID string, lease time.Duration) (db.OrderStockReturn, error) {
+	ret, ok := r.returns[orderUUID]
+	if !ok {
+		return db.OrderStockReturn{}, repository.ErrNoStockReturn
+	}
+
+	switch ret.Status {
+	case db.StockReturnStatusCompleted:
+		return db.OrderStockReturn{}, repository.ErrStockReturnDone
+	case db.StockReturnStatusInProgress:
+		return db.OrderStockReturn{}, repository.ErrStockReturnClaimed
+	}
+
+	ret.Status = db.StockReturnStatusInProgress
+	ret.Attempts++
+	r.returns[orderUUID] = ret
+	return ret, nil
+}
+
+func (r *fakeStockReturnRepo) Complete(ctx context.Context, orderUUID string) error {
+	ret := r.returns[orderUUID]
+	ret.Status = db.StockReturnStatusCompleted
+	r.returns[orderUUID] = ret
+	return nil
+}
+
+func (r *fakeStockReturnRepo) Fail(ctx context.Context, orderUUID string, reason string) error {
+	ret := r.returns[orderUUID]
+	ret.Status = db.StockReturnStatusFailed
+	ret.LastError = pgtype.Text{String: reason, Valid: true}
+	r.returns[orderUUID] = ret
+	return nil
+}
+
+type fakePaymentRepo struct {
+	repository.PaymentRepository
+	payments []db.Payment
+}
+
+func (r *fakePaymentRepo) Create(ctx context.Context, arg db.CreatePaymentParams) (db.Payment, error) {
+	if arg.Status != db.PaymentStatusFailed {
+		if _, err := r.GetActive(ctx, arg.OrderUuid); err == nil {
+			return db.Payment{}, repository.ErrPaymentExists
+		}
+	}
+
+	payment := db.Payment{
+		Uuid:           arg.Uuid,
+		OrderUuid:      arg.OrderUuid,
+		Provider:       arg.Provider,
+		Reference:      arg.Reference,
+		Status:         arg.Status,
+		Amount:         arg.Amount,
+		Currency:       arg.Currency,
+		CapturedAmount: repository.MoneyToNumeric(models.Money{}),
+		RefundedAmount: repository.MoneyToNumeric(models.Money{}),
+		Error:          arg.Error,
+	}
+	r.payments = append(r.payments, payment)
+	return payment, nil
+}
+
+func (r *fakePaymentRepo) GetActive(ctx context.Context, orderUUID pgtype.UUID) (db.Payment, error) {
+	for _, p := range r.payments {
+		if p.OrderUuid != orderUUID {
+			continue
+		}
+		switch p.Status {
+		case db.PaymentStatusAuthorized, db.PaymentStatusCaptured, db.PaymentStatusRefunded:
+			return p, nil
+		}
+	}
+	return db.Payment{}, repository.ErrPaymentNotFound
+}
+
+func (r *fakePaymentRepo) Capture(ctx context.Context, arg db.CapturePaymentParams) (db.Payment, error) {
+	return r.update(arg.Uuid, func(p *db.Payment) {
+		p.Status = db.PaymentStatusCaptured
+		p.CapturedAmount = arg.CapturedAmount
+	})
+}
+
+func (r *fakePaymentRepo) Void(ctx context.Context, paymentUUID pgtype.UUID) (db.Payment, error) {
+	return r.update(paymentUUID, func(p *db.Payment) {
+		p.Status = db.PaymentStatusVoided
+	})
+}
+
+// update changes an authorized payment
+func (r *fakePaymentRepo) update(paymentUUID pgtype.UUID, change func(p *db.Payment)) (db.Payment, error) {
+	for i := range r.payments {
+		p := &r.payments[i]
+		if p.Uuid != paymentUUID {
+			continue
+		}
+		if p.Status != db.PaymentStatusAuthorized {
+			return db.Payment{}, repository.ErrPaymentStatusChanged
+		}
+		change(p)
+		return *p, nil
+	}
+	return db.Payment{}, repository.ErrPaymentStatusChanged
+}
+
+// statuses lists the status of every recorded payment in creation order
+func (r *fakePaymentRepo) statuses() []db.PaymentStatus {
+	res := make([]db.PaymentStatus, len(r.payments))
+	for i, p := range r.payments {
+		res[i] = p.Status
+	}
+	return res
+}
+
+type fakePromotionRepo struct {
+	repository.PromotionRepository
+	promotions map[string]db.Promotion
+}
+
+func newFakePromotionRepo(promotions ...db.Promotion) *fakePromotionRepo {
+	r := &fakePromotionRepo{promotions: make(map[string]db.Promotion, len(promotions))}
+	for _, p := range promotions {
+		r.promotions[p.Uuid.String()] = p
+	}
+	return r
+}
+
+func (r *fakePromotionRepo) Get(ctx context.Context, uuid string) (db.Promotion, error) {
+	p, ok := r.promotions[uuid]
+	if !ok {
+		return db.Promotion{}, repository.ErrPromotionNotFound
+	}
+	return p, nil
+}
+
+// fakeTaxRepo holds the tax rules by tax class, they apply in every region
+type fakeTaxRepo struct {
+	repository.TaxRuleRepository
+	rules map[string]db.TaxRule
+}
+
+func (r *fakeTaxRepo) Find(ctx context.Context, taxClass, region string) (db.TaxRule, error) {
+	rule, ok := r.rules[taxClass]
+	if !ok {
+		return db.TaxRule{}, repository.ErrTaxRuleNotFound
+	}
+	return rule, nil
+}
+
+// fakeSagaRepo claims sagas like the repository but never lets a claim
+// expire, a saga is claimed once per test
+type fakeSagaRepo struct {
+	repository.SagaRepository
+	sagas   []db.OrderSaga
+	claimed map[string]bool
+}
+
+func newFakeSagaRepo(sagas ...db.OrderSaga) *fakeSagaRepo {
+	return &fakeSagaRepo{sagas: sagas, claimed: map[string]bool{}}
+}
+
+func (r *fakeSagaRepo) ClaimUnfinished(ctx context.Context, olderThan time.Duration) (db.OrderSaga, error) {
+	for _, saga := range r.sagas {
+		unfinished := saga.Step == db.SagaStepStarted || saga.Step == db.SagaStepStockRemoved
+		if unfinished && !r.claimed[saga.Uuid.String()] {
+			r.claimed[saga.Uuid.String()] = true
+			return saga, nil
+		}
+	}
+	return db.OrderSaga{}, repository.ErrNoUnfinishedSaga
+}
+
+func (r *fakeSagaRepo) SetStep(ctx context.Context, sagaUUID string, step db.SagaStep, lastError string) error {
+	for i := range r.sagas {
+		if r.sagas[i].Uuid.String() == sagaUUID {
+			r.sagas[i].Step = step
+			r.sagas[i].LastError = pgtype.Text{String: lastError, Valid: lastError != ""}
+			return nil
+		}
+	}
+	return nil
+}
+
+// fakeSMSClient records the products written back, every call fails with err
+// when it is set
+type fakeSMSClient struct {
+	clients.SMSClient
+	err      error
+	writeOns [][]models.ProductWriteOffRequest
+}
+
+func (c *fakeSMSClient) WriteOnCoupleProducts(ctx context.Context, req []models.ProductWriteOffRequest) ([]string, error) {
+	c.writeOns = append(c.writeOns, req)
+	if c.err != nil {
+		return nil, c.err
+	}
+	return nil, nil
+}
+
+// fakeIdempotencyRepo keeps the keys by scope and key, they never expire
+type fakeIdempotencyRepo struct {
+	repository.IdempotencyRepository
+	keys map[[2]string]db.IdempotencyKey
+}
+
+func newFakeIdempotencyRepo() *fakeIdempotencyRepo {
+	return &fakeIdempotencyRepo{keys: map[[2]string]db.IdempotencyKey{}}
+}
+
+func (r *fakeIdempotencyRepo) Claim(ctx context.Context, scope, key, fingerprint string, ttl time.Duration) error {
+	if _, ok := r.keys[[2]string{scope, key}]; ok {
+		return repository.ErrIdempotencyKeyUsed
+	}
+	r.keys[[2]string{scope, key}] = db.IdempotencyKey{Scope: scope, Key: key, Fingerprint: fingerprint}
+	return nil
+}
+
+func (r *fakeIdempotencyRepo) Get(ctx context.Context, scope, key string) (db.IdempotencyKey, error) {
+	stored, ok := r.keys[[2]string{scope, key}]
+	if !ok {
+		return db.IdempotencyKey{}, pgx.ErrNoRows
+	}
+	return stored, nil
+}
+
+func (r *fakeIdempotencyRepo) Complete(ctx context.Context, scope, key string, response []byte) error {
+	stored := r.keys[[2]string{scope, key}]
+	stored.Response = response
+	r.keys[[2]string{scope, key}] = stored
+	return nil
+}
+
+func (r *fakeIdempotencyRepo) Release(ctx context.Context, scope, key string) error {
+	delete(r.keys, [2]string{scope, key})
+	return nil
+}
+
+func testUUID(b byte) pgtype.UUID {
+	return pgtype.UUID{Bytes: [16]byte{15: b}, Valid: true}
+}
+
+func cents(c int64) pgtype.Numeric {
+	return repository.MoneyToNumeric(models.MoneyFromCents(c))
+}
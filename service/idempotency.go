@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/igntnk/stocky-oms/auth"
+	"github.com/igntnk/stocky-oms/models"
+	"github.com/igntnk/stocky-oms/repository"
+	"github.com/jackc/pgx/v5"
+	"time"
+)
+
+const maxIdempotencyKeyLength = 255
+
+type CreateOrderFunc func(ctx context.Context, req models.OrderCreateRequest) (*models.OrderResponse, error)
+
+type IdempotencyService interface {
+	// CreateOrder runs create at most once per tenant, scope and key. A repeated
+	// request returns the stored response, a request with a different
+	// payload under the same key is rejected. An empty key disables the check.
+	CreateOrder(ctx context.Context, scope, key string, req models.OrderCreateRequest, create CreateOrderFunc) (*models.OrderResponse, error)
+	PurgeExpired(ctx context.Context) (int64, error)
+}
+
+type idempotencyService struct {
+	repo repository.IdempotencyRepository
+	ttl  time.Duration
+}
+
+func NewIdempotencyService(repo repository.IdempotencyRepository, ttl time.Duration) IdempotencyService {
+	return &idempotencyService{
+		repo: repo,
+		ttl:  ttl,
+	}
+}
+
+func (s *idempotencyService) CreateOrder(
+	ctx context.Context,
+	scope, key string,
+	req models.OrderCreateRequest,
+	create CreateOrderFunc,
+) (*models.OrderResponse, error) {
+	if key == "" {
+		return create(ctx, req)
+	}
+	if len(key) > maxIdempotencyKeyLength {
+		return nil, ErrInvalidIdempotencyKey
+	}
+
+	fingerprint, err := requestFingerprint(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// keys are chosen by clients, the same key may be used by several shops
+	if tenant, ok := auth.TenantFromContext(ctx); ok {
+		scope = tenant + "/" + scope
+	}
+
+	err = s.repo.Claim(ctx, scope, key, fingerprint, s.ttl)
+	if err != nil {
+		if errors.Is(err, repository.ErrIdempotencyKeyUsed) {
+			return s.replay(ctx, scope, key, fingerprint)
+		}
+		return nil, fmt.Errorf("failed to claim idempotency key: %w", err)
+	}
+
+	recordCtx := context.WithoutCancel(ctx)
+
+	resp, err := create(ctx, req)
+	if err != nil {
+		return nil, errors.Join(err, s.repo.Release(recordCtx, scope, key))
+	}
+
+	// the order is already created; if the response is not stored the key
+	// stays in progress until it expires instead of creating a duplicate
+	if payload, err := json.Marshal(resp); err == nil {
+		_ = s.repo.Complete(recordCtx, scope, key, payload)
+	}
+
+	return resp, nil
+}
+
+func (s *idempotencyService) replay(ctx context.Context, scope, key, fingerprint string) (*models.OrderResponse, error) {
+	stored, err := s.repo.Get(ctx, scope, key)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			// released by a failed request in the meantime
+			return nil, ErrIdempotencyKeyInProgress
+		}
+		return nil, fmt.Errorf("failed to get idempotency key: %w", err)
+	}
+
+	if stored.Fingerprint != fingerprint {
+		return nil, ErrIdempotencyKeyConflict
+	}
+	if stored.Response == nil {
+		return nil, ErrIdempotencyKeyInProgress
+	}
+
+	var resp models.OrderResponse
+	err = json.Unmarshal(stored.Response, &resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode stored response: %w", err)
+	}
+	return &resp, nil
+}
+
+func (s *idempotencyService) PurgeExpired(ctx context.Context) (int64, error) {
+	return s.repo.DeleteExpired(ctx)
+}
+
+func requestFingerprint(req any) (string, error) {
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/igntnk/stocky-oms/auth"
+	"github.com/igntnk/stocky-oms/models"
+	"strings"
+	"testing"
+)
+
+// countingCreate creates orders numbered by the calls made so far
+type countingCreate struct {
+	calls int
+	err   error
+}
+
+func (c *countingCreate) create(ctx context.Context, req models.OrderCreateRequest) (*models.OrderResponse, error) {
+	c.calls++
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &models.OrderResponse{ID: fmt.Sprintf("order-%d", c.calls), Comment: req.Comment}, nil
+}
+
+func TestIdempotentCreateOrder(t *testing.T) {
+	ctx := auth.WithTenant(context.Background(), "shop-1")
+	req := models.OrderCreateRequest{UserID: "alice", Comment: "first"}
+
+	t.Run("repeated request is replayed", func(t *testing.T) {
+		s := NewIdempotencyService(newFakeIdempotencyRepo(), 0)
+		c := &countingCreate{}
+
+		first, err := s.CreateOrder(ctx, "saga", "key", req, c.create)
+		if err != nil {
+			t.Fatalf("CreateOrder() error = %v", err)
+		}
+		second, err := s.CreateOrder(ctx, "saga", "key", req, c.create)
+		if err != nil {
+			t.Fatalf("repeated CreateOrder() error = %v", err)
+		}
+
+		if c.calls != 1 {
+			t.Errorf("orders created = %d, want 1", c.calls)
+		}
+		if second.ID != first.ID {
+			t.Errorf("replayed order = %s, want %s", second.ID, first.ID)
+		}
+	})
+
+	t.Run("different request under the key", func(t *testing.T) {
+		s := NewIdempotencyService(newFakeIdempotencyRepo(), 0)
+		c := &countingCreate{}
+
+		_, err := s.CreateOrder(ctx, "saga", "key", req, c.create)
+		if err != nil {
+			t.Fatalf("CreateOrder() error = %v", err)
+		}
+		other := req
+		other.Comment = "second"
+		_, err = s.CreateOrder(ctx, "saga", "key", other, c.create)
+		if !errors.Is(err, ErrIdempotencyKeyConflict) {
+			t.Errorf("CreateOrder() error = %v, want %v", err, ErrIdempotencyKeyConflict)
+		}
+		if c.calls != 1 {
+			t.Errorf("orders created = %d, want 1", c.calls)
+		}
+	})
+
+	t.Run("request in progress", func(t *testing.T) {
+		s := NewIdempotencyService(newFakeIdempotencyRepo(), 0)
+
+		// the repeated request arrives while the first one still creates the order
+		var nestedErr error
+		_, err := s.CreateOrder(ctx, "saga", "key", req, func(ctx context.Context, req models.OrderCreateRequest) (*models.OrderResponse, error) {
+			_, nestedErr = s.CreateOrder(ctx, "saga", "key", req, (&countingCreate{}).create)
+			return &models.OrderResponse{ID: "order-1"}, nil
+		})
+		if err != nil {
+			t.Fatalf("CreateOrder() error = %v", err)
+		}
+		if !errors.Is(nestedErr, ErrIdempotencyKeyInProgress) {
+			t.Errorf("repeated CreateOrder() error = %v, want %v", nestedErr, ErrIdempotencyKeyInProgress)
+		}
+	})
+
+	t.Run("failed request releases the key", func(t *testing.T) {
+		s := NewIdempotencyService(newFakeIdempotencyRepo(), 0)
+		failing := &countingCreate{err: ErrEmptyOrder}
+
+		_, err := s.CreateOrder(ctx, "saga", "key", req, failing.create)
+		if !errors.Is(err, ErrEmptyOrder) {
+			t.Fatalf("CreateOrder() error = %v, want %v", err, ErrEmptyOrder)
+		}
+
+		c := &countingCreate{}
+		_, err = s.CreateOrder(ctx, "saga", "key", req, c.create)
+		if err != nil {
+			t.Fatalf("retried CreateOrder() error = %v", err)
+		}
+		if c.calls != 1 {
+			t.Errorf("orders created on retry = %d, want 1", c.calls)
+		}
+	})
+
+	t.Run("keys are separate per tenant and scope", func(t *testing.T) {
+		s := NewIdempotencyService(newFakeIdempotencyRepo(), 0)
+		c := &countingCreate{}
+
+		calls := []struct {
+			ctx   context.Context
+			scope string
+		}{
+			{ctx: ctx, scope: "saga"},
+			{ctx: ctx, scope: "tcc"},
+			{ctx: auth.WithTenant(context.Background(), "shop-2"), scope: "saga"},
+		}
+		for _, call := range calls {
+			_, err := s.CreateOrder(call.ctx, call.scope, "key", req, c.create)
+			if err != nil {
+				t.Fatalf("CreateOrder() error = %v", err)
+			}
+		}
+		if c.calls != len(calls) {
+			t.Errorf("orders created = %d, want %d", c.calls, len(calls))
+		}
+	})
+
+	t.Run("no key", func(t *testing.T) {
+		s := NewIdempotencyService(newFakeIdempotencyRepo(), 0)
+		c := &countingCreate{}
+
+		for range 2 {
+			_, err := s.CreateOrder(ctx, "saga", "", req, c.create)
+			if err != nil {
+				t.Fatalf("CreateOrder() error = %v", err)
+			}
+		}
+		if c.calls != 2 {
+			t.Errorf("orders created = %d, want 2", c.calls)
+		}
+	})
+
+	t.Run("key too long", func(t *testing.T) {
+		s := NewIdempotencyService(newFakeIdempotencyRepo(), 0)
+		c := &countingCreate{}
+
+		_, err := s.CreateOrder(ctx, "saga", strings.Repeat("k", maxIdempotencyKeyLength+1), req, c.create)
+		if !errors.Is(err, ErrInvalidIdempotencyKey) {
+			t.Errorf("CreateOrder() error = %v, want %v", err, ErrInvalidIdempotencyKey)
+		}
+		if c.calls != 0 {
+			t.Errorf("orders created = %d, want 0", c.calls)
+		}
+	})
+}
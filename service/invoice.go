@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/igntnk/stocky-oms/db"
+	"github.com/igntnk/stocky-oms/models"
+	"github.com/igntnk/stocky-oms/repository"
+	"github.com/jackc/pgx/v5/pgtype"
+	"time"
+)
+
+type InvoiceService interface {
+	// GetOrderInvoice returns the invoice of a completed order. The invoice is
+	// issued on the first request and returned unchanged afterwards.
+	GetOrderInvoice(ctx context.Context, orderID string) (*models.InvoiceResponse, error)
+	// RenderInvoice renders the invoice as an HTML or PDF document and returns
+	// its content type. Without the invoice font PDF requests fail with
+	// ErrInvoicePDFDisabled and the default format is HTML.
+	RenderInvoice(invoice *models.InvoiceResponse, format models.InvoiceFormat) ([]byte, string, error)
+}
+
+type invoiceService struct {
+	repo         repository.InvoiceRepository
+	orders       OrderService
+	seller       models.InvoiceSeller
+	numberPrefix string
+	font         string
+}
+
+// invoiceSnapshot is what is stored with an invoice. It holds everything
+// printed on the invoice so it renders the same however the order and the
+// configuration change.
+type invoiceSnapshot struct {
+	Number string               `json:"number"`
+	Seller models.InvoiceSeller `json:"seller"`
+	Order  models.OrderResponse `json:"order"`
+}
+
+// NewInvoiceService creates the invoice service. Invoice numbers are the
+// sequential number of the shop after numberPrefix. font is the TrueType font
+// file PDF invoices are drawn with, see CheckInvoiceFont, PDF invoices are
+// disabled when it is empty.
+func NewInvoiceService(
+	repo repository.InvoiceRepository,
+	orders OrderService,
+	seller models.InvoiceSeller,
+	numberPrefix string,
+	font string,
+) InvoiceService {
+	return &invoiceService{
+		repo:         repo,
+		orders:       orders,
+		seller:       seller,
+		numberPrefix: numberPrefix,
+		font:         font,
+	}
+}
+
+func (s *invoiceService) GetOrderInvoice(ctx context.Context, orderID string) (*models.InvoiceResponse, error) {
+	orderUUID, err := uuid.Parse(orderID)
+	if err != nil {
+		return nil, ErrInvalidOrderID
+	}
+
+	orderPgUUID := pgtype.UUID{
+		Bytes: orderUUID,
+		Valid: true,
+	}
+
+	invoice, err := s.repo.GetByOrder(ctx, orderPgUUID)
+	if err == nil {
+		res, err := invoiceToResponse(invoice)
+		if err != nil {
+			return nil, err
+		}
+		return res, checkOrderAccess(ctx, res.Order.UserID)
+	}
+	if !errors.Is(err, repository.ErrInvoiceNotFound) {
+		return nil, fmt.Errorf("failed to get invoice: %w", err)
+	}
+
+	order, err := s.orders.GetOrder(ctx, orderUUID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	if order.Status != models.OrderStatusCompleted {
+		return nil, ErrOrderNotInvoiceable
+	}
+
+	invoice, err = s.issue(ctx, orderPgUUID, order)
+	if err != nil {
+		if !errors.Is(err, repository.ErrInvoiceExists) {
+			return nil, fmt.Errorf("failed to issue invoice: %w", err)
+		}
+
+		// issued by a concurrent request
+		invoice, err = s.repo.GetByOrder(ctx, orderPgUUID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get invoice: %w", err)
+		}
+	}
+
+	return invoiceToResponse(invoice)
+}
+
+// issue stores the invoice snapshot of the order. The invoice number is only
+// known inside the repository transaction, so the snapshot is completed there.
+func (s *invoiceService) issue(ctx context.Context, orderUUID pgtype.UUID, order *models.OrderResponse) (db.Invoice, error) {
+	return s.repo.Create(ctx, db.CreateInvoiceParams{
+		Uuid: pgtype.UUID{
+			Bytes: uuid.New(),
+			Valid: true,
+		},
+		OrderUuid: orderUUID,
+	}, func(number int64) ([]byte, error) {
+		return json.Marshal(invoiceSnapshot{
+			Number: fmt.Sprintf("%s%06d", s.numberPrefix, number),
+			Seller: s.seller,
+			Order:  *order,
+		})
+	})
+}
+
+func (s *invoiceService) RenderInvoice(invoice *models.InvoiceResponse, format models.InvoiceFormat) ([]byte, string, error) {
+	switch format {
+	case models.InvoiceFormatHTML:
+		content, err := renderInvoiceHTML(invoice)
+		return content, "text/html; charset=utf-8", err
+	case models.InvoiceFormatPDF, "":
+		if s.font == "" {
+			if format == "" {
+				return s.RenderInvoice(invoice, models.InvoiceFormatHTML)
+			}
+			return nil, "", ErrInvoicePDFDisabled
+		}
+		content, err := renderInvoicePDF(invoice, s.font)
+		return content, "application/pdf", err
+	default:
+		return nil, "", fmt.Errorf("unsupported invoice format %q", format)
+	}
+}
+
+func invoiceToResponse(invoice db.Invoice) (*models.InvoiceResponse, error) {
+	var snapshot invoiceSnapshot
+	err := json.Unmarshal(invoice.Snapshot, &snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read invoice snapshot: %w", err)
+	}
+
+	return &models.InvoiceResponse{
+		ID:       invoice.Uuid.String(),
+		Number:   snapshot.Number,
+		OrderID:  invoice.OrderUuid.String(),
+		IssuedAt: invoice.IssuedAt.Time.Format(time.RFC3339),
+		Seller:   snapshot.Seller,
+		Order:    snapshot.Order,
+	}, nil
+}
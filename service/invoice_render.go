@@ -0,0 +1,196 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/igntnk/stocky-oms/models"
+	"github.com/jung-kurt/gofpdf"
+	"html/template"
+	"path/filepath"
+	"strconv"
+)
+
+var invoiceTemplate = template.Must(template.New("invoice").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Invoice {{.Number}}</title>
+<style>
+body { font-family: sans-serif; font-size: 14px; margin: 40px; }
+table { border-collapse: collapse; width: 100%; }
+th, td { border-bottom: 1px solid #ccc; padding: 6px; text-align: left; }
+.num { text-align: right; }
+.totals td { border: none; }
+</style>
+</head>
+<body>
+<h1>Invoice {{.Number}}</h1>
+<p>Issued: {{.IssuedAt}}<br>Order: {{.OrderID}}</p>
+<p><strong>{{.Seller.Name}}</strong>{{with .Seller.Address}}<br>{{.}}{{end}}{{with .Seller.TaxID}}<br>Tax ID: {{.}}{{end}}</p>
+<p>Customer: {{.Order.UserID}}</p>
+{{with .Order.BillingAddress}}<p>Bill to:<br>{{.Recipient}}<br>{{.Line1}}{{with .Line2}}<br>{{.}}{{end}}<br>{{with .PostalCode}}{{.}} {{end}}{{.City}}{{with .Region}}, {{.}}{{end}}<br>{{.Country}}</p>
+{{end}}<table>
+<tr><th>Product</th><th>Code</th><th class="num">Qty</th><th class="num">Price</th><th class="num">Discount</th><th class="num">Tax</th><th class="num">Total</th></tr>
+{{range .Order.Products}}<tr><td>{{.Name}}</td><td>{{.ProductCode}}</td><td class="num">{{.Amount}}</td><td class="num">{{.Price}}</td><td class="num">{{.Discount}}</td><td class="num">{{.Tax}}</td><td class="num">{{.TotalPrice}}</td></tr>
+{{end}}</table>
+<table class="totals">
+<tr><td class="num">Discount</td><td class="num">{{.Order.Discount}} {{.Order.Currency}}</td></tr>
+<tr><td class="num">Net</td><td class="num">{{.Order.NetAmount}} {{.Order.Currency}}</td></tr>
+<tr><td class="num">Tax</td><td class="num">{{.Order.TaxAmount}} {{.Order.Currency}}</td></tr>
+<tr><td class="num">Shipping</td><td class="num">{{.Order.ShippingCost}} {{.Order.Currency}}</td></tr>
+<tr><td class="num"><strong>Total</strong></td><td class="num"><strong>{{.Order.OrderCost}} {{.Order.Currency}}</strong></td></tr>
+</table>
+</body>
+</html>
+`))
+
+func renderInvoiceHTML(invoice *models.InvoiceResponse) ([]byte, error) {
+	var buf bytes.Buffer
+	err := invoiceTemplate.Execute(&buf, invoice)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render invoice: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// invoiceColumns are the widths in millimeters of the PDF invoice line table
+var invoiceColumns = []float64{82, 14, 20, 20, 20, 24}
+
+// invoiceFontFamily is the name the invoice font is registered under
+const invoiceFontFamily = "invoice"
+
+// CheckInvoiceFont loads the TrueType font PDF invoices are drawn with. Core
+// fonts only cover the cp1252 charset and can't print most names and
+// addresses, so PDF invoices are disabled without one.
+func CheckInvoiceFont(font string) error {
+	_, err := newInvoicePDF(font)
+	return err
+}
+
+func newInvoicePDF(font string) (*gofpdf.Fpdf, error) {
+	if font == "" {
+		return nil, ErrInvoiceFontMissing
+	}
+
+	pdf := gofpdf.New("P", "mm", "A4", filepath.Dir(font))
+	pdf.AddUTF8Font(invoiceFontFamily, "", filepath.Base(font))
+	pdf.AddUTF8Font(invoiceFontFamily, "B", filepath.Base(font))
+	if pdf.Err() {
+		return nil, fmt.Errorf("%w: failed to load %s: %w", ErrInvoiceFontMissing, font, pdf.Error())
+	}
+	return pdf, nil
+}
+
+// renderInvoicePDF lays the invoice out on A4 pages, text is drawn with the
+// TrueType font
+func renderInvoicePDF(invoice *models.InvoiceResponse, font string) ([]byte, error) {
+	pdf, err := newInvoicePDF(font)
+	if err != nil {
+		return nil, err
+	}
+	pdf.SetTitle("Invoice "+invoice.Number, true)
+
+	pdf.AddPage()
+
+	pdf.SetFont(invoiceFontFamily, "B", 18)
+	pdf.CellFormat(0, 10, "Invoice "+invoice.Number, "", 1, "L", false, 0, "")
+
+	pdf.SetFont(invoiceFontFamily, "", 10)
+	pdf.CellFormat(0, 5, "Issued: "+invoice.IssuedAt, "", 1, "L", false, 0, "")
+	pdf.CellFormat(0, 5, "Order: "+invoice.OrderID, "", 1, "L", false, 0, "")
+	pdf.Ln(4)
+
+	pdf.SetFont(invoiceFontFamily, "B", 10)
+	pdf.CellFormat(0, 5, invoice.Seller.Name, "", 1, "L", false, 0, "")
+	pdf.SetFont(invoiceFontFamily, "", 10)
+	if invoice.Seller.Address != "" {
+		pdf.MultiCell(0, 5, invoice.Seller.Address, "", "L", false)
+	}
+	if invoice.Seller.TaxID != "" {
+		pdf.CellFormat(0, 5, "Tax ID: "+invoice.Seller.TaxID, "", 1, "L", false, 0, "")
+	}
+	pdf.Ln(2)
+	pdf.CellFormat(0, 5, "Customer: "+invoice.Order.UserID, "", 1, "L", false, 0, "")
+	if invoice.Order.BillingAddress != nil {
+		pdf.Ln(2)
+		pdf.CellFormat(0, 5, "Bill to:", "", 1, "L", false, 0, "")
+		for _, line := range addressLines(*invoice.Order.BillingAddress) {
+			pdf.CellFormat(0, 5, line, "", 1, "L", false, 0, "")
+		}
+	}
+	pdf.Ln(4)
+
+	pdf.SetFont(invoiceFontFamily, "B", 9)
+	for i, title := range []string{"Product", "Qty", "Price", "Discount", "Tax", "Total"} {
+		align := "R"
+		if i == 0 {
+			align = "L"
+		}
+		pdf.CellFormat(invoiceColumns[i], 7, title, "B", 0, align, false, 0, "")
+	}
+	pdf.Ln(-1)
+
+	pdf.SetFont(invoiceFontFamily, "", 9)
+	for _, line := range invoice.Order.Products {
+		cells := []string{
+			line.Name,
+			strconv.Itoa(line.Amount),
+			line.Price.String(),
+			line.Discount.String(),
+			line.Tax.String(),
+			line.TotalPrice.String(),
+		}
+		for i, cell := range cells {
+			align := "R"
+			if i == 0 {
+				align = "L"
+			}
+			pdf.CellFormat(invoiceColumns[i], 6, cell, "B", 0, align, false, 0, "")
+		}
+		pdf.Ln(-1)
+	}
+	pdf.Ln(4)
+
+	currency := " " + invoice.Order.Currency
+	totals := []struct {
+		title  string
+		amount models.Money
+	}{
+		{"Discount", invoice.Order.Discount},
+		{"Net", invoice.Order.NetAmount},
+		{"Tax", invoice.Order.TaxAmount},
+		{"Shipping", invoice.Order.ShippingCost},
+		{"Total", invoice.Order.OrderCost},
+	}
+	for i, total := range totals {
+		if i == len(totals)-1 {
+			pdf.SetFont(invoiceFontFamily, "B", 10)
+		}
+		pdf.CellFormat(150, 6, total.title, "", 0, "R", false, 0, "")
+		pdf.CellFormat(30, 6, total.amount.String()+currency, "", 1, "R", false, 0, "")
+	}
+
+	var buf bytes.Buffer
+	err = pdf.Output(&buf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render invoice: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// addressLines formats an address the way it is printed on an envelope
+func addressLines(a models.Address) []string {
+	lines := []string{a.Recipient, a.Line1}
+	if a.Line2 != "" {
+		lines = append(lines, a.Line2)
+	}
+
+	city := a.City
+	if a.PostalCode != "" {
+		city = a.PostalCode + " " + city
+	}
+	if a.Region != "" {
+		city += ", " + a.Region
+	}
+	return append(lines, city, a.Country)
+}
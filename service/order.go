@@ -22,17 +22,38 @@ type OrderService interface {
 	GetOrder(ctx context.Context, id string) (*models.OrderResponse, error)
 	ListOrders(ctx context.Context, filter models.OrderFilter) ([]*models.OrderResponse, error)
 	UpdateOrder(ctx context.Context, id string, req models.OrderUpdateRequest) (*models.OrderResponse, error)
+	ChangeOrderStatus(ctx context.Context, id string, req models.OrderStatusChangeRequest) (*models.OrderResponse, error)
+	GetOrderStatusHistory(ctx context.Context, id string) ([]*models.OrderStatusHistoryResponse, error)
+	CancelOrder(ctx context.Context, id string, req models.OrderCancelRequest) (*models.OrderResponse, error)
+	ListStockReturns(ctx context.Context, filter models.StockReturnFilter) ([]*models.StockReturnResponse, error)
+	RecoverSagas(ctx context.Context, olderThan time.Duration) (int, error)
 	DeleteOrder(ctx context.Context, id string) error
 	GetOrderProducts(ctx context.Context, orderID string) ([]*models.ProductDetail, error)
-	AddOrderProduct(ctx context.Context, orderID string, productID string, amount float64) (*models.ProductDetail, error)
+	AddOrderProduct(ctx context.Context, orderID string, productID string, amount int) (*models.OrderResponse, error)
+	ChangeOrderProductAmount(ctx context.Context, orderID string, productID string, amount int) (*models.OrderResponse, error)
+	RemoveOrderProduct(ctx context.Context, orderID string, productID string) (*models.OrderResponse, error)
 	TccCreateOrder(ctx context.Context, req models.OrderCreateRequest) (*models.OrderResponse, error)
+	TryOrder(ctx context.Context, req models.OrderCreateRequest) (*models.OrderResponse, error)
+	ConfirmOrder(ctx context.Context, id string) (*models.OrderResponse, error)
+	CancelPendingOrder(ctx context.Context, id string, reason string) (*models.OrderResponse, error)
+	ExpireReservations(ctx context.Context) (int, error)
 }
 
 type orderService struct {
-	sms         clients.SMSClient
-	oms         clients.OMSClient
-	orderRepo   repository.OrderRepository
-	productRepo repository.ProductRepository
+	sms             clients.SMSClient
+	oms             clients.OMSClient
+	orderRepo       repository.OrderRepository
+	productRepo     repository.ProductRepository
+	stockReturnRepo repository.StockReturnRepository
+	sagaRepo        repository.SagaRepository
+	promotionRepo   repository.PromotionRepository
+	taxRepo         repository.TaxRuleRepository
+	shippingRepo    repository.ShippingMethodRepository
+	customerRepo    repository.CustomerRepository
+	payments        PaymentService
+	reservationTTL  time.Duration
+	currency        CurrencyConverter
+	taxRegion       string
 }
 
 func NewOrderService(
@@ -40,12 +61,32 @@ func NewOrderService(
 	omsClient clients.OMSClient,
 	orderRepo repository.OrderRepository,
 	productRepo repository.ProductRepository,
+	stockReturnRepo repository.StockReturnRepository,
+	sagaRepo repository.SagaRepository,
+	promotionRepo repository.PromotionRepository,
+	taxRepo repository.TaxRuleRepository,
+	shippingRepo repository.ShippingMethodRepository,
+	customerRepo repository.CustomerRepository,
+	payments PaymentService,
+	reservationTTL time.Duration,
+	currency CurrencyConverter,
+	taxRegion string,
 ) OrderService {
 	return &orderService{
-		sms:         smsClient,
-		oms:         omsClient,
-		orderRepo:   orderRepo,
-		productRepo: productRepo,
+		sms:             smsClient,
+		oms:             omsClient,
+		orderRepo:       orderRepo,
+		productRepo:     productRepo,
+		stockReturnRepo: stockReturnRepo,
+		sagaRepo:        sagaRepo,
+		promotionRepo:   promotionRepo,
+		taxRepo:         taxRepo,
+		shippingRepo:    shippingRepo,
+		customerRepo:    customerRepo,
+		payments:        payments,
+		reservationTTL:  reservationTTL,
+		currency:        currency,
+		taxRegion:       taxRegion,
 	}
 }
 
@@ -63,6 +104,14 @@ func (s *orderService) TccCreateOrder(ctx context.Context, req models.OrderCreat
 		UserId:   req.UserID,
 		StaffId:  req.StaffID,
 		Products: products,
+	}, clients.OrderExtras{
+		Currency: s.orderCurrency(req),
+		Region:   s.orderRegion(req),
+		Coupons:  req.Coupons,
+
+		ShippingMethod:  req.ShippingMethod,
+		ShippingAddress: req.ShippingAddress,
+		BillingAddress:  req.BillingAddress,
 	})
 	if err != nil {
 		return nil, err
@@ -73,10 +122,10 @@ func (s *orderService) TccCreateOrder(ctx context.Context, req models.OrderCreat
 		resProducts[i] = models.ProductDetail{
 			ID:          product.Product.Uuid,
 			Name:        product.Product.Name,
-			Price:       product.ResultPrice,
+			Price:       models.MoneyFromFloat(product.ResultPrice),
 			ProductCode: product.ProductCode,
 			Amount:      int(product.Amount),
-			TotalPrice:  product.ResultPrice * float64(product.Amount),
+			TotalPrice:  models.MoneyFromFloat(product.ResultPrice).Mul(int64(product.Amount)),
 		}
 	}
 
@@ -85,24 +134,19 @@ func (s *orderService) TccCreateOrder(ctx context.Context, req models.OrderCreat
 		Comment:      res.Comment,
 		UserID:       res.UserId,
 		StaffID:      res.StaffId,
-		OrderCost:    res.OrderCost,
+		OrderCost:    models.MoneyFromFloat(res.OrderCost),
+		Currency:     s.orderCurrency(req),
 		Status:       models.OrderStatus(res.Status),
 		CreationDate: res.CreationDate.String(),
 		Products:     resProducts,
 	}, nil
 }
 
-func (s *orderService) AddOrderProduct(ctx context.Context, orderID string, productID string, amount float64) (*models.ProductDetail, error) {
-	return s.orderRepo.AddOrderProduct(ctx, orderID, productID, amount)
-}
-
 func (s *orderService) CreateNakedOrder(ctx context.Context, req models.OrderCreateRequest) (*models.Order, error) {
 	// Create order with transaction
 	orderUUID := uuid.New()
-	cost, err := repository.Float64ToNumericWithPrecision(200)
-	if err != nil {
-		return nil, err
-	}
+	// products are added later, an empty order costs nothing
+	cost := repository.MoneyToNumeric(models.Money{})
 
 	order, err := s.orderRepo.CreateNakedOrder(ctx, db.CreateOrderParams{
 		Uuid: pgtype.UUID{
@@ -113,6 +157,7 @@ func (s *orderService) CreateNakedOrder(ctx context.Context, req models.OrderCre
 		UserID:    req.UserID,
 		StaffID:   req.StaffID,
 		OrderCost: cost,
+		Currency:  s.orderCurrency(req),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create order: %w", err)
@@ -123,6 +168,7 @@ func (s *orderService) CreateNakedOrder(ctx context.Context, req models.OrderCre
 		Comment:      order.Comment.String,
 		UserID:       order.UserID,
 		StaffID:      order.StaffID,
+		Currency:     order.Currency,
 		Status:       models.OrderStatus(order.Status),
 		CreationDate: order.CreationDate.Time,
 	}, nil
@@ -131,12 +177,12 @@ func (s *orderService) CreateNakedOrder(ctx context.Context, req models.OrderCre
 
 func (s *orderService) CreateOrder(ctx context.Context, req models.OrderCreateRequest) (*models.OrderResponse, error) {
 	// Validate products exist and calculate total cost
-	products, totalCost, err := s.validateOrderProducts(ctx, req.Products)
+	priced, err := s.priceOrder(ctx, req)
 	if err != nil {
 		return nil, err
 	}
 
-	cost, err := repository.Float64ToNumericWithPrecision(totalCost)
+	shippingAddress, billingAddress, err := s.orderAddresses(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -148,12 +194,25 @@ func (s *orderService) CreateOrder(ctx context.Context, req models.OrderCreateRe
 			Bytes: orderUUID,
 			Valid: true,
 		},
-		Comment:   pgtype.Text{String: req.Comment, Valid: true},
-		UserID:    req.UserID,
-		StaffID:   req.StaffID,
-		OrderCost: cost,
-	}, products)
+		Comment:            pgtype.Text{String: req.Comment, Valid: true},
+		UserID:             req.UserID,
+		StaffID:            req.StaffID,
+		OrderCost:          repository.MoneyToNumeric(priced.total),
+		Currency:           s.orderCurrency(req),
+		Discount:           repository.MoneyToNumeric(priced.discount),
+		PromotionUuids:     priced.promotions,
+		Region:             pgtype.Text{String: s.orderRegion(req), Valid: true},
+		NetAmount:          repository.MoneyToNumeric(priced.net),
+		TaxAmount:          repository.MoneyToNumeric(priced.tax),
+		ShippingMethodUuid: priced.shippingMethod,
+		ShippingCost:       repository.MoneyToNumeric(priced.shipping),
+		ShippingAddress:    shippingAddress,
+		BillingAddress:     billingAddress,
+	}, priced.products)
 	if err != nil {
+		if errors.Is(err, repository.ErrPromotionUnavailable) {
+			return nil, ErrPromotionUnavailable
+		}
 		return nil, fmt.Errorf("failed to create order: %w", err)
 	}
 
@@ -170,7 +229,12 @@ func (s *orderService) CreateOrder(ctx context.Context, req models.OrderCreateRe
 func (s *orderService) CreateSagaOrder(ctx context.Context, req models.OrderCreateRequest) (res *models.OrderResponse, err error) {
 	products := req.Products
 
-	prods, totalCost, err := s.validateOrderProducts(ctx, req.Products)
+	priced, err := s.priceOrder(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	shippingAddress, billingAddress, err := s.orderAddresses(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -183,41 +247,67 @@ func (s *orderService) CreateSagaOrder(ctx context.Context, req models.OrderCrea
 		}
 	}
 
-	_, err = s.sms.RemoveCoupleProducts(ctx, reqPr)
+	orderUUID := uuid.New()
+	sagaID, err := s.startSaga(ctx, orderUUID, reqPr)
 	if err != nil {
 		return nil, err
 	}
 
-	defer func() {
-		if err != nil {
-			_, _ = s.sms.WriteOnCoupleProducts(ctx, reqPr)
-		}
-	}()
+	// Saga bookkeeping must survive the caller going away after SMS was called
+	recordCtx := context.WithoutCancel(ctx)
 
-	var cost pgtype.Numeric
-	cost, err = repository.Float64ToNumericWithPrecision(totalCost)
+	_, err = s.sms.RemoveCoupleProducts(ctx, reqPr)
 	if err != nil {
-		return nil, err
+		return nil, errors.Join(err, s.sagaRepo.SetStep(recordCtx, sagaID, db.SagaStepFailed, err.Error()))
+	}
+
+	err = s.sagaRepo.SetStep(recordCtx, sagaID, db.SagaStepStockRemoved, "")
+	if err != nil {
+		return nil, errors.Join(
+			fmt.Errorf("failed to record saga step: %w", err),
+			s.compensateSaga(recordCtx, sagaID, reqPr, err),
+		)
 	}
 
 	var order db.Order
 	order, err = s.orderRepo.CreateWithProducts(ctx, db.CreateOrderParams{
 		Uuid: pgtype.UUID{
-			Bytes: uuid.New(),
+			Bytes: orderUUID,
 			Valid: true,
 		},
 		Comment: pgtype.Text{
 			String: req.Comment,
 			Valid:  true,
 		},
-		UserID:    req.UserID,
-		StaffID:   req.StaffID,
-		OrderCost: cost,
-	}, prods)
+		UserID:             req.UserID,
+		StaffID:            req.StaffID,
+		OrderCost:          repository.MoneyToNumeric(priced.total),
+		Currency:           s.orderCurrency(req),
+		Discount:           repository.MoneyToNumeric(priced.discount),
+		PromotionUuids:     priced.promotions,
+		Region:             pgtype.Text{String: s.orderRegion(req), Valid: true},
+		NetAmount:          repository.MoneyToNumeric(priced.net),
+		TaxAmount:          repository.MoneyToNumeric(priced.tax),
+		ShippingMethodUuid: priced.shippingMethod,
+		ShippingCost:       repository.MoneyToNumeric(priced.shipping),
+		ShippingAddress:    shippingAddress,
+		BillingAddress:     billingAddress,
+		StockTaken:         true,
+	}, priced.products)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create order: %w", err)
+		if errors.Is(err, repository.ErrPromotionUnavailable) {
+			err = ErrPromotionUnavailable
+		}
+		return nil, errors.Join(
+			fmt.Errorf("failed to create order: %w", err),
+			s.compensateSaga(recordCtx, sagaID, reqPr, err),
+		)
 	}
 
+	// The order is stored at this point. If the step is not recorded,
+	// recovery finds the order and finishes the saga.
+	_ = s.sagaRepo.SetStep(recordCtx, sagaID, db.SagaStepOrderPersisted, "")
+
 	// Fetch products with details for response
 	orderProducts, err := s.orderRepo.GetOrderProducts(ctx, order.Uuid.String())
 	if err != nil {
@@ -227,40 +317,79 @@ func (s *orderService) CreateSagaOrder(ctx context.Context, req models.OrderCrea
 	return s.buildOrderResponse(order, orderProducts)
 }
 
+// validateOrderProducts prices the products from the product table in the
+// order currency, converting prices set in other currencies. The products are
+// returned in the order of the lines.
 func (s *orderService) validateOrderProducts(
 	ctx context.Context,
+	currency string,
 	products []models.OrderProductInput,
-) ([]db.AddProductToOrderParams, float64, error) {
-	var totalCost float64
+) ([]db.AddProductToOrderParams, []db.Product, error) {
 	var repoProducts []db.AddProductToOrderParams
+	var dbProducts []db.Product
 
 	for _, item := range products {
 		// Get product details
 		product, err := s.productRepo.Get(ctx, item.ProductID.String())
 		if err != nil {
-			return nil, 0, fmt.Errorf("product %s not found: %w", item.ProductID, err)
+			return nil, nil, fmt.Errorf("product %s not found: %w", item.ProductID, err)
 		}
 
-		cost, err := repository.NumericToFloat64(product.CustomerCost)
+		cost, historyUUID, err := s.productPrice(ctx, product, currency)
 		if err != nil {
-			return nil, 0, err
+			return nil, nil, fmt.Errorf("product %s: %w", item.ProductID, err)
 		}
-		// Calculate item total
-		itemTotal := cost * float64(item.Amount)
 
 		repoProducts = append(repoProducts, db.AddProductToOrderParams{
 			ProductCode: pgtype.UUID{
 				Bytes: item.ProductID,
 				Valid: true,
 			},
-			ResultPrice: product.CustomerCost,
-			Amount:      int32(item.Amount),
+			ResultPrice:      repository.MoneyToNumeric(cost),
+			Amount:           int32(item.Amount),
+			PriceHistoryUuid: historyUUID,
 		})
+		dbProducts = append(dbProducts, product)
+	}
 
-		totalCost += itemTotal
+	return repoProducts, dbProducts, nil
+}
+
+// productPrice returns the current price of the product in the order currency
+// and the price history entry it comes from. Products without history are
+// priced from the product row.
+func (s *orderService) productPrice(ctx context.Context, product db.Product, currency string) (models.Money, pgtype.UUID, error) {
+	costNum, costCurrency := product.CustomerCost, product.Currency
+	var historyUUID pgtype.UUID
+
+	price, err := s.productRepo.GetCurrentPrice(ctx, product.Uuid)
+	switch {
+	case err == nil:
+		costNum, costCurrency = price.CustomerCost, price.Currency
+		historyUUID = price.Uuid
+	case !errors.Is(err, repository.ErrPriceNotFound):
+		return models.Money{}, pgtype.UUID{}, fmt.Errorf("failed to get current price: %w", err)
 	}
 
-	return repoProducts, totalCost, nil
+	cost, err := repository.NumericToMoney(costNum)
+	if err != nil {
+		return models.Money{}, pgtype.UUID{}, err
+	}
+
+	cost, err = s.currency.Convert(cost, costCurrency, currency)
+	if err != nil {
+		return models.Money{}, pgtype.UUID{}, err
+	}
+
+	return cost, historyUUID, nil
+}
+
+// orderCurrency returns the requested currency or the default one
+func (s *orderService) orderCurrency(req models.OrderCreateRequest) string {
+	if req.Currency != "" {
+		return req.Currency
+	}
+	return s.currency.Default()
 }
 
 func (s *orderService) GetOrder(ctx context.Context, id string) (*models.OrderResponse, error) {
@@ -277,6 +406,11 @@ func (s *orderService) GetOrder(ctx context.Context, id string) (*models.OrderRe
 		return nil, fmt.Errorf("failed to get order: %w", err)
 	}
 
+	err = checkOrderAccess(ctx, order.UserID)
+	if err != nil {
+		return nil, err
+	}
+
 	products, err := s.orderRepo.GetOrderProducts(ctx, order.Uuid.String())
 	if err != nil {
 		return nil, fmt.Errorf("failed to get order products: %w", err)
@@ -286,12 +420,17 @@ func (s *orderService) GetOrder(ctx context.Context, id string) (*models.OrderRe
 }
 
 func (s *orderService) ListOrders(ctx context.Context, filter models.OrderFilter) ([]*models.OrderResponse, error) {
-	dbOrders, err := s.orderRepo.List(ctx, int32(filter.Limit), int32(filter.Offset), db.OrderStatus(filter.Status))
+	// customers only list their own orders
+	if userID, limited := customerID(ctx); limited {
+		filter.UserID = userID
+	}
+
+	dbOrders, err := s.orderRepo.List(ctx, int32(filter.Limit), int32(filter.Offset), db.OrderStatus(filter.Status), filter.UserID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list orders: %w", err)
 	}
 
-	var responses []*models.OrderResponse
+	responses := make([]*models.OrderResponse, 0, len(dbOrders))
 	for _, order := range dbOrders {
 		products, err := s.orderRepo.GetOrderProducts(ctx, order.Uuid.String())
 		if err != nil {
@@ -314,26 +453,36 @@ func (s *orderService) UpdateOrder(ctx context.Context, id string, req models.Or
 		return nil, ErrInvalidOrderID
 	}
 
-	updateParams := db.UpdateOrderParams{
-		Uuid: pgtype.UUID{
-			Bytes: orderUUID,
-			Valid: true,
-		},
-	}
-
-	if req.Comment != nil {
-		updateParams.Comment = pgtype.Text{String: *req.Comment}
-	}
+	var order db.Order
 	if req.Status != nil {
-		updateParams.Status = db.OrderStatus(*req.Status)
+		order, err = s.changeOrderStatus(ctx, orderUUID, models.OrderStatusChangeRequest{
+			Status: *req.Status,
+			Reason: req.Reason,
+		})
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	order, err := s.orderRepo.UpdateOrder(ctx, updateParams)
-	if err != nil {
-		if errors.Is(err, repository.ErrOrderNotFound) {
-			return nil, ErrOrderNotFound
+	if req.Comment != nil || req.Status == nil {
+		updateParams := db.UpdateOrderParams{
+			Uuid: pgtype.UUID{
+				Bytes: orderUUID,
+				Valid: true,
+			},
+		}
+
+		if req.Comment != nil {
+			updateParams.Comment = pgtype.Text{String: *req.Comment, Valid: true}
+		}
+
+		order, err = s.orderRepo.UpdateOrder(ctx, updateParams)
+		if err != nil {
+			if errors.Is(err, repository.ErrOrderNotFound) {
+				return nil, ErrOrderNotFound
+			}
+			return nil, fmt.Errorf("failed to update order: %w", err)
 		}
-		return nil, fmt.Errorf("failed to update order: %w", err)
 	}
 
 	products, err := s.orderRepo.GetOrderProducts(ctx, order.Uuid.String())
@@ -354,6 +503,9 @@ func (s *orderService) DeleteOrder(ctx context.Context, id string) error {
 		if errors.Is(err, repository.ErrOrderNotFound) {
 			return ErrOrderNotFound
 		}
+		if errors.Is(err, repository.ErrOrderInvoiced) {
+			return ErrOrderInvoiced
+		}
 		return fmt.Errorf("failed to delete order: %w", err)
 	}
 
@@ -361,25 +513,36 @@ func (s *orderService) DeleteOrder(ctx context.Context, id string) error {
 }
 
 func (s *orderService) GetOrderProducts(ctx context.Context, orderID string) ([]*models.ProductDetail, error) {
-	products, err := s.orderRepo.GetOrderProducts(ctx, orderID)
+	orderUUID, err := uuid.Parse(orderID)
+	if err != nil {
+		return nil, ErrInvalidOrderID
+	}
+
+	order, err := s.orderRepo.Get(ctx, orderUUID.String())
+	if err != nil {
+		if errors.Is(err, repository.ErrOrderNotFound) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+
+	err = checkOrderAccess(ctx, order.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	products, err := s.orderRepo.GetOrderProducts(ctx, orderUUID.String())
 	if err != nil {
 		return nil, fmt.Errorf("failed to get order products: %w", err)
 	}
 
-	var result []*models.ProductDetail
+	result := make([]*models.ProductDetail, 0, len(products))
 	for _, p := range products {
-		resPrice, err := repository.NumericToFloat64(p.ResultPrice)
+		detail, err := productDetail(p)
 		if err != nil {
 			return nil, err
 		}
-
-		result = append(result, &models.ProductDetail{
-			ID:         p.ProductUuid.String(),
-			Name:       p.ProductName,
-			Price:      resPrice,
-			Amount:     int(p.Amount),
-			TotalPrice: resPrice * float64(p.Amount),
-		})
+		result = append(result, &detail)
 	}
 
 	return result, nil
@@ -397,35 +560,129 @@ func (s *orderService) buildOrderResponse(
 
 	productDetails := make([]models.ProductDetail, 0, len(products))
 	for _, p := range products {
-		resPrice, err := repository.NumericToFloat64(p.ResultPrice)
+		detail, err := productDetail(p)
 		if err != nil {
 			return nil, err
 		}
+		productDetails = append(productDetails, detail)
+	}
 
-		productDetails = append(productDetails, models.ProductDetail{
-			ID:          p.ProductUuid.String(),
-			Name:        p.ProductName,
-			ProductCode: p.ProductCode.String(),
-			Price:       resPrice,
-			Amount:      int(p.Amount),
-			TotalPrice:  resPrice * float64(p.Amount),
-		})
+	resOrderCost, err := repository.NumericToMoney(order.OrderCost)
+	if err != nil {
+		return nil, err
+	}
+
+	resDiscount, err := repository.NumericToMoney(order.Discount)
+	if err != nil {
+		return nil, err
 	}
 
-	resOrderCost, err := repository.NumericToFloat64(order.OrderCost)
+	resNet, err := repository.NumericToMoney(order.NetAmount)
+	if err != nil {
+		return nil, err
+	}
+
+	resTax, err := repository.NumericToMoney(order.TaxAmount)
+	if err != nil {
+		return nil, err
+	}
+
+	resShipping, err := repository.NumericToMoney(order.ShippingCost)
+	if err != nil {
+		return nil, err
+	}
+
+	shippingAddress, err := unmarshalAddress(order.ShippingAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	billingAddress, err := unmarshalAddress(order.BillingAddress)
 	if err != nil {
 		return nil, err
 	}
 
 	return &models.OrderResponse{
-		ID:           order.Uuid.String(),
-		Comment:      order.Comment.String,
-		UserID:       order.UserID,
-		StaffID:      order.StaffID,
-		OrderCost:    resOrderCost,
-		Status:       models.OrderStatus(order.Status),
-		CreationDate: order.CreationDate.Time.Format(time.RFC3339),
-		FinishDate:   finishDate,
-		Products:     productDetails,
+		ID:               order.Uuid.String(),
+		Comment:          order.Comment.String,
+		UserID:           order.UserID,
+		StaffID:          order.StaffID,
+		OrderCost:        resOrderCost,
+		NetAmount:        resNet,
+		TaxAmount:        resTax,
+		Region:           order.Region,
+		Discount:         resDiscount,
+		ShippingCost:     resShipping,
+		ShippingMethodID: optionalUUIDString(order.ShippingMethodUuid),
+		ShippingAddress:  shippingAddress,
+		BillingAddress:   billingAddress,
+		Promotions:       uuidStrings(order.PromotionUuids),
+		Currency:         order.Currency,
+		Status:           models.OrderStatus(order.Status),
+		CreationDate:     order.CreationDate.Time.Format(time.RFC3339),
+		FinishDate:       finishDate,
+		Products:         productDetails,
 	}, nil
 }
+
+func productDetail(p db.GetOrderProductsRow) (models.ProductDetail, error) {
+	listPrice, err := repository.NumericToMoney(p.ListPrice)
+	if err != nil {
+		return models.ProductDetail{}, err
+	}
+
+	resPrice, err := repository.NumericToMoney(p.ResultPrice)
+	if err != nil {
+		return models.ProductDetail{}, err
+	}
+
+	discount, err := repository.NumericToMoney(p.Discount)
+	if err != nil {
+		return models.ProductDetail{}, err
+	}
+
+	taxRate, err := repository.NumericToMoney(p.TaxRate)
+	if err != nil {
+		return models.ProductDetail{}, err
+	}
+
+	tax, err := repository.NumericToMoney(p.TaxAmount)
+	if err != nil {
+		return models.ProductDetail{}, err
+	}
+
+	return models.ProductDetail{
+		ID:             p.ProductUuid.String(),
+		Name:           p.ProductName,
+		ProductCode:    p.ProductCode.String(),
+		ListPrice:      listPrice,
+		Price:          resPrice,
+		Amount:         int(p.Amount),
+		Discount:       discount,
+		TotalPrice:     resPrice.Mul(int64(p.Amount)).Sub(discount),
+		TaxRate:        taxRate,
+		Tax:            tax,
+		TaxInclusive:   p.TaxInclusive,
+		Promotions:     uuidStrings(p.PromotionUuids),
+		PriceHistoryID: optionalUUIDString(p.PriceHistoryUuid),
+	}, nil
+}
+
+func optionalUUIDString(id pgtype.UUID) string {
+	if !id.Valid {
+		return ""
+	}
+	return id.String()
+}
+
+func uuidStrings(uuids []pgtype.UUID) []string {
+	if len(uuids) == 0 {
+		return nil
+	}
+
+	res := make([]string, 0, len(uuids))
+	for _, id := range uuids {
+		res = append(res, id.String())
+	}
+	return res
+}
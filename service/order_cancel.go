@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/igntnk/stocky-oms/db"
+	"github.com/igntnk/stocky-oms/models"
+	"github.com/igntnk/stocky-oms/repository"
+	"github.com/jackc/pgx/v5/pgtype"
+	"time"
+)
+
+// stockReturnLease is how long a claimed stock return is left to its caller.
+// A return still in progress after it was abandoned and is claimed again, it
+// must outlast the SMS call with all its tries.
+const stockReturnLease = 5 * time.Minute
+
+// CancelOrder cancels the order and writes its products back to SMS if they
+// were written off when the order was created. Calling it again for a
+// cancelled order retries a failed stock return.
+func (s *orderService) CancelOrder(ctx context.Context, id string, req models.OrderCancelRequest) (*models.OrderResponse, error) {
+	orderUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, ErrInvalidOrderID
+	}
+
+	order, err := s.cancelOrder(ctx, orderUUID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	products, err := s.orderRepo.GetOrderProducts(ctx, order.Uuid.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order products: %w", err)
+	}
+
+	return s.buildOrderResponse(order, products)
+}
+
+func (s *orderService) cancelOrder(ctx context.Context, orderUUID uuid.UUID, req models.OrderCancelRequest) (db.Order, error) {
+	order, err := s.orderRepo.Get(ctx, orderUUID.String())
+	if err != nil {
+		if errors.Is(err, repository.ErrOrderNotFound) {
+			return db.Order{}, ErrOrderNotFound
+		}
+		return db.Order{}, fmt.Errorf("failed to get order: %w", err)
+	}
+
+	if order.Status == db.OrderStatusPending {
+		return s.cancelPending(ctx, order, callerActor(ctx, order.StaffID), req.Reason)
+	}
+
+	if order.Status != db.OrderStatusCancelled {
+		from := models.OrderStatus(order.Status)
+		if !canTransition(from, models.OrderStatusCancelled) {
+			return db.Order{}, fmt.Errorf("%w: %s -> %s", ErrInvalidStatusTransition, from, models.OrderStatusCancelled)
+		}
+
+		order, err = s.orderRepo.Cancel(ctx, db.AddOrderStatusHistoryParams{
+			Uuid: pgtype.UUID{
+				Bytes: uuid.New(),
+				Valid: true,
+			},
+			OrderUuid:  order.Uuid,
+			FromStatus: order.Status,
+			Actor:      callerActor(ctx, order.StaffID),
+			Reason: pgtype.Text{
+				String: req.Reason,
+				Valid:  req.Reason != "",
+			},
+		})
+		if err != nil {
+			if errors.Is(err, repository.ErrOrderStatusChanged) {
+				return db.Order{}, ErrOrderStatusConflict
+			}
+			return db.Order{}, fmt.Errorf("failed to cancel order: %w", err)
+		}
+	}
+
+	err = s.returnStock(ctx, order)
+	if err != nil {
+		return db.Order{}, err
+	}
+
+	// The authorization is released on every cancel so a failed void is retried
+	_, err = s.payments.VoidOrder(ctx, order.Uuid.String())
+	if err != nil && !errors.Is(err, ErrPaymentNotFound) {
+		return db.Order{}, err
+	}
+
+	return order, nil
+}
+
+// returnStock writes the products of a cancelled order back to SMS. The outcome
+// is recorded on the stock return so failed returns can be found and retried.
+// Orders that never took stock have nothing to return.
+func (s *orderService) returnStock(ctx context.Context, order db.Order) error {
+	if !order.StockTaken {
+		return nil
+	}
+
+	orderID := order.Uuid.String()
+
+	_, err := s.stockReturnRepo.Claim(ctx, orderID, stockReturnLease)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrStockReturnDone),
+			errors.Is(err, repository.ErrNoStockReturn):
+			return nil
+		case errors.Is(err, repository.ErrStockReturnClaimed):
+			return ErrStockReturnInProgress
+		}
+		return fmt.Errorf("failed to claim stock return: %w", err)
+	}
+
+	// Bookkeeping must survive the caller going away after SMS was called
+	recordCtx := context.WithoutCancel(ctx)
+
+	products, err := s.orderRepo.GetOrderProducts(ctx, orderID)
+	if err != nil {
+		return errors.Join(
+			fmt.Errorf("failed to get order products: %w", err),
+			s.stockReturnRepo.Fail(recordCtx, orderID, err.Error()),
+		)
+	}
+
+	if len(products) > 0 {
+		reqPr := make([]models.ProductWriteOffRequest, len(products))
+		for i, product := range products {
+			reqPr[i] = models.ProductWriteOffRequest{
+				Uuid:   product.ProductCode.String(),
+				Amount: float64(product.Amount),
+			}
+		}
+
+		_, err = s.sms.WriteOnCoupleProducts(ctx, reqPr)
+		if err != nil {
+			return errors.Join(
+				fmt.Errorf("%w: %w", ErrStockReturnFailed, err),
+				s.stockReturnRepo.Fail(recordCtx, orderID, err.Error()),
+			)
+		}
+	}
+
+	err = s.stockReturnRepo.Complete(recordCtx, orderID)
+	if err != nil {
+		return fmt.Errorf("failed to complete stock return: %w", err)
+	}
+
+	return nil
+}
+
+func (s *orderService) ListStockReturns(ctx context.Context, filter models.StockReturnFilter) ([]*models.StockReturnResponse, error) {
+	stockReturns, err := s.stockReturnRepo.List(ctx, db.StockReturnStatus(filter.Status), int32(filter.Limit), int32(filter.Offset))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stock returns: %w", err)
+	}
+
+	result := make([]*models.StockReturnResponse, 0, len(stockReturns))
+	for _, r := range stockReturns {
+		result = append(result, &models.StockReturnResponse{
+			OrderID:   r.OrderUuid.String(),
+			Status:    models.StockReturnStatus(r.Status),
+			Attempts:  int(r.Attempts),
+			LastError: r.LastError.String,
+			CreatedAt: r.CreatedAt.Time.Format(time.RFC3339),
+			UpdatedAt: r.UpdatedAt.Time.Format(time.RFC3339),
+		})
+	}
+
+	return result, nil
+}
@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/igntnk/stocky-oms/clients"
+	"github.com/igntnk/stocky-oms/db"
+	"github.com/igntnk/stocky-oms/models"
+	"reflect"
+	"testing"
+)
+
+func TestCancelOrder(t *testing.T) {
+	orderUUID := testUUID(1)
+	lines := []db.GetOrderProductsRow{{OrderUuid: orderUUID, ProductCode: testUUID(7), Amount: 2}}
+	wantReturned := []models.ProductWriteOffRequest{{Uuid: testUUID(7).String(), Amount: 2}}
+
+	// noReturn is the status of orders without a stock return
+	const noReturn db.StockReturnStatus = ""
+
+	tests := []struct {
+		name       string
+		status     db.OrderStatus
+		stockTaken bool
+		// stockReturn is the status of the stock return before the cancel
+		stockReturn db.StockReturnStatus
+		authorized  bool
+		smsErr      error
+		wantErr     error
+		wantStatus  db.OrderStatus
+		wantReturn  db.StockReturnStatus
+		wantWriteOn bool
+		wantPayment []db.PaymentStatus
+	}{
+		{
+			name:        "stock is returned",
+			status:      db.OrderStatusProcessing,
+			stockTaken:  true,
+			wantStatus:  db.OrderStatusCancelled,
+			wantReturn:  db.StockReturnStatusCompleted,
+			wantWriteOn: true,
+			wantPayment: []db.PaymentStatus{},
+		},
+		{
+			name:        "order that took no stock",
+			status:      db.OrderStatusNew,
+			wantStatus:  db.OrderStatusCancelled,
+			wantReturn:  noReturn,
+			wantPayment: []db.PaymentStatus{},
+		},
+		{
+			name:        "failed return",
+			status:      db.OrderStatusNew,
+			stockTaken:  true,
+			smsErr:      errors.New("sms is down"),
+			wantErr:     ErrStockReturnFailed,
+			wantStatus:  db.OrderStatusCancelled,
+			wantReturn:  db.StockReturnStatusFailed,
+			wantWriteOn: true,
+			wantPayment: []db.PaymentStatus{},
+		},
+		{
+			name:        "failed return is retried",
+			status:      db.OrderStatusCancelled,
+			stockTaken:  true,
+			stockReturn: db.StockReturnStatusFailed,
+			wantStatus:  db.OrderStatusCancelled,
+			wantReturn:  db.StockReturnStatusCompleted,
+			wantWriteOn: true,
+			wantPayment: []db.PaymentStatus{},
+		},
+		{
+			name:        "return in progress",
+			status:      db.OrderStatusCancelled,
+			stockTaken:  true,
+			stockReturn: db.StockReturnStatusInProgress,
+			wantErr:     ErrStockReturnInProgress,
+			wantStatus:  db.OrderStatusCancelled,
+			wantReturn:  db.StockReturnStatusInProgress,
+			wantPayment: []db.PaymentStatus{},
+		},
+		{
+			name:        "stock returned already",
+			status:      db.OrderStatusCancelled,
+			stockTaken:  true,
+			stockReturn: db.StockReturnStatusCompleted,
+			wantStatus:  db.OrderStatusCancelled,
+			wantReturn:  db.StockReturnStatusCompleted,
+			wantPayment: []db.PaymentStatus{},
+		},
+		{
+			name:        "authorization is voided",
+			status:      db.OrderStatusProcessing,
+			stockTaken:  true,
+			authorized:  true,
+			wantStatus:  db.OrderStatusCancelled,
+			wantReturn:  db.StockReturnStatusCompleted,
+			wantWriteOn: true,
+			wantPayment: []db.PaymentStatus{db.PaymentStatusVoided},
+		},
+		{
+			name:        "completed order",
+			status:      db.OrderStatusCompleted,
+			stockTaken:  true,
+			wantErr:     ErrInvalidStatusTransition,
+			wantStatus:  db.OrderStatusCompleted,
+			wantReturn:  noReturn,
+			wantPayment: []db.PaymentStatus{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			order := db.Order{
+				Uuid:       orderUUID,
+				StaffID:    "staff",
+				OrderCost:  cents(2500),
+				Currency:   "RUB",
+				Status:     tt.status,
+				StockTaken: tt.stockTaken,
+			}
+			stockReturns := newFakeStockReturnRepo()
+			if tt.stockReturn != noReturn {
+				stockReturns = newFakeStockReturnRepo(db.OrderStockReturn{OrderUuid: orderUUID, Status: tt.stockReturn})
+			}
+			orderRepo := newFakeOrderRepo(order)
+			orderRepo.products[orderUUID.String()] = lines
+			orderRepo.stockReturns = stockReturns
+			paymentRepo := &fakePaymentRepo{}
+			payments := NewPaymentService(clients.NewFakePaymentProvider(models.Money{}), paymentRepo, orderRepo)
+			sms := &fakeSMSClient{err: tt.smsErr}
+			s := &orderService{orderRepo: orderRepo, stockReturnRepo: stockReturns, payments: payments, sms: sms}
+
+			if tt.authorized {
+				_, err := payments.AuthorizeOrder(ctx, orderUUID.String())
+				if err != nil {
+					t.Fatalf("AuthorizeOrder() error = %v", err)
+				}
+			}
+
+			_, err := s.cancelOrder(ctx, uuid.UUID(orderUUID.Bytes), models.OrderCancelRequest{})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("cancelOrder() error = %v, want %v", err, tt.wantErr)
+			}
+
+			stored, _ := orderRepo.Get(ctx, orderUUID.String())
+			if stored.Status != tt.wantStatus {
+				t.Errorf("order status = %s, want %s", stored.Status, tt.wantStatus)
+			}
+			if got := stockReturns.returns[orderUUID.String()].Status; got != tt.wantReturn {
+				t.Errorf("stock return = %q, want %q", got, tt.wantReturn)
+			}
+
+			var wantWriteOns [][]models.ProductWriteOffRequest
+			if tt.wantWriteOn {
+				wantWriteOns = [][]models.ProductWriteOffRequest{wantReturned}
+			}
+			if !reflect.DeepEqual(sms.writeOns, wantWriteOns) {
+				t.Errorf("stock written back = %v, want %v", sms.writeOns, wantWriteOns)
+			}
+			if got := paymentRepo.statuses(); !reflect.DeepEqual(got, tt.wantPayment) {
+				t.Errorf("payments = %v, want %v", got, tt.wantPayment)
+			}
+		})
+	}
+}
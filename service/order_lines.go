@@ -0,0 +1,334 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/igntnk/stocky-oms/db"
+	"github.com/igntnk/stocky-oms/models"
+	"github.com/igntnk/stocky-oms/repository"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// AddOrderProduct adds amount units of the product to a new order. A product
+// that is already in the order gets its quantity increased.
+func (s *orderService) AddOrderProduct(ctx context.Context, orderID string, productID string, amount int) (*models.OrderResponse, error) {
+	return s.changeOrderLine(ctx, orderID, productID, int32(amount), true)
+}
+
+// ChangeOrderProductAmount sets the quantity of a product in a new order
+func (s *orderService) ChangeOrderProductAmount(ctx context.Context, orderID string, productID string, amount int) (*models.OrderResponse, error) {
+	return s.changeOrderLine(ctx, orderID, productID, int32(amount), false)
+}
+
+// RemoveOrderProduct removes a product from a new order
+func (s *orderService) RemoveOrderProduct(ctx context.Context, orderID string, productID string) (*models.OrderResponse, error) {
+	return s.changeOrderLine(ctx, orderID, productID, 0, false)
+}
+
+// maxLineChangeTries is how often a line change is tried while other changes
+// of the same order get in between
+const maxLineChangeTries = 3
+
+// changeOrderLine sets the quantity of the product line to amount, or adds
+// amount to it when add is set, and recalculates the order. New lines are
+// priced at the current product price without promotions. If the order took
+// its stock from SMS, the stock is adjusted by the difference before the
+// order is stored and the adjustment is reverted if storing fails. A change
+// that raced with another change of the order is reverted and tried again
+// from the new lines.
+func (s *orderService) changeOrderLine(ctx context.Context, orderID, productID string, amount int32, add bool) (*models.OrderResponse, error) {
+	for try := 1; ; try++ {
+		order, err := s.tryChangeOrderLine(ctx, orderID, productID, amount, add)
+		if errors.Is(err, ErrOrderLinesConflict) && try < maxLineChangeTries {
+			continue
+		}
+		return order, err
+	}
+}
+
+func (s *orderService) tryChangeOrderLine(ctx context.Context, orderID, productID string, amount int32, add bool) (*models.OrderResponse, error) {
+	orderUUID, err := uuid.Parse(orderID)
+	if err != nil {
+		return nil, ErrInvalidOrderID
+	}
+
+	productUUID, err := uuid.Parse(productID)
+	if err != nil {
+		return nil, ErrInvalidProductID
+	}
+
+	order, err := s.orderRepo.Get(ctx, orderUUID.String())
+	if err != nil {
+		if errors.Is(err, repository.ErrOrderNotFound) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+
+	if order.Status != db.OrderStatusNew {
+		return nil, ErrOrderNotEditable
+	}
+
+	rows, err := s.orderRepo.GetOrderProducts(ctx, order.Uuid.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order products: %w", err)
+	}
+
+	lines := make([]db.AddProductToOrderParams, 0, len(rows)+1)
+	products := make([]db.Product, 0, len(rows)+1)
+	line := -1
+	for i, row := range rows {
+		product, err := s.productRepo.Get(ctx, row.ProductCode.String())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get product %s: %w", row.ProductCode, err)
+		}
+
+		lines = append(lines, orderLine(row))
+		products = append(products, product)
+		if row.ProductCode.Bytes == productUUID {
+			line = i
+		}
+	}
+
+	var before int32
+	if line >= 0 {
+		before = lines[line].Amount
+	} else if !add {
+		return nil, ErrOrderProductNotFound
+	}
+
+	after := amount
+	if add {
+		after += before
+	}
+
+	switch {
+	case line < 0:
+		product, err := s.productRepo.Get(ctx, productUUID.String())
+		if err != nil {
+			if errors.Is(err, repository.ErrProductNotFound) {
+				return nil, ErrProductNotFound
+			}
+			return nil, fmt.Errorf("failed to get product: %w", err)
+		}
+
+		price, historyUUID, err := s.productPrice(ctx, product, order.Currency)
+		if err != nil {
+			return nil, err
+		}
+
+		lines = append(lines, db.AddProductToOrderParams{
+			ProductCode:      product.ProductCode,
+			ResultPrice:      repository.MoneyToNumeric(price),
+			Amount:           after,
+			ListPrice:        repository.MoneyToNumeric(price),
+			PromotionUuids:   []pgtype.UUID{},
+			PriceHistoryUuid: historyUUID,
+		})
+		products = append(products, product)
+	case after == 0:
+		lines = append(lines[:line], lines[line+1:]...)
+		products = append(products[:line], products[line+1:]...)
+	default:
+		lines[line].Amount = after
+		err = s.repriceLine(ctx, &lines[line])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(lines) == 0 {
+		return nil, ErrEmptyOrder
+	}
+
+	priced, err := s.repriceOrder(ctx, order, lines, products)
+	if err != nil {
+		return nil, err
+	}
+
+	reserve, release := s.sms.RemoveCoupleProducts, s.sms.WriteOnCoupleProducts
+	delta := after - before
+	if !order.StockTaken {
+		// SMS never wrote the lines off, there is nothing to adjust
+		delta = 0
+	}
+	if delta < 0 {
+		reserve, release = release, reserve
+		delta = -delta
+	}
+	stock := []models.ProductWriteOffRequest{{
+		Uuid:   productUUID.String(),
+		Amount: float64(delta),
+	}}
+
+	if delta != 0 {
+		_, err = reserve(ctx, stock)
+		if err != nil {
+			return nil, fmt.Errorf("failed to adjust stock: %w", err)
+		}
+	}
+
+	order, err = s.orderRepo.SetOrderProducts(ctx, db.UpdateOrderTotalsParams{
+		OrderCost:    repository.MoneyToNumeric(priced.total),
+		Discount:     repository.MoneyToNumeric(priced.discount),
+		NetAmount:    repository.MoneyToNumeric(priced.net),
+		TaxAmount:    repository.MoneyToNumeric(priced.tax),
+		ShippingCost: repository.MoneyToNumeric(priced.shipping),
+		Uuid:         order.Uuid,
+	}, rows, priced.products)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrOrderNotFound):
+			err = ErrOrderNotFound
+		case errors.Is(err, repository.ErrOrderNotEditable):
+			err = ErrOrderNotEditable
+		case errors.Is(err, repository.ErrOrderLinesChanged):
+			err = ErrOrderLinesConflict
+		case errors.Is(err, repository.ErrProductNotFound):
+			err = ErrProductNotFound
+		default:
+			err = fmt.Errorf("failed to update order products: %w", err)
+		}
+
+		if delta == 0 {
+			return nil, err
+		}
+
+		// The stock adjustment must be reverted even if the caller went away
+		_, releaseErr := release(context.WithoutCancel(ctx), stock)
+		if releaseErr != nil {
+			return nil, errors.Join(err, fmt.Errorf("failed to revert stock adjustment: %w", releaseErr))
+		}
+		return nil, err
+	}
+
+	orderProducts, err := s.orderRepo.GetOrderProducts(ctx, order.Uuid.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order products: %w", err)
+	}
+
+	return s.buildOrderResponse(order, orderProducts)
+}
+
+// repriceLine recomputes the buy X get Y discount of the line for its new
+// amount. Promotions that were deleted since the order was created no longer
+// apply.
+func (s *orderService) repriceLine(ctx context.Context, line *db.AddProductToOrderParams) error {
+	price, err := repository.NumericToMoney(line.ResultPrice)
+	if err != nil {
+		return err
+	}
+
+	var discount models.Money
+	for _, id := range line.PromotionUuids {
+		p, err := s.promotionRepo.Get(ctx, id.String())
+		if err != nil {
+			if errors.Is(err, repository.ErrPromotionNotFound) {
+				continue
+			}
+			return fmt.Errorf("failed to get promotion: %w", err)
+		}
+
+		if p.Kind != db.PromotionKindBuyXGetY {
+			continue
+		}
+
+		group := p.BuyQuantity.Int32 + p.FreeQuantity.Int32
+		discount = price.Mul(int64(line.Amount / group * p.FreeQuantity.Int32))
+	}
+
+	line.Discount = repository.MoneyToNumeric(discount)
+	return nil
+}
+
+// repriceOrder recalculates the totals of the order for the given lines and
+// their products. The order discount is recomputed from the order level
+// promotion of the order. Without one the stored discount is kept, capped by
+// the new lines total. Shipping is recalculated with the method of the order.
+func (s *orderService) repriceOrder(
+	ctx context.Context,
+	order db.Order,
+	lines []db.AddProductToOrderParams,
+	products []db.Product,
+) (pricedOrder, error) {
+	var linesTotal models.Money
+	for _, line := range lines {
+		price, err := repository.NumericToMoney(line.ResultPrice)
+		if err != nil {
+			return pricedOrder{}, err
+		}
+		discount, err := repository.NumericToMoney(line.Discount)
+		if err != nil {
+			return pricedOrder{}, err
+		}
+		linesTotal = linesTotal.Add(price.Mul(int64(line.Amount)).Sub(discount))
+	}
+
+	discount, err := repository.NumericToMoney(order.Discount)
+	if err != nil {
+		return pricedOrder{}, err
+	}
+	if discount.Cmp(linesTotal) > 0 {
+		discount = linesTotal
+	}
+
+	for _, id := range order.PromotionUuids {
+		p, err := s.promotionRepo.Get(ctx, id.String())
+		if err != nil {
+			if errors.Is(err, repository.ErrPromotionNotFound) {
+				continue
+			}
+			return pricedOrder{}, fmt.Errorf("failed to get promotion: %w", err)
+		}
+
+		if p.Scope != db.PromotionScopeOrder {
+			continue
+		}
+		if d, ok := s.promotionDiscount(p, order.Currency, linesTotal); ok {
+			discount = d
+		}
+	}
+
+	priced := pricedOrder{
+		products:   lines,
+		discount:   discount,
+		promotions: order.PromotionUuids,
+		total:      linesTotal.Sub(discount),
+	}
+
+	taxClasses := make([]string, len(products))
+	for i, product := range products {
+		taxClasses[i] = product.TaxClass
+	}
+
+	err = s.applyTaxes(ctx, order.Region, &priced, taxClasses)
+	if err != nil {
+		return pricedOrder{}, err
+	}
+
+	err = s.reapplyShipping(ctx, order, &priced, products)
+	if err != nil {
+		return pricedOrder{}, err
+	}
+
+	return priced, nil
+}
+
+// orderLine turns a stored order line back into insert parameters
+func orderLine(row db.GetOrderProductsRow) db.AddProductToOrderParams {
+	return db.AddProductToOrderParams{
+		ProductCode:      row.ProductCode,
+		OrderUuid:        row.OrderUuid,
+		ResultPrice:      row.ResultPrice,
+		Amount:           row.Amount,
+		ListPrice:        row.ListPrice,
+		Discount:         row.Discount,
+		PromotionUuids:   row.PromotionUuids,
+		TaxRate:          row.TaxRate,
+		TaxAmount:        row.TaxAmount,
+		TaxInclusive:     pgtype.Bool{Bool: row.TaxInclusive, Valid: true},
+		PriceHistoryUuid: row.PriceHistoryUuid,
+	}
+}
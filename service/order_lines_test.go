@@ -0,0 +1,207 @@
+package service
+
+import (
+	"context"
+	"github.com/igntnk/stocky-oms/db"
+	"github.com/igntnk/stocky-oms/repository"
+	"github.com/jackc/pgx/v5/pgtype"
+	"testing"
+)
+
+func TestRepriceLine(t *testing.T) {
+	buy2get1 := db.Promotion{
+		Uuid:         testUUID(1),
+		Kind:         db.PromotionKindBuyXGetY,
+		BuyQuantity:  pgtype.Int4{Int32: 2, Valid: true},
+		FreeQuantity: pgtype.Int4{Int32: 1, Valid: true},
+	}
+	percentage := db.Promotion{
+		Uuid:    testUUID(2),
+		Kind:    db.PromotionKindPercentage,
+		Percent: cents(1000),
+	}
+	s := &orderService{promotionRepo: newFakePromotionRepo(buy2get1, percentage)}
+
+	tests := []struct {
+		name         string
+		amount       int32
+		promotions   []pgtype.UUID
+		wantDiscount int64
+	}{
+		{name: "below the group", amount: 2, promotions: []pgtype.UUID{buy2get1.Uuid}, wantDiscount: 0},
+		{name: "one group", amount: 3, promotions: []pgtype.UUID{buy2get1.Uuid}, wantDiscount: 1000},
+		{name: "started group", amount: 5, promotions: []pgtype.UUID{buy2get1.Uuid}, wantDiscount: 1000},
+		{name: "two groups", amount: 6, promotions: []pgtype.UUID{buy2get1.Uuid}, wantDiscount: 2000},
+		{name: "unit promotions are in the price", amount: 6, promotions: []pgtype.UUID{percentage.Uuid}, wantDiscount: 0},
+		{name: "deleted promotion", amount: 6, promotions: []pgtype.UUID{testUUID(9)}, wantDiscount: 0},
+		{name: "no promotions", amount: 6, promotions: []pgtype.UUID{}, wantDiscount: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			line := db.AddProductToOrderParams{
+				ResultPrice:    cents(1000),
+				Amount:         tt.amount,
+				Discount:       cents(12345),
+				PromotionUuids: tt.promotions,
+			}
+
+			err := s.repriceLine(context.Background(), &line)
+			if err != nil {
+				t.Fatalf("repriceLine() error = %v", err)
+			}
+
+			discount, err := repository.NumericToMoney(line.Discount)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if discount.Cents() != tt.wantDiscount {
+				t.Errorf("discount = %s, want %d cents", discount, tt.wantDiscount)
+			}
+		})
+	}
+}
+
+func TestRepriceOrder(t *testing.T) {
+	tenPercent := db.Promotion{
+		Uuid:    testUUID(1),
+		Kind:    db.PromotionKindPercentage,
+		Scope:   db.PromotionScopeOrder,
+		Percent: cents(1000),
+	}
+	productPromotion := db.Promotion{
+		Uuid:    testUUID(2),
+		Kind:    db.PromotionKindPercentage,
+		Scope:   db.PromotionScopeProduct,
+		Percent: cents(5000),
+	}
+
+	s := &orderService{
+		promotionRepo: newFakePromotionRepo(tenPercent, productPromotion),
+		taxRepo: &fakeTaxRepo{rules: map[string]db.TaxRule{
+			"standard": {Rate: cents(2000)},
+			"included": {Rate: cents(2000), Inclusive: true},
+		}},
+	}
+
+	// a line of amount units at price, both in cents
+	line := func(price int64, amount int32) db.AddProductToOrderParams {
+		return db.AddProductToOrderParams{
+			ResultPrice: cents(price),
+			Amount:      amount,
+			Discount:    cents(0),
+		}
+	}
+
+	tests := []struct {
+		name       string
+		lines      []db.AddProductToOrderParams
+		taxClasses []string
+		// discount is the stored order discount
+		discount     int64
+		promotions   []pgtype.UUID
+		wantDiscount int64
+		wantNet      int64
+		wantTax      int64
+		wantTotal    int64
+	}{
+		{
+			name:       "untaxed lines",
+			lines:      []db.AddProductToOrderParams{line(1000, 2), line(500, 1)},
+			taxClasses: []string{"", ""},
+			wantNet:    2500,
+			wantTotal:  2500,
+		},
+		{
+			name:         "stored discount is kept",
+			lines:        []db.AddProductToOrderParams{line(1000, 2), line(500, 1)},
+			taxClasses:   []string{"", ""},
+			discount:     300,
+			wantDiscount: 300,
+			wantNet:      2200,
+			wantTotal:    2200,
+		},
+		{
+			name:         "stored discount is capped by the lines",
+			lines:        []db.AddProductToOrderParams{line(500, 1)},
+			taxClasses:   []string{""},
+			discount:     3000,
+			wantDiscount: 500,
+			wantTotal:    0,
+		},
+		{
+			name:         "order promotion is recomputed",
+			lines:        []db.AddProductToOrderParams{line(1000, 2), line(500, 1)},
+			taxClasses:   []string{"", ""},
+			discount:     1000,
+			promotions:   []pgtype.UUID{tenPercent.Uuid},
+			wantDiscount: 250,
+			wantNet:      2250,
+			wantTotal:    2250,
+		},
+		{
+			name:         "product promotions don't change the order discount",
+			lines:        []db.AddProductToOrderParams{line(1000, 2)},
+			taxClasses:   []string{""},
+			discount:     100,
+			promotions:   []pgtype.UUID{productPromotion.Uuid, testUUID(9)},
+			wantDiscount: 100,
+			wantNet:      1900,
+			wantTotal:    1900,
+		},
+		{
+			name:       "exclusive tax is added",
+			lines:      []db.AddProductToOrderParams{line(1000, 2), line(500, 1)},
+			taxClasses: []string{"standard", ""},
+			wantNet:    2500,
+			wantTax:    400,
+			wantTotal:  2900,
+		},
+		{
+			name:       "inclusive tax is part of the price",
+			lines:      []db.AddProductToOrderParams{line(1200, 1)},
+			taxClasses: []string{"included"},
+			wantNet:    1000,
+			wantTax:    200,
+			wantTotal:  1200,
+		},
+		{
+			name:         "discount is spread before tax",
+			lines:        []db.AddProductToOrderParams{line(1000, 1), line(1000, 1)},
+			taxClasses:   []string{"standard", ""},
+			discount:     1000,
+			wantDiscount: 1000,
+			wantNet:      1000,
+			wantTax:      100,
+			wantTotal:    1100,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := db.Order{
+				Uuid:           testUUID(1),
+				Currency:       "RUB",
+				Discount:       cents(tt.discount),
+				PromotionUuids: tt.promotions,
+			}
+			products := make([]db.Product, len(tt.taxClasses))
+			for i, class := range tt.taxClasses {
+				products[i] = db.Product{TaxClass: class}
+			}
+
+			priced, err := s.repriceOrder(context.Background(), order, tt.lines, products)
+			if err != nil {
+				t.Fatalf("repriceOrder() error = %v", err)
+			}
+
+			got := []int64{priced.discount.Cents(), priced.net.Cents(), priced.tax.Cents(), priced.total.Cents()}
+			want := []int64{tt.wantDiscount, tt.wantNet, tt.wantTax, tt.wantTotal}
+			for i, name := range []string{"discount", "net", "tax", "total"} {
+				if got[i] != want[i] {
+					t.Errorf("%s = %d cents, want %d", name, got[i], want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/igntnk/stocky-oms/db"
+	"github.com/igntnk/stocky-oms/models"
+	"github.com/igntnk/stocky-oms/repository"
+	"github.com/jackc/pgx/v5/pgtype"
+	"math/big"
+)
+
+// pricedOrder holds the order lines and totals with promotions applied
+type pricedOrder struct {
+	products []db.AddProductToOrderParams
+	// discount is the order level discount taken off the lines total
+	discount   models.Money
+	promotions []pgtype.UUID
+	net        models.Money
+	tax        models.Money
+	// shipping is the cost of shippingMethod, it is not taxed
+	shippingMethod pgtype.UUID
+	shipping       models.Money
+	// total is the gross amount the customer pays, shipping included
+	total models.Money
+}
+
+// priceOrder prices the products in the order currency and applies the
+// promotions. Every line gets the best per-unit promotion and the best buy X
+// get Y promotion for its product, then the best order level promotion is
+// taken off the lines total. Coupons that are unknown, used up or match
+// nothing in the order fail the request. Taxes are computed on the discounted
+// amounts, shipping is added last.
+func (s *orderService) priceOrder(ctx context.Context, req models.OrderCreateRequest) (pricedOrder, error) {
+	currency := s.orderCurrency(req)
+	products, dbProducts, err := s.validateOrderProducts(ctx, currency, req.Products)
+	if err != nil {
+		return pricedOrder{}, err
+	}
+
+	promotions, err := s.promotionRepo.ListApplicable(ctx, req.Coupons)
+	if err != nil {
+		return pricedOrder{}, fmt.Errorf("failed to list promotions: %w", err)
+	}
+
+	coupons := make(map[string]bool, len(req.Coupons))
+	for _, code := range req.Coupons {
+		coupons[code] = false
+	}
+	for code := range coupons {
+		if !hasCoupon(promotions, code) {
+			return pricedOrder{}, fmt.Errorf("%w: %s", ErrInvalidCoupon, code)
+		}
+	}
+
+	var linesTotal models.Money
+	for i := range products {
+		line := &products[i]
+		listPrice, err := repository.NumericToMoney(line.ResultPrice)
+		if err != nil {
+			return pricedOrder{}, err
+		}
+
+		var unitDiscount models.Money
+		var freeUnits int32
+		var unitPromotion, freePromotion *db.Promotion
+		for j := range promotions {
+			p := &promotions[j]
+			if p.Scope != db.PromotionScopeProduct || p.ProductCode != line.ProductCode {
+				continue
+			}
+
+			if p.Kind == db.PromotionKindBuyXGetY {
+				group := p.BuyQuantity.Int32 + p.FreeQuantity.Int32
+				free := line.Amount / group * p.FreeQuantity.Int32
+				if free > freeUnits {
+					freeUnits, freePromotion = free, p
+				}
+				continue
+			}
+
+			discount, ok := s.promotionDiscount(*p, currency, listPrice)
+			if ok && discount.Cmp(unitDiscount) > 0 {
+				unitDiscount, unitPromotion = discount, p
+			}
+		}
+
+		price := listPrice.Sub(unitDiscount)
+		discount := price.Mul(int64(freeUnits))
+
+		line.ListPrice = line.ResultPrice
+		line.ResultPrice = repository.MoneyToNumeric(price)
+		line.Discount = repository.MoneyToNumeric(discount)
+		line.PromotionUuids = []pgtype.UUID{}
+		for _, p := range []*db.Promotion{unitPromotion, freePromotion} {
+			if p != nil {
+				line.PromotionUuids = append(line.PromotionUuids, p.Uuid)
+				markCoupon(coupons, *p)
+			}
+		}
+
+		linesTotal = linesTotal.Add(price.Mul(int64(line.Amount)).Sub(discount))
+	}
+
+	var orderDiscount models.Money
+	var orderPromotion *db.Promotion
+	for j := range promotions {
+		p := &promotions[j]
+		if p.Scope != db.PromotionScopeOrder {
+			continue
+		}
+
+		discount, ok := s.promotionDiscount(*p, currency, linesTotal)
+		if ok && discount.Cmp(orderDiscount) > 0 {
+			orderDiscount, orderPromotion = discount, p
+		}
+	}
+
+	priced := pricedOrder{
+		products:   products,
+		discount:   orderDiscount,
+		promotions: []pgtype.UUID{},
+		total:      linesTotal.Sub(orderDiscount),
+	}
+	if orderPromotion != nil {
+		priced.promotions = append(priced.promotions, orderPromotion.Uuid)
+		markCoupon(coupons, *orderPromotion)
+	}
+
+	for code, applied := range coupons {
+		if !applied {
+			return pricedOrder{}, fmt.Errorf("%w: %s does not apply to the order", ErrInvalidCoupon, code)
+		}
+	}
+
+	taxClasses := make([]string, len(dbProducts))
+	for i, product := range dbProducts {
+		taxClasses[i] = product.TaxClass
+	}
+
+	err = s.applyTaxes(ctx, s.orderRegion(req), &priced, taxClasses)
+	if err != nil {
+		return pricedOrder{}, err
+	}
+
+	err = s.applyShipping(ctx, req.ShippingMethod, currency, &priced, dbProducts)
+	if err != nil {
+		return pricedOrder{}, err
+	}
+
+	return priced, nil
+}
+
+// promotionDiscount is the discount of a percentage or fixed promotion on
+// amount, capped by amount. Fixed promotions in a currency that can't be
+// converted to the order currency don't apply.
+func (s *orderService) promotionDiscount(p db.Promotion, currency string, amount models.Money) (models.Money, bool) {
+	var discount models.Money
+	switch p.Kind {
+	case db.PromotionKindPercentage:
+		percent, err := repository.NumericToMoney(p.Percent)
+		if err != nil {
+			return models.Money{}, false
+		}
+		// percent is stored with two decimals, so 100% is 10000 hundredths
+		discount = amount.MulRat(big.NewRat(percent.Cents(), 100_00))
+	case db.PromotionKindFixed:
+		fixed, err := repository.NumericToMoney(p.Amount)
+		if err != nil {
+			return models.Money{}, false
+		}
+		discount, err = s.currency.Convert(fixed, p.Currency.String, currency)
+		if err != nil {
+			return models.Money{}, false
+		}
+	default:
+		return models.Money{}, false
+	}
+
+	if discount.Cmp(amount) > 0 {
+		discount = amount
+	}
+	return discount, true
+}
+
+func hasCoupon(promotions []db.Promotion, code string) bool {
+	for _, p := range promotions {
+		if p.Code.Valid && p.Code.String == code {
+			return true
+		}
+	}
+	return false
+}
+
+func markCoupon(coupons map[string]bool, p db.Promotion) {
+	if p.Code.Valid {
+		coupons[p.Code.String] = true
+	}
+}
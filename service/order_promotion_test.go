@@ -0,0 +1,56 @@
+package service
+
+import (
+	"github.com/igntnk/stocky-oms/db"
+	"github.com/igntnk/stocky-oms/models"
+	"github.com/jackc/pgx/v5/pgtype"
+	"testing"
+)
+
+func TestPromotionDiscount(t *testing.T) {
+	currency, err := NewCurrencyConverter("RUB", map[string]string{"EUR_RUB": "100"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &orderService{currency: currency}
+
+	percent := func(c int64) db.Promotion {
+		return db.Promotion{Kind: db.PromotionKindPercentage, Percent: cents(c)}
+	}
+	fixed := func(c int64, currency string) db.Promotion {
+		return db.Promotion{
+			Kind:     db.PromotionKindFixed,
+			Amount:   cents(c),
+			Currency: pgtype.Text{String: currency, Valid: true},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		promotion db.Promotion
+		amount    int64
+		want      int64
+		wantOK    bool
+	}{
+		{name: "percentage", promotion: percent(1000), amount: 10000, want: 1000, wantOK: true},
+		{name: "percentage rounds to cents", promotion: percent(1250), amount: 999, want: 125, wantOK: true},
+		{name: "full percentage", promotion: percent(10000), amount: 4321, want: 4321, wantOK: true},
+		{name: "fixed", promotion: fixed(500, "RUB"), amount: 10000, want: 500, wantOK: true},
+		{name: "fixed is converted", promotion: fixed(500, "EUR"), amount: 100000, want: 50000, wantOK: true},
+		{name: "fixed is capped by the amount", promotion: fixed(500, "EUR"), amount: 1999, want: 1999, wantOK: true},
+		{name: "fixed without a rate", promotion: fixed(500, "USD"), amount: 10000, wantOK: false},
+		{name: "buy x get y is per line", promotion: db.Promotion{Kind: db.PromotionKindBuyXGetY}, amount: 10000, wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := s.promotionDiscount(tt.promotion, "RUB", models.MoneyFromCents(tt.amount))
+			if ok != tt.wantOK {
+				t.Fatalf("promotionDiscount() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && got.Cents() != tt.want {
+				t.Errorf("promotionDiscount() = %s, want %s", got, models.MoneyFromCents(tt.want))
+			}
+		})
+	}
+}
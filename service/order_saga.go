@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/igntnk/stocky-oms/db"
+	"github.com/igntnk/stocky-oms/models"
+	"github.com/igntnk/stocky-oms/repository"
+	"github.com/jackc/pgx/v5/pgtype"
+	"time"
+)
+
+// recoverBatchSize bounds the sagas resolved in one RecoverSagas call
+const recoverBatchSize = 100
+
+func (s *orderService) startSaga(ctx context.Context, orderUUID uuid.UUID, products []models.ProductWriteOffRequest) (string, error) {
+	payload, err := json.Marshal(products)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode saga products: %w", err)
+	}
+
+	saga, err := s.sagaRepo.Create(ctx, db.CreateSagaParams{
+		Uuid: pgtype.UUID{
+			Bytes: uuid.New(),
+			Valid: true,
+		},
+		OrderUuid: pgtype.UUID{
+			Bytes: orderUUID,
+			Valid: true,
+		},
+		Products: payload,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to start saga: %w", err)
+	}
+
+	return saga.Uuid.String(), nil
+}
+
+// compensateSaga writes the removed products back to SMS. When SMS fails the
+// saga stays in stock_removed so recovery retries the compensation.
+func (s *orderService) compensateSaga(ctx context.Context, sagaID string, products []models.ProductWriteOffRequest, cause error) error {
+	_, err := s.sms.WriteOnCoupleProducts(ctx, products)
+	if err != nil {
+		return errors.Join(
+			fmt.Errorf("failed to compensate saga %s: %w", sagaID, err),
+			s.sagaRepo.SetStep(ctx, sagaID, db.SagaStepStockRemoved, err.Error()),
+		)
+	}
+
+	return s.sagaRepo.SetStep(ctx, sagaID, db.SagaStepCompensated, cause.Error())
+}
+
+// RecoverSagas finishes sagas left unfinished by a crash or a failed
+// compensation. Sagas with the order already stored are marked persisted,
+// sagas that removed stock without storing the order are compensated.
+// Sagas interrupted before SMS confirmed the write-off cannot be resolved
+// safely and are marked failed for an operator to check. Every saga is
+// claimed before it is resolved, so overlapping runs never compensate the
+// same saga twice. Returns the number of sagas resolved.
+func (s *orderService) RecoverSagas(ctx context.Context, olderThan time.Duration) (int, error) {
+	var recovered int
+	var errs []error
+	for try := 0; try < recoverBatchSize; try++ {
+		saga, err := s.sagaRepo.ClaimUnfinished(ctx, olderThan)
+		if err != nil {
+			if errors.Is(err, repository.ErrNoUnfinishedSaga) {
+				break
+			}
+			errs = append(errs, fmt.Errorf("failed to claim unfinished saga: %w", err))
+			break
+		}
+
+		err = s.recoverSaga(ctx, saga)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		recovered++
+	}
+
+	return recovered, errors.Join(errs...)
+}
+
+func (s *orderService) recoverSaga(ctx context.Context, saga db.OrderSaga) error {
+	sagaID := saga.Uuid.String()
+
+	if saga.Step == db.SagaStepStarted {
+		return s.sagaRepo.SetStep(ctx, sagaID, db.SagaStepFailed, "interrupted before stock write-off was confirmed")
+	}
+
+	_, err := s.orderRepo.Get(ctx, saga.OrderUuid.String())
+	if err == nil {
+		return s.sagaRepo.SetStep(ctx, sagaID, db.SagaStepOrderPersisted, "")
+	}
+	if !errors.Is(err, repository.ErrOrderNotFound) {
+		return fmt.Errorf("failed to get order of saga %s: %w", sagaID, err)
+	}
+
+	var products []models.ProductWriteOffRequest
+	err = json.Unmarshal(saga.Products, &products)
+	if err != nil {
+		return fmt.Errorf("failed to decode products of saga %s: %w", sagaID, err)
+	}
+
+	return s.compensateSaga(ctx, sagaID, products, errors.New("order was not persisted"))
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/igntnk/stocky-oms/db"
+	"github.com/igntnk/stocky-oms/models"
+	"reflect"
+	"testing"
+)
+
+func TestRecoverSagas(t *testing.T) {
+	products := []models.ProductWriteOffRequest{{Uuid: testUUID(7).String(), Amount: 2}}
+	payload, err := json.Marshal(products)
+	if err != nil {
+		t.Fatal(err)
+	}
+	storedOrder := db.Order{Uuid: testUUID(1), Status: db.OrderStatusNew}
+
+	// saga of the order with id b
+	saga := func(b byte, step db.SagaStep) db.OrderSaga {
+		return db.OrderSaga{Uuid: testUUID(100 + b), OrderUuid: testUUID(b), Step: step, Products: payload}
+	}
+
+	tests := []struct {
+		name   string
+		saga   db.OrderSaga
+		smsErr error
+		// runs is the number of RecoverSagas calls, later runs find the saga claimed
+		runs          int
+		wantRecovered int
+		wantErr       bool
+		wantStep      db.SagaStep
+		wantWriteOns  int
+	}{
+		{
+			name:          "interrupted before the write-off",
+			saga:          saga(2, db.SagaStepStarted),
+			runs:          1,
+			wantRecovered: 1,
+			wantStep:      db.SagaStepFailed,
+		},
+		{
+			name:          "order was stored",
+			saga:          saga(1, db.SagaStepStockRemoved),
+			runs:          1,
+			wantRecovered: 1,
+			wantStep:      db.SagaStepOrderPersisted,
+		},
+		{
+			name:          "order was lost",
+			saga:          saga(2, db.SagaStepStockRemoved),
+			runs:          1,
+			wantRecovered: 1,
+			wantStep:      db.SagaStepCompensated,
+			wantWriteOns:  1,
+		},
+		{
+			name:         "failed compensation stays unfinished",
+			saga:         saga(2, db.SagaStepStockRemoved),
+			smsErr:       errors.New("sms is down"),
+			runs:         1,
+			wantErr:      true,
+			wantStep:     db.SagaStepStockRemoved,
+			wantWriteOns: 1,
+		},
+		{
+			name:         "overlapping runs compensate once",
+			saga:         saga(2, db.SagaStepStockRemoved),
+			smsErr:       errors.New("sms is down"),
+			runs:         3,
+			wantErr:      true,
+			wantStep:     db.SagaStepStockRemoved,
+			wantWriteOns: 1,
+		},
+		{
+			name:     "finished sagas are left alone",
+			saga:     saga(2, db.SagaStepCompensated),
+			runs:     1,
+			wantStep: db.SagaStepCompensated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sagaRepo := newFakeSagaRepo(tt.saga)
+			sms := &fakeSMSClient{err: tt.smsErr}
+			s := &orderService{orderRepo: newFakeOrderRepo(storedOrder), sagaRepo: sagaRepo, sms: sms}
+
+			var recovered int
+			var errs []error
+			for range tt.runs {
+				n, err := s.RecoverSagas(context.Background(), 0)
+				recovered += n
+				errs = append(errs, err)
+			}
+
+			if err := errors.Join(errs...); (err != nil) != tt.wantErr {
+				t.Errorf("RecoverSagas() error = %v, want error %v", err, tt.wantErr)
+			}
+			if recovered != tt.wantRecovered {
+				t.Errorf("recovered = %d, want %d", recovered, tt.wantRecovered)
+			}
+			if got := sagaRepo.sagas[0].Step; got != tt.wantStep {
+				t.Errorf("saga step = %s, want %s", got, tt.wantStep)
+			}
+			if len(sms.writeOns) != tt.wantWriteOns {
+				t.Fatalf("stock written back %d times, want %d", len(sms.writeOns), tt.wantWriteOns)
+			}
+			for _, got := range sms.writeOns {
+				if !reflect.DeepEqual(got, products) {
+					t.Errorf("stock written back = %v, want %v", got, products)
+				}
+			}
+		})
+	}
+}
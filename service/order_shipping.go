@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/igntnk/stocky-oms/db"
+	"github.com/igntnk/stocky-oms/models"
+	"github.com/igntnk/stocky-oms/repository"
+	"github.com/jackc/pgx/v5/pgtype"
+	"math/big"
+)
+
+// applyShipping adds the cost of the shipping method with code to the priced
+// order. Only active methods can be selected, orders without a method are not
+// shipped. products are the order products in the order of the lines.
+func (s *orderService) applyShipping(ctx context.Context, code, currency string, priced *pricedOrder, products []db.Product) error {
+	if code == "" {
+		return nil
+	}
+
+	method, err := s.shippingRepo.GetByCode(ctx, code)
+	if err != nil {
+		if errors.Is(err, repository.ErrShippingMethodNotFound) {
+			return fmt.Errorf("%w: %s", ErrInvalidShippingMethod, code)
+		}
+		return fmt.Errorf("failed to get shipping method: %w", err)
+	}
+
+	if !method.Active {
+		return fmt.Errorf("%w: %s", ErrInvalidShippingMethod, code)
+	}
+
+	return s.setShipping(method, currency, priced, products)
+}
+
+// reapplyShipping recalculates the shipping of a stored order for its new
+// lines. The method of the order is kept even if it was deactivated since.
+func (s *orderService) reapplyShipping(ctx context.Context, order db.Order, priced *pricedOrder, products []db.Product) error {
+	if !order.ShippingMethodUuid.Valid {
+		return nil
+	}
+
+	method, err := s.shippingRepo.Get(ctx, order.ShippingMethodUuid.String())
+	if err != nil {
+		return fmt.Errorf("failed to get shipping method: %w", err)
+	}
+
+	return s.setShipping(method, order.Currency, priced, products)
+}
+
+func (s *orderService) setShipping(method db.ShippingMethod, currency string, priced *pricedOrder, products []db.Product) error {
+	cost, err := s.shippingCost(method, currency, priced, products)
+	if err != nil {
+		return err
+	}
+
+	priced.shippingMethod = method.Uuid
+	priced.shipping = cost
+	priced.total = priced.total.Add(cost)
+	return nil
+}
+
+// shippingCost prices the method for the order in the order currency. Free
+// over thresholds are compared with the order total after discounts and
+// taxes. Shipping is not taxed.
+func (s *orderService) shippingCost(method db.ShippingMethod, currency string, priced *pricedOrder, products []db.Product) (models.Money, error) {
+	cost, err := s.shippingAmount(method.Cost, method.Currency, currency)
+	if err != nil {
+		return models.Money{}, err
+	}
+
+	switch models.ShippingRule(method.Rule) {
+	case models.ShippingRuleFlat:
+		return cost, nil
+	case models.ShippingRuleWeight:
+		perKg, err := s.shippingAmount(method.CostPerKg, method.Currency, currency)
+		if err != nil {
+			return models.Money{}, err
+		}
+
+		var grams int64
+		for i, line := range priced.products {
+			grams += int64(products[i].WeightGrams) * int64(line.Amount)
+		}
+		return cost.Add(perKg.MulRat(big.NewRat(grams, 1000))), nil
+	case models.ShippingRuleFreeOver:
+		threshold, err := s.shippingAmount(method.FreeThreshold, method.Currency, currency)
+		if err != nil {
+			return models.Money{}, err
+		}
+
+		if priced.total.Cmp(threshold) >= 0 {
+			return models.Money{}, nil
+		}
+		return cost, nil
+	default:
+		return models.Money{}, fmt.Errorf("unknown shipping rule %q", method.Rule)
+	}
+}
+
+// shippingAmount converts an amount of a shipping method to the order currency
+func (s *orderService) shippingAmount(amount pgtype.Numeric, from, to string) (models.Money, error) {
+	m, err := repository.NumericToMoney(amount)
+	if err != nil {
+		return models.Money{}, err
+	}
+	return s.currency.Convert(m, from, to)
+}
+
+// orderAddresses returns the addresses of a new order as they are stored. The
+// shipping address defaults to the default shipping address of the customer
+// and the billing address to the shipping address. Orders with a shipping
+// method must have a shipping address.
+func (s *orderService) orderAddresses(ctx context.Context, req models.OrderCreateRequest) ([]byte, []byte, error) {
+	shipping := req.ShippingAddress
+	if shipping == nil {
+		var err error
+		shipping, err = s.defaultShippingAddress(ctx, req.UserID)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if shipping == nil && req.ShippingMethod != "" {
+		return nil, nil, ErrShippingAddressRequired
+	}
+
+	billing := req.BillingAddress
+	if billing == nil {
+		billing = shipping
+	}
+
+	shippingJSON, err := marshalAddress(shipping)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	billingJSON, err := marshalAddress(billing)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return shippingJSON, billingJSON, nil
+}
+
+// defaultShippingAddress returns the default shipping address of the customer
+// or nil if the user is no customer yet or has no default address
+func (s *orderService) defaultShippingAddress(ctx context.Context, userID string) (*models.Address, error) {
+	customer, err := s.customerRepo.Get(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrCustomerNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get customer: %w", err)
+	}
+
+	if !customer.DefaultShippingAddressUuid.Valid {
+		return nil, nil
+	}
+
+	address, err := s.customerRepo.GetAddress(ctx, db.GetCustomerAddressParams{
+		Uuid:         customer.DefaultShippingAddressUuid,
+		CustomerUuid: customer.Uuid,
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrAddressNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get default shipping address: %w", err)
+	}
+
+	res := addressFromDB(address)
+	return &res, nil
+}
+
+func marshalAddress(address *models.Address) ([]byte, error) {
+	if address == nil {
+		return nil, nil
+	}
+
+	res, err := json.Marshal(address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode address: %w", err)
+	}
+	return res, nil
+}
+
+func unmarshalAddress(address []byte) (*models.Address, error) {
+	if address == nil {
+		return nil, nil
+	}
+
+	var res models.Address
+	err := json.Unmarshal(address, &res)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode address: %w", err)
+	}
+	return &res, nil
+}
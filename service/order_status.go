@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/igntnk/stocky-oms/db"
+	"github.com/igntnk/stocky-oms/models"
+	"github.com/igntnk/stocky-oms/repository"
+	"github.com/jackc/pgx/v5/pgtype"
+	"time"
+)
+
+// orderStatusTransitions lists the statuses each status may move to.
+// Completed and cancelled orders are final. Orders with shipped products can
+// no longer be cancelled.
+var orderStatusTransitions = map[models.OrderStatus][]models.OrderStatus{
+	models.OrderStatusNew: {models.OrderStatusProcessing, models.OrderStatusCancelled},
+	models.OrderStatusProcessing: {
+		models.OrderStatusPartiallyShipped,
+		models.OrderStatusShipped,
+		models.OrderStatusCompleted,
+		models.OrderStatusCancelled,
+	},
+	models.OrderStatusPartiallyShipped: {models.OrderStatusShipped},
+	models.OrderStatusShipped:          {models.OrderStatusCompleted},
+}
+
+func canTransition(from, to models.OrderStatus) bool {
+	for _, allowed := range orderStatusTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *orderService) ChangeOrderStatus(ctx context.Context, id string, req models.OrderStatusChangeRequest) (*models.OrderResponse, error) {
+	orderUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, ErrInvalidOrderID
+	}
+
+	order, err := s.changeOrderStatus(ctx, orderUUID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	products, err := s.orderRepo.GetOrderProducts(ctx, order.Uuid.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order products: %w", err)
+	}
+
+	return s.buildOrderResponse(order, products)
+}
+
+func (s *orderService) changeOrderStatus(ctx context.Context, orderUUID uuid.UUID, req models.OrderStatusChangeRequest) (db.Order, error) {
+	if req.Status == models.OrderStatusCancelled {
+		return s.cancelOrder(ctx, orderUUID, models.OrderCancelRequest{
+			Reason: req.Reason,
+		})
+	}
+
+	order, err := s.orderRepo.Get(ctx, orderUUID.String())
+	if err != nil {
+		if errors.Is(err, repository.ErrOrderNotFound) {
+			return db.Order{}, ErrOrderNotFound
+		}
+		return db.Order{}, fmt.Errorf("failed to get order: %w", err)
+	}
+
+	from := models.OrderStatus(order.Status)
+	if from == req.Status {
+		return order, nil
+	}
+	if !canTransition(from, req.Status) {
+		return db.Order{}, fmt.Errorf("%w: %s -> %s", ErrInvalidStatusTransition, from, req.Status)
+	}
+
+	// An order is only processed once its cost is authorized and the payment is
+	// captured when it completes
+	switch req.Status {
+	case models.OrderStatusProcessing:
+		_, err = s.payments.AuthorizeOrder(ctx, order.Uuid.String())
+	case models.OrderStatusCompleted:
+		_, err = s.payments.CaptureOrder(ctx, order.Uuid.String())
+	}
+	if err != nil {
+		if errors.Is(err, ErrPaymentNotFound) {
+			return db.Order{}, ErrPaymentNotAuthorized
+		}
+		return db.Order{}, err
+	}
+
+	// The change is attributed to the caller, calls from inside the service to
+	// the responsible staff member
+	actor := callerActor(ctx, order.StaffID)
+
+	order, err = s.orderRepo.UpdateStatus(ctx, db.AddOrderStatusHistoryParams{
+		Uuid: pgtype.UUID{
+			Bytes: uuid.New(),
+			Valid: true,
+		},
+		OrderUuid:  order.Uuid,
+		FromStatus: order.Status,
+		ToStatus:   db.OrderStatus(req.Status),
+		Actor:      actor,
+		Reason: pgtype.Text{
+			String: req.Reason,
+			Valid:  req.Reason != "",
+		},
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrOrderStatusChanged) {
+			return db.Order{}, ErrOrderStatusConflict
+		}
+		return db.Order{}, fmt.Errorf("failed to update order status: %w", err)
+	}
+
+	return order, nil
+}
+
+func (s *orderService) GetOrderStatusHistory(ctx context.Context, id string) ([]*models.OrderStatusHistoryResponse, error) {
+	orderUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, ErrInvalidOrderID
+	}
+
+	order, err := s.orderRepo.Get(ctx, orderUUID.String())
+	if err != nil {
+		if errors.Is(err, repository.ErrOrderNotFound) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+
+	err = checkOrderAccess(ctx, order.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := s.orderRepo.ListStatusHistory(ctx, orderUUID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order status history: %w", err)
+	}
+
+	result := make([]*models.OrderStatusHistoryResponse, 0, len(history))
+	for _, h := range history {
+		result = append(result, &models.OrderStatusHistoryResponse{
+			ID:         h.Uuid.String(),
+			OrderID:    h.OrderUuid.String(),
+			FromStatus: models.OrderStatus(h.FromStatus),
+			ToStatus:   models.OrderStatus(h.ToStatus),
+			Actor:      h.Actor,
+			Reason:     h.Reason.String,
+			ChangedAt:  h.ChangedAt.Time.Format(time.RFC3339),
+		})
+	}
+
+	return result, nil
+}
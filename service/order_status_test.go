@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/igntnk/stocky-oms/clients"
+	"github.com/igntnk/stocky-oms/db"
+	"github.com/igntnk/stocky-oms/models"
+	"reflect"
+	"testing"
+)
+
+func TestCanTransition(t *testing.T) {
+	tests := []struct {
+		from, to models.OrderStatus
+		want     bool
+	}{
+		{from: models.OrderStatusNew, to: models.OrderStatusProcessing, want: true},
+		{from: models.OrderStatusNew, to: models.OrderStatusCancelled, want: true},
+		{from: models.OrderStatusNew, to: models.OrderStatusCompleted, want: false},
+		{from: models.OrderStatusProcessing, to: models.OrderStatusPartiallyShipped, want: true},
+		{from: models.OrderStatusProcessing, to: models.OrderStatusCompleted, want: true},
+		{from: models.OrderStatusProcessing, to: models.OrderStatusNew, want: false},
+		{from: models.OrderStatusPartiallyShipped, to: models.OrderStatusShipped, want: true},
+		{from: models.OrderStatusPartiallyShipped, to: models.OrderStatusCancelled, want: false},
+		{from: models.OrderStatusShipped, to: models.OrderStatusCompleted, want: true},
+		{from: models.OrderStatusShipped, to: models.OrderStatusCancelled, want: false},
+		{from: models.OrderStatusCompleted, to: models.OrderStatusProcessing, want: false},
+		{from: models.OrderStatusCancelled, to: models.OrderStatusNew, want: false},
+		{from: models.OrderStatusPending, to: models.OrderStatusProcessing, want: false},
+	}
+
+	for _, tt := range tests {
+		if got := canTransition(tt.from, tt.to); got != tt.want {
+			t.Errorf("canTransition(%s, %s) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+// TestChangeOrderStatusPayments checks that processing authorizes the order
+// cost and completing captures it, and that a failed payment keeps the status
+func TestChangeOrderStatusPayments(t *testing.T) {
+	// the fake provider declines authorizations above 1000.00
+	const limit = 100000
+
+	tests := []struct {
+		name string
+		from models.OrderStatus
+		cost int64
+		// authorized has the order cost authorized before the change
+		authorized   bool
+		to           models.OrderStatus
+		wantErr      error
+		wantStatus   models.OrderStatus
+		wantPayments []db.PaymentStatus
+	}{
+		{
+			name:         "processing authorizes",
+			from:         models.OrderStatusNew,
+			cost:         2500,
+			to:           models.OrderStatusProcessing,
+			wantStatus:   models.OrderStatusProcessing,
+			wantPayments: []db.PaymentStatus{db.PaymentStatusAuthorized},
+		},
+		{
+			name:         "processing keeps an existing authorization",
+			from:         models.OrderStatusNew,
+			cost:         2500,
+			authorized:   true,
+			to:           models.OrderStatusProcessing,
+			wantStatus:   models.OrderStatusProcessing,
+			wantPayments: []db.PaymentStatus{db.PaymentStatusAuthorized},
+		},
+		{
+			name:         "declined authorization keeps the order new",
+			from:         models.OrderStatusNew,
+			cost:         limit + 1,
+			to:           models.OrderStatusProcessing,
+			wantErr:      ErrPaymentDeclined,
+			wantStatus:   models.OrderStatusNew,
+			wantPayments: []db.PaymentStatus{db.PaymentStatusFailed},
+		},
+		{
+			name:         "completing captures",
+			from:         models.OrderStatusProcessing,
+			cost:         2500,
+			authorized:   true,
+			to:           models.OrderStatusCompleted,
+			wantStatus:   models.OrderStatusCompleted,
+			wantPayments: []db.PaymentStatus{db.PaymentStatusCaptured},
+		},
+		{
+			name:         "shipped orders capture on completion",
+			from:         models.OrderStatusShipped,
+			cost:         2500,
+			authorized:   true,
+			to:           models.OrderStatusCompleted,
+			wantStatus:   models.OrderStatusCompleted,
+			wantPayments: []db.PaymentStatus{db.PaymentStatusCaptured},
+		},
+		{
+			name:         "completing without authorization",
+			from:         models.OrderStatusProcessing,
+			cost:         2500,
+			to:           models.OrderStatusCompleted,
+			wantErr:      ErrPaymentNotAuthorized,
+			wantStatus:   models.OrderStatusProcessing,
+			wantPayments: []db.PaymentStatus{},
+		},
+		{
+			name:         "skipping processing",
+			from:         models.OrderStatusNew,
+			cost:         2500,
+			to:           models.OrderStatusCompleted,
+			wantErr:      ErrInvalidStatusTransition,
+			wantStatus:   models.OrderStatusNew,
+			wantPayments: []db.PaymentStatus{},
+		},
+		{
+			name:         "completed is final",
+			from:         models.OrderStatusCompleted,
+			cost:         2500,
+			to:           models.OrderStatusShipped,
+			wantErr:      ErrInvalidStatusTransition,
+			wantStatus:   models.OrderStatusCompleted,
+			wantPayments: []db.PaymentStatus{},
+		},
+		{
+			name:         "same status is a no-op",
+			from:         models.OrderStatusProcessing,
+			cost:         2500,
+			to:           models.OrderStatusProcessing,
+			wantStatus:   models.OrderStatusProcessing,
+			wantPayments: []db.PaymentStatus{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			order := db.Order{
+				Uuid:      testUUID(1),
+				StaffID:   "staff",
+				OrderCost: cents(tt.cost),
+				Currency:  "RUB",
+				Status:    db.OrderStatus(tt.from),
+			}
+			orderRepo := newFakeOrderRepo(order)
+			paymentRepo := &fakePaymentRepo{}
+			payments := NewPaymentService(clients.NewFakePaymentProvider(models.MoneyFromCents(limit)), paymentRepo, orderRepo)
+			s := &orderService{orderRepo: orderRepo, payments: payments}
+
+			if tt.authorized {
+				_, err := payments.AuthorizeOrder(ctx, order.Uuid.String())
+				if err != nil {
+					t.Fatalf("AuthorizeOrder() error = %v", err)
+				}
+			}
+
+			_, err := s.changeOrderStatus(ctx, uuid.UUID(order.Uuid.Bytes), models.OrderStatusChangeRequest{
+				Status: tt.to,
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("changeOrderStatus() error = %v, want %v", err, tt.wantErr)
+			}
+
+			stored, _ := orderRepo.Get(ctx, order.Uuid.String())
+			if got := models.OrderStatus(stored.Status); got != tt.wantStatus {
+				t.Errorf("order status = %s, want %s", got, tt.wantStatus)
+			}
+			if got := paymentRepo.statuses(); !reflect.DeepEqual(got, tt.wantPayments) {
+				t.Errorf("payments = %v, want %v", got, tt.wantPayments)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/igntnk/stocky-oms/db"
+	"github.com/igntnk/stocky-oms/models"
+	"github.com/igntnk/stocky-oms/repository"
+	"github.com/jackc/pgx/v5/pgtype"
+	"math/big"
+)
+
+// lineTax is the tax of one order line
+type lineTax struct {
+	rate      models.Money
+	inclusive bool
+	tax       models.Money
+	// net is the line amount without tax
+	net models.Money
+}
+
+// applyTaxes taxes every line on its total after discounts. The order
+// discount is spread over the lines in proportion to their totals, so a
+// discounted order is taxed on what the customer actually pays.
+func (s *orderService) applyTaxes(ctx context.Context, region string, priced *pricedOrder, taxClasses []string) error {
+	lineTotals := make([]models.Money, len(priced.products))
+	var linesTotal models.Money
+	for i, line := range priced.products {
+		price, err := repository.NumericToMoney(line.ResultPrice)
+		if err != nil {
+			return err
+		}
+		discount, err := repository.NumericToMoney(line.Discount)
+		if err != nil {
+			return err
+		}
+
+		lineTotals[i] = price.Mul(int64(line.Amount)).Sub(discount)
+		linesTotal = linesTotal.Add(lineTotals[i])
+	}
+
+	var net, tax, allocated models.Money
+	for i := range priced.products {
+		share := models.Money{}
+		switch {
+		case i == len(priced.products)-1:
+			// the last line takes the rounding remainder
+			share = priced.discount.Sub(allocated)
+		case linesTotal.Sign() > 0:
+			share = priced.discount.MulRat(big.NewRat(lineTotals[i].Cents(), linesTotal.Cents()))
+		}
+		allocated = allocated.Add(share)
+
+		t, err := s.lineTax(ctx, taxClasses[i], region, lineTotals[i].Sub(share))
+		if err != nil {
+			return err
+		}
+		setLineTax(&priced.products[i], t)
+
+		net = net.Add(t.net)
+		tax = tax.Add(t.tax)
+	}
+
+	priced.net = net
+	priced.tax = tax
+	priced.total = net.Add(tax)
+	return nil
+}
+
+// lineTax finds the tax rule of the class in the region and taxes amount.
+// Classes without a rule are not taxed.
+func (s *orderService) lineTax(ctx context.Context, taxClass, region string, amount models.Money) (lineTax, error) {
+	rule, err := s.taxRepo.Find(ctx, taxClass, region)
+	if err != nil {
+		if errors.Is(err, repository.ErrTaxRuleNotFound) {
+			return lineTax{net: amount}, nil
+		}
+		return lineTax{}, fmt.Errorf("failed to find tax rule: %w", err)
+	}
+
+	// rates are percents with two decimals, so 100% is 10000 hundredths
+	rate, err := repository.NumericToMoney(rule.Rate)
+	if err != nil {
+		return lineTax{}, err
+	}
+
+	t := lineTax{rate: rate, inclusive: rule.Inclusive}
+	if rule.Inclusive {
+		t.tax = amount.MulRat(big.NewRat(rate.Cents(), 100_00+rate.Cents()))
+		t.net = amount.Sub(t.tax)
+	} else {
+		t.tax = amount.MulRat(big.NewRat(rate.Cents(), 100_00))
+		t.net = amount
+	}
+	return t, nil
+}
+
+func setLineTax(line *db.AddProductToOrderParams, t lineTax) {
+	line.TaxRate = repository.MoneyToNumeric(t.rate)
+	line.TaxAmount = repository.MoneyToNumeric(t.tax)
+	line.TaxInclusive = pgtype.Bool{Bool: t.inclusive, Valid: true}
+}
+
+// orderRegion returns the requested tax region or the default one
+func (s *orderService) orderRegion(req models.OrderCreateRequest) string {
+	if req.Region != "" {
+		return req.Region
+	}
+	return s.taxRegion
+}
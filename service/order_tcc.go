@@ -0,0 +1,252 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/igntnk/stocky-oms/db"
+	"github.com/igntnk/stocky-oms/models"
+	"github.com/igntnk/stocky-oms/repository"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	reservationSweeperActor  = "reservation-sweeper"
+	expiredReservationsBatch = 100
+)
+
+// TryOrder is the Try phase of TCC. It stores a pending order priced from the
+// product table, reserves its lines until the reservation TTL passes and
+// writes the products off in SMS.
+func (s *orderService) TryOrder(ctx context.Context, req models.OrderCreateRequest) (*models.OrderResponse, error) {
+	if len(req.Products) == 0 {
+		return nil, ErrEmptyOrder
+	}
+
+	priced, err := s.priceOrder(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	shippingAddress, billingAddress, err := s.orderAddresses(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	order, err := s.orderRepo.CreatePending(ctx, db.CreateOrderParams{
+		Uuid: pgtype.UUID{
+			Bytes: uuid.New(),
+			Valid: true,
+		},
+		Comment:            pgtype.Text{String: req.Comment, Valid: true},
+		UserID:             req.UserID,
+		StaffID:            req.StaffID,
+		OrderCost:          repository.MoneyToNumeric(priced.total),
+		Currency:           s.orderCurrency(req),
+		Discount:           repository.MoneyToNumeric(priced.discount),
+		PromotionUuids:     priced.promotions,
+		Region:             pgtype.Text{String: s.orderRegion(req), Valid: true},
+		NetAmount:          repository.MoneyToNumeric(priced.net),
+		TaxAmount:          repository.MoneyToNumeric(priced.tax),
+		ShippingMethodUuid: priced.shippingMethod,
+		ShippingCost:       repository.MoneyToNumeric(priced.shipping),
+		ShippingAddress:    shippingAddress,
+		BillingAddress:     billingAddress,
+	}, priced.products, s.reservationTTL)
+	if err != nil {
+		if errors.Is(err, repository.ErrProductNotFound) {
+			return nil, ErrProductNotFound
+		}
+		if errors.Is(err, repository.ErrPromotionUnavailable) {
+			return nil, ErrPromotionUnavailable
+		}
+		return nil, fmt.Errorf("failed to create order: %w", err)
+	}
+
+	reqPr := make([]models.ProductWriteOffRequest, len(req.Products))
+	for i, product := range req.Products {
+		reqPr[i] = models.ProductWriteOffRequest{
+			Uuid:   product.ProductID.String(),
+			Amount: float64(product.Amount),
+		}
+	}
+
+	// Releasing the reservation must survive the caller going away after SMS was called
+	recordCtx := context.WithoutCancel(ctx)
+
+	_, err = s.sms.RemoveCoupleProducts(ctx, reqPr)
+	if err != nil {
+		_, cancelErr := s.cancelPending(recordCtx, order, "", "failed to reserve products in warehouse")
+		return nil, errors.Join(fmt.Errorf("failed to reserve products: %w", err), cancelErr)
+	}
+
+	err = s.orderRepo.HoldStock(recordCtx, order.Uuid.String())
+	if err != nil {
+		// without the hold mark cancelling would not return the products. The
+		// sweeper may have released the reservations already, then only the
+		// stock is returned here.
+		_, smsErr := s.sms.WriteOnCoupleProducts(recordCtx, reqPr)
+		if errors.Is(err, repository.ErrReservationExpired) {
+			return nil, errors.Join(ErrReservationExpired, smsErr)
+		}
+		_, cancelErr := s.cancelPending(recordCtx, order, "", "failed to record stock hold")
+		return nil, errors.Join(fmt.Errorf("failed to record stock hold: %w", err), smsErr, cancelErr)
+	}
+
+	orderProducts, err := s.orderRepo.GetOrderProducts(ctx, order.Uuid.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch order products: %w", err)
+	}
+
+	return s.buildOrderResponse(order, orderProducts)
+}
+
+// ConfirmOrder is the Confirm phase of TCC. It turns a pending order into a
+// new one. Confirming an already confirmed order returns it unchanged.
+func (s *orderService) ConfirmOrder(ctx context.Context, id string) (*models.OrderResponse, error) {
+	orderUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, ErrInvalidOrderID
+	}
+
+	order, err := s.orderRepo.Get(ctx, orderUUID.String())
+	if err != nil {
+		if errors.Is(err, repository.ErrOrderNotFound) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+
+	switch order.Status {
+	case db.OrderStatusPending:
+		order, err = s.orderRepo.ConfirmPending(ctx, db.AddOrderStatusHistoryParams{
+			Uuid: pgtype.UUID{
+				Bytes: uuid.New(),
+				Valid: true,
+			},
+			OrderUuid: order.Uuid,
+			Actor:     order.StaffID,
+		})
+		if err != nil {
+			switch {
+			case errors.Is(err, repository.ErrReservationExpired):
+				return nil, ErrReservationExpired
+			case errors.Is(err, repository.ErrOrderStatusChanged):
+				return nil, ErrOrderStatusConflict
+			default:
+				return nil, fmt.Errorf("failed to confirm order: %w", err)
+			}
+		}
+	case db.OrderStatusCancelled:
+		return nil, ErrReservationExpired
+	}
+
+	products, err := s.orderRepo.GetOrderProducts(ctx, order.Uuid.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order products: %w", err)
+	}
+
+	return s.buildOrderResponse(order, products)
+}
+
+// CancelPendingOrder is the Cancel phase of TCC. It releases the reservations
+// and returns the products to SMS. Calling it again for a cancelled order
+// retries a failed stock return.
+func (s *orderService) CancelPendingOrder(ctx context.Context, id string, reason string) (*models.OrderResponse, error) {
+	orderUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, ErrInvalidOrderID
+	}
+
+	order, err := s.orderRepo.Get(ctx, orderUUID.String())
+	if err != nil {
+		if errors.Is(err, repository.ErrOrderNotFound) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+
+	switch order.Status {
+	case db.OrderStatusPending:
+		order, err = s.cancelPending(ctx, order, "", reason)
+	case db.OrderStatusCancelled:
+		err = s.returnStock(ctx, order)
+	default:
+		err = fmt.Errorf("%w: %s -> %s", ErrInvalidStatusTransition, order.Status, models.OrderStatusCancelled)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	products, err := s.orderRepo.GetOrderProducts(ctx, order.Uuid.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order products: %w", err)
+	}
+
+	return s.buildOrderResponse(order, products)
+}
+
+// ExpireReservations cancels pending orders that were not confirmed before
+// their reservations expired. Returns the number of cancelled orders.
+func (s *orderService) ExpireReservations(ctx context.Context) (int, error) {
+	orderIDs, err := s.orderRepo.ListExpiredPending(ctx, expiredReservationsBatch)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list expired reservations: %w", err)
+	}
+
+	var expired int
+	var errs []error
+	for _, orderID := range orderIDs {
+		order, err := s.orderRepo.Get(ctx, orderID)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to get order %s: %w", orderID, err))
+			continue
+		}
+
+		_, err = s.cancelPending(ctx, order, reservationSweeperActor, "reservation expired")
+		if err != nil {
+			// confirmed concurrently, nothing to release
+			if errors.Is(err, ErrOrderStatusConflict) {
+				continue
+			}
+			errs = append(errs, fmt.Errorf("order %s: %w", orderID, err))
+			continue
+		}
+		expired++
+	}
+
+	return expired, errors.Join(errs...)
+}
+
+func (s *orderService) cancelPending(ctx context.Context, order db.Order, actor, reason string) (db.Order, error) {
+	if actor == "" {
+		actor = order.StaffID
+	}
+
+	order, err := s.orderRepo.CancelPending(ctx, db.AddOrderStatusHistoryParams{
+		Uuid: pgtype.UUID{
+			Bytes: uuid.New(),
+			Valid: true,
+		},
+		OrderUuid: order.Uuid,
+		Actor:     actor,
+		Reason: pgtype.Text{
+			String: reason,
+			Valid:  reason != "",
+		},
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrOrderStatusChanged) {
+			return db.Order{}, ErrOrderStatusConflict
+		}
+		return db.Order{}, fmt.Errorf("failed to cancel order: %w", err)
+	}
+
+	err = s.returnStock(ctx, order)
+	if err != nil {
+		return db.Order{}, err
+	}
+
+	return order, nil
+}
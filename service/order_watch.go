@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"github.com/igntnk/stocky-oms/auth"
+	"github.com/igntnk/stocky-oms/models"
+	"sync"
+)
+
+// orderWatchBuffer is how many changes a watcher may fall behind before it is
+// dropped
+const orderWatchBuffer = 64
+
+type OrderWatchService interface {
+	// Watch returns the order changes of the tenant of ctx matching the
+	// filter. The channel is closed when ctx is done or when the watcher falls
+	// too far behind, the watcher should then reload the orders and watch again.
+	Watch(ctx context.Context, filter models.OrderWatchFilter) <-chan models.OrderChange
+	// Publish pushes an order change to the watchers interested in it
+	Publish(ctx context.Context, event models.OrderEvent)
+}
+
+type orderWatcher struct {
+	tenant  string
+	filter  models.OrderWatchFilter
+	changes chan models.OrderChange
+}
+
+type orderWatchService struct {
+	orders OrderService
+
+	mu       sync.Mutex
+	watchers map[*orderWatcher]struct{}
+}
+
+func NewOrderWatchService(orders OrderService) OrderWatchService {
+	return &orderWatchService{
+		orders:   orders,
+		watchers: make(map[*orderWatcher]struct{}),
+	}
+}
+
+func (s *orderWatchService) Watch(ctx context.Context, filter models.OrderWatchFilter) <-chan models.OrderChange {
+	tenant, _ := auth.TenantFromContext(ctx)
+	w := &orderWatcher{
+		tenant:  tenant,
+		filter:  filter,
+		changes: make(chan models.OrderChange, orderWatchBuffer),
+	}
+
+	s.mu.Lock()
+	s.watchers[w] = struct{}{}
+	s.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		s.remove(w)
+	}()
+
+	return w.changes
+}
+
+func (s *orderWatchService) Publish(ctx context.Context, event models.OrderEvent) {
+	if !s.watched(event) {
+		return
+	}
+
+	change := models.OrderChange{OrderEvent: event}
+	if event.Type != models.OrderEventDeleted {
+		// The order may already be gone again, the change is pushed without it then
+		order, err := s.orders.GetOrder(ctx, event.OrderID)
+		if err == nil {
+			change.Order = order
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for w := range s.watchers {
+		if !w.matches(event) {
+			continue
+		}
+
+		select {
+		case w.changes <- change:
+		default:
+			delete(s.watchers, w)
+			close(w.changes)
+		}
+	}
+}
+
+// watched reports whether any watcher wants the event, so orders are only
+// loaded for changes someone receives
+func (s *orderWatchService) watched(event models.OrderEvent) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for w := range s.watchers {
+		if w.matches(event) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *orderWatchService) remove(w *orderWatcher) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.watchers[w]; ok {
+		delete(s.watchers, w)
+		close(w.changes)
+	}
+}
+
+// matches reports whether the event is of the watcher tenant and passes its
+// filter
+func (w *orderWatcher) matches(event models.OrderEvent) bool {
+	if w.tenant == "" || (w.tenant != auth.AllTenants && w.tenant != event.TenantID) {
+		return false
+	}
+
+	filter := w.filter
+	return (filter.Status == "" || filter.Status == event.Status) &&
+		(filter.UserID == "" || filter.UserID == event.UserID) &&
+		(filter.StaffID == "" || filter.StaffID == event.StaffID)
+}
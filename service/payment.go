@@ -0,0 +1,288 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/igntnk/stocky-oms/clients"
+	"github.com/igntnk/stocky-oms/db"
+	"github.com/igntnk/stocky-oms/models"
+	"github.com/igntnk/stocky-oms/repository"
+	"github.com/jackc/pgx/v5/pgtype"
+	"time"
+)
+
+type PaymentService interface {
+	ListOrderPayments(ctx context.Context, orderID string) ([]*models.PaymentResponse, error)
+	// AuthorizeOrder holds the order cost with the provider. An order that is
+	// already authorized keeps its payment.
+	AuthorizeOrder(ctx context.Context, orderID string) (*models.PaymentResponse, error)
+	// CaptureOrder takes the authorized amount. Captured payments are returned as is.
+	CaptureOrder(ctx context.Context, orderID string) (*models.PaymentResponse, error)
+	// VoidOrder releases an authorization that was not captured
+	VoidOrder(ctx context.Context, orderID string) (*models.PaymentResponse, error)
+	// RefundOrder gives back part of the captured amount
+	RefundOrder(ctx context.Context, orderID string, amount models.Money) (*models.PaymentResponse, error)
+}
+
+type paymentService struct {
+	provider  clients.PaymentProvider
+	repo      repository.PaymentRepository
+	orderRepo repository.OrderRepository
+}
+
+func NewPaymentService(provider clients.PaymentProvider, repo repository.PaymentRepository, orderRepo repository.OrderRepository) PaymentService {
+	return &paymentService{
+		provider:  provider,
+		repo:      repo,
+		orderRepo: orderRepo,
+	}
+}
+
+func (s *paymentService) ListOrderPayments(ctx context.Context, orderID string) ([]*models.PaymentResponse, error) {
+	order, err := s.getOrder(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	payments, err := s.repo.ListByOrder(ctx, order.Uuid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list payments: %w", err)
+	}
+
+	result := make([]*models.PaymentResponse, 0, len(payments))
+	for _, p := range payments {
+		res, err := paymentToResponse(p)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, res)
+	}
+
+	return result, nil
+}
+
+func (s *paymentService) AuthorizeOrder(ctx context.Context, orderID string) (*models.PaymentResponse, error) {
+	order, err := s.getOrder(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	payment, err := s.repo.GetActive(ctx, order.Uuid)
+	if err == nil {
+		return paymentToResponse(payment)
+	}
+	if !errors.Is(err, repository.ErrPaymentNotFound) {
+		return nil, fmt.Errorf("failed to get payment: %w", err)
+	}
+
+	amount, err := repository.NumericToMoney(order.OrderCost)
+	if err != nil {
+		return nil, err
+	}
+
+	params := db.CreatePaymentParams{
+		Uuid: pgtype.UUID{
+			Bytes: uuid.New(),
+			Valid: true,
+		},
+		OrderUuid: order.Uuid,
+		Provider:  s.provider.Name(),
+		Status:    db.PaymentStatusAuthorized,
+		Amount:    order.OrderCost,
+		Currency:  order.Currency,
+	}
+
+	params.Reference, err = s.provider.Authorize(ctx, clients.PaymentAuthorization{
+		OrderID:  order.Uuid.String(),
+		Amount:   amount,
+		Currency: order.Currency,
+	})
+	if err != nil {
+		// the failed attempt stays in the payment history
+		params.Status = db.PaymentStatusFailed
+		params.Error = err.Error()
+		_, recordErr := s.repo.Create(context.WithoutCancel(ctx), params)
+		return nil, errors.Join(providerError(err), recordErr)
+	}
+
+	payment, err = s.repo.Create(ctx, params)
+	if err != nil {
+		// The authorization is not recorded, release it so no money stays held
+		voidErr := s.provider.Void(context.WithoutCancel(ctx), params.Reference)
+		if errors.Is(err, repository.ErrPaymentExists) && voidErr == nil {
+			payment, err = s.repo.GetActive(ctx, order.Uuid)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get payment: %w", err)
+			}
+			return paymentToResponse(payment)
+		}
+		return nil, errors.Join(fmt.Errorf("failed to record payment: %w", err), voidErr)
+	}
+
+	return paymentToResponse(payment)
+}
+
+func (s *paymentService) CaptureOrder(ctx context.Context, orderID string) (*models.PaymentResponse, error) {
+	payment, err := s.activePayment(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if payment.Status != db.PaymentStatusAuthorized {
+		return paymentToResponse(payment)
+	}
+
+	amount, err := repository.NumericToMoney(payment.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.provider.Capture(ctx, payment.Reference, amount)
+	if err != nil {
+		return nil, providerError(err)
+	}
+
+	payment, err = s.repo.Capture(ctx, db.CapturePaymentParams{
+		CapturedAmount: payment.Amount,
+		Uuid:           payment.Uuid,
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrPaymentStatusChanged) {
+			return nil, ErrPaymentConflict
+		}
+		return nil, fmt.Errorf("failed to record capture: %w", err)
+	}
+
+	return paymentToResponse(payment)
+}
+
+func (s *paymentService) VoidOrder(ctx context.Context, orderID string) (*models.PaymentResponse, error) {
+	payment, err := s.activePayment(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if payment.Status != db.PaymentStatusAuthorized {
+		return nil, fmt.Errorf("%w: payment is %s", ErrPaymentNotAuthorized, payment.Status)
+	}
+
+	err = s.provider.Void(ctx, payment.Reference)
+	if err != nil {
+		return nil, providerError(err)
+	}
+
+	payment, err = s.repo.Void(ctx, payment.Uuid)
+	if err != nil {
+		if errors.Is(err, repository.ErrPaymentStatusChanged) {
+			return nil, ErrPaymentConflict
+		}
+		return nil, fmt.Errorf("failed to record void: %w", err)
+	}
+
+	return paymentToResponse(payment)
+}
+
+func (s *paymentService) RefundOrder(ctx context.Context, orderID string, amount models.Money) (*models.PaymentResponse, error) {
+	payment, err := s.activePayment(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if payment.Status != db.PaymentStatusCaptured {
+		return nil, fmt.Errorf("%w: payment is %s", ErrPaymentNotCaptured, payment.Status)
+	}
+
+	err = s.provider.Refund(ctx, payment.Reference, amount)
+	if err != nil {
+		return nil, providerError(err)
+	}
+
+	payment, err = s.repo.AddRefund(ctx, db.AddPaymentRefundParams{
+		Amount: repository.MoneyToNumeric(amount),
+		Uuid:   payment.Uuid,
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrPaymentStatusChanged) {
+			return nil, ErrPaymentConflict
+		}
+		return nil, fmt.Errorf("failed to record refund: %w", err)
+	}
+
+	return paymentToResponse(payment)
+}
+
+func (s *paymentService) getOrder(ctx context.Context, orderID string) (db.Order, error) {
+	orderUUID, err := uuid.Parse(orderID)
+	if err != nil {
+		return db.Order{}, ErrInvalidOrderID
+	}
+
+	order, err := s.orderRepo.Get(ctx, orderUUID.String())
+	if err != nil {
+		if errors.Is(err, repository.ErrOrderNotFound) {
+			return db.Order{}, ErrOrderNotFound
+		}
+		return db.Order{}, fmt.Errorf("failed to get order: %w", err)
+	}
+
+	return order, nil
+}
+
+func (s *paymentService) activePayment(ctx context.Context, orderID string) (db.Payment, error) {
+	order, err := s.getOrder(ctx, orderID)
+	if err != nil {
+		return db.Payment{}, err
+	}
+
+	payment, err := s.repo.GetActive(ctx, order.Uuid)
+	if err != nil {
+		if errors.Is(err, repository.ErrPaymentNotFound) {
+			return db.Payment{}, ErrPaymentNotFound
+		}
+		return db.Payment{}, fmt.Errorf("failed to get payment: %w", err)
+	}
+
+	return payment, nil
+}
+
+// providerError maps payment provider errors to service errors
+func providerError(err error) error {
+	if errors.Is(err, clients.ErrPaymentDeclined) {
+		return fmt.Errorf("%w: %w", ErrPaymentDeclined, err)
+	}
+	return fmt.Errorf("%w: %w", ErrPaymentFailed, err)
+}
+
+func paymentToResponse(p db.Payment) (*models.PaymentResponse, error) {
+	amount, err := repository.NumericToMoney(p.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	captured, err := repository.NumericToMoney(p.CapturedAmount)
+	if err != nil {
+		return nil, err
+	}
+
+	refunded, err := repository.NumericToMoney(p.RefundedAmount)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.PaymentResponse{
+		ID:             p.Uuid.String(),
+		OrderID:        p.OrderUuid.String(),
+		Provider:       p.Provider,
+		Reference:      p.Reference,
+		Status:         models.PaymentStatus(p.Status),
+		Amount:         amount,
+		Currency:       p.Currency,
+		CapturedAmount: captured,
+		RefundedAmount: refunded,
+		Error:          p.Error,
+		CreatedAt:      p.CreatedAt.Time.Format(time.RFC3339),
+		UpdatedAt:      p.UpdatedAt.Time.Format(time.RFC3339),
+	}, nil
+}
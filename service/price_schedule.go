@@ -0,0 +1,218 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/igntnk/stocky-oms/db"
+	"github.com/igntnk/stocky-oms/models"
+	"github.com/igntnk/stocky-oms/repository"
+	"github.com/jackc/pgx/v5/pgtype"
+	"time"
+)
+
+// applyBatchSize bounds the changes applied in one ApplyDuePriceChanges call
+const applyBatchSize = 100
+
+type PriceScheduleService interface {
+	SchedulePriceChange(ctx context.Context, req models.PriceChangeCreateRequest) (*models.PriceChangeResponse, error)
+	GetPriceChange(ctx context.Context, id string) (*models.PriceChangeResponse, error)
+	ListPriceChanges(ctx context.Context, filter models.PriceChangeFilter) ([]*models.PriceChangeResponse, error)
+	CancelPriceChange(ctx context.Context, id string) (*models.PriceChangeResponse, error)
+	// ApplyDuePriceChanges applies the changes whose time has come, each in
+	// its own transaction, and returns them
+	ApplyDuePriceChanges(ctx context.Context) ([]*models.PriceChangeResponse, error)
+}
+
+type priceScheduleService struct {
+	repo        repository.PriceScheduleRepository
+	productRepo repository.ProductRepository
+}
+
+func NewPriceScheduleService(repo repository.PriceScheduleRepository, productRepo repository.ProductRepository) PriceScheduleService {
+	return &priceScheduleService{repo: repo, productRepo: productRepo}
+}
+
+func (s *priceScheduleService) SchedulePriceChange(ctx context.Context, req models.PriceChangeCreateRequest) (*models.PriceChangeResponse, error) {
+	if !req.RunAt.After(time.Now()) {
+		return nil, fmt.Errorf("%w: run_at must be in the future", ErrInvalidPriceChange)
+	}
+	if req.RevertAt != nil && !req.RevertAt.After(req.RunAt) {
+		return nil, fmt.Errorf("%w: revert_at must be after run_at", ErrInvalidPriceChange)
+	}
+
+	product, err := s.productRepo.Get(ctx, req.ProductCode)
+	if err != nil {
+		if errors.Is(err, repository.ErrProductNotFound) {
+			return nil, ErrProductNotFound
+		}
+		return nil, fmt.Errorf("failed to get product: %w", err)
+	}
+
+	actor := callerActor(ctx, "")
+	change := db.CreateScheduledPriceChangeParams{
+		Uuid: pgtype.UUID{
+			Bytes: uuid.New(),
+			Valid: true,
+		},
+		ProductUuid:  product.Uuid,
+		CustomerCost: repository.MoneyToNumeric(req.CustomerCost),
+		RunAt:        pgtype.Timestamp{Time: req.RunAt.UTC(), Valid: true},
+		CreatedBy:    actor,
+		Reason:       req.Reason,
+	}
+	if req.Currency != "" {
+		change.Currency = pgtype.Text{String: req.Currency, Valid: true}
+	}
+
+	var revert *db.CreateScheduledPriceChangeParams
+	if req.RevertAt != nil {
+		revert = &db.CreateScheduledPriceChangeParams{
+			Uuid: pgtype.UUID{
+				Bytes: uuid.New(),
+				Valid: true,
+			},
+			ProductUuid: product.Uuid,
+			RunAt:       pgtype.Timestamp{Time: req.RevertAt.UTC(), Valid: true},
+			CreatedBy:   actor,
+			Reason:      req.Reason,
+		}
+	}
+
+	res, err := s.repo.Create(ctx, change, revert)
+	if err != nil {
+		return nil, fmt.Errorf("failed to schedule price change: %w", err)
+	}
+
+	return priceChangeToResponse(res)
+}
+
+func (s *priceScheduleService) GetPriceChange(ctx context.Context, id string) (*models.PriceChangeResponse, error) {
+	changeUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, ErrInvalidPriceChangeID
+	}
+
+	change, err := s.repo.Get(ctx, changeUUID.String())
+	if err != nil {
+		if errors.Is(err, repository.ErrPriceChangeNotFound) {
+			return nil, ErrPriceChangeNotFound
+		}
+		return nil, fmt.Errorf("failed to get price change: %w", err)
+	}
+
+	return priceChangeToResponse(change)
+}
+
+func (s *priceScheduleService) ListPriceChanges(ctx context.Context, filter models.PriceChangeFilter) ([]*models.PriceChangeResponse, error) {
+	params := db.ListScheduledPriceChangesParams{
+		Limit:  int32(filter.Limit),
+		Offset: int32(filter.Offset),
+	}
+	if filter.Status != "" {
+		params.Status = db.NullScheduledPriceStatus{
+			ScheduledPriceStatus: db.ScheduledPriceStatus(filter.Status),
+			Valid:                true,
+		}
+	}
+	if filter.ProductCode != "" {
+		product, err := s.productRepo.Get(ctx, filter.ProductCode)
+		if err != nil {
+			if errors.Is(err, repository.ErrProductNotFound) {
+				return nil, ErrProductNotFound
+			}
+			return nil, fmt.Errorf("failed to get product: %w", err)
+		}
+		params.ProductUuid = product.Uuid
+	}
+
+	changes, err := s.repo.List(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list price changes: %w", err)
+	}
+
+	response := make([]*models.PriceChangeResponse, 0, len(changes))
+	for _, c := range changes {
+		change, err := priceChangeToResponse(c)
+		if err != nil {
+			return nil, err
+		}
+		response = append(response, change)
+	}
+
+	return response, nil
+}
+
+func (s *priceScheduleService) CancelPriceChange(ctx context.Context, id string) (*models.PriceChangeResponse, error) {
+	changeUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, ErrInvalidPriceChangeID
+	}
+
+	change, err := s.repo.Cancel(ctx, changeUUID.String())
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrPriceChangeNotFound):
+			return nil, ErrPriceChangeNotFound
+		case errors.Is(err, repository.ErrPriceChangeNotPending):
+			return nil, ErrPriceChangeNotPending
+		default:
+			return nil, fmt.Errorf("failed to cancel price change: %w", err)
+		}
+	}
+
+	return priceChangeToResponse(change)
+}
+
+func (s *priceScheduleService) ApplyDuePriceChanges(ctx context.Context) ([]*models.PriceChangeResponse, error) {
+	var applied []*models.PriceChangeResponse
+	for len(applied) < applyBatchSize {
+		change, err := s.repo.ApplyNextDue(ctx)
+		if err != nil {
+			if errors.Is(err, repository.ErrNoDuePriceChange) {
+				break
+			}
+			return applied, fmt.Errorf("failed to apply price change: %w", err)
+		}
+
+		res, err := priceChangeToResponse(change)
+		if err != nil {
+			return applied, err
+		}
+		applied = append(applied, res)
+	}
+
+	return applied, nil
+}
+
+func priceChangeToResponse(c db.ScheduledPriceChange) (*models.PriceChangeResponse, error) {
+	res := &models.PriceChangeResponse{
+		ID:        c.Uuid.String(),
+		ProductID: c.ProductUuid.String(),
+		Currency:  c.Currency.String,
+		RunAt:     c.RunAt.Time.Format(time.RFC3339),
+		Status:    models.PriceChangeStatus(c.Status),
+		Actor:     c.CreatedBy,
+		Reason:    c.Reason,
+		Error:     c.Error,
+		CreatedAt: c.CreatedAt.Time.Format(time.RFC3339),
+	}
+
+	if c.CustomerCost.Valid {
+		cost, err := repository.NumericToMoney(c.CustomerCost)
+		if err != nil {
+			return nil, err
+		}
+		res.CustomerCost = &cost
+	}
+	if c.RevertOf.Valid {
+		res.RevertOf = c.RevertOf.String()
+	}
+	if c.AppliedAt.Valid {
+		appliedAt := c.AppliedAt.Time.Format(time.RFC3339)
+		res.AppliedAt = &appliedAt
+	}
+
+	return res, nil
+}
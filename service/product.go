@@ -3,8 +3,10 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
 	"github.com/igntnk/stocky-oms/db"
 	"github.com/jackc/pgx/v5/pgtype"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/igntnk/stocky-oms/models"
@@ -14,18 +16,25 @@ import (
 type ProductService interface {
 	CreateProduct(ctx context.Context, req models.ProductCreateRequest) (*models.ProductResponse, error)
 	GetProduct(ctx context.Context, id string) (*models.ProductResponse, error)
+	// GetProductByCode returns the product with the product code orders
+	// refer to it by
+	GetProductByCode(ctx context.Context, code string) (*models.ProductResponse, error)
 	ListProducts(ctx context.Context, limit, offset int) ([]*models.ProductResponse, error)
 	UpdateProduct(ctx context.Context, id string, req models.ProductUpdateRequest) (*models.ProductResponse, error)
 	DeleteProduct(ctx context.Context, id string) error
 	GetProductsByOrder(ctx context.Context, orderID string) ([]*models.ProductResponse, error)
+	// GetPriceAt returns the price of the product that was in effect at the given time
+	GetPriceAt(ctx context.Context, id string, at time.Time) (*models.ProductPriceResponse, error)
+	ListPriceHistory(ctx context.Context, id string, filter models.ProductPriceFilter) ([]*models.ProductPriceResponse, error)
 }
 
 type productService struct {
-	repo repository.ProductRepository
+	repo            repository.ProductRepository
+	defaultCurrency string
 }
 
-func NewProductService(repo repository.ProductRepository) ProductService {
-	return &productService{repo: repo}
+func NewProductService(repo repository.ProductRepository, defaultCurrency string) ProductService {
+	return &productService{repo: repo, defaultCurrency: defaultCurrency}
 }
 
 func (s *productService) CreateProduct(ctx context.Context, req models.ProductCreateRequest) (*models.ProductResponse, error) {
@@ -38,9 +47,14 @@ func (s *productService) CreateProduct(ctx context.Context, req models.ProductCr
 		return nil, err
 	}
 
-	custCost, err := repository.Float64ToNumericWithPrecision(req.CustomerCost)
-	if err != nil {
-		return nil, err
+	currency := req.Currency
+	if currency == "" {
+		currency = s.defaultCurrency
+	}
+
+	taxClass := req.TaxClass
+	if taxClass == "" {
+		taxClass = models.DefaultTaxClass
 	}
 
 	dbProduct, err := s.repo.Create(ctx, db.CreateProductParams{
@@ -50,8 +64,11 @@ func (s *productService) CreateProduct(ctx context.Context, req models.ProductCr
 		},
 		Name:         req.Name,
 		ProductCode:  prodUuid,
-		CustomerCost: custCost,
-	})
+		CustomerCost: repository.MoneyToNumeric(req.CustomerCost),
+		Currency:     currency,
+		TaxClass:     taxClass,
+		WeightGrams:  int32(req.WeightGrams),
+	}, callerActor(ctx, ""))
 	if err != nil {
 		return nil, err
 	}
@@ -60,15 +77,24 @@ func (s *productService) CreateProduct(ctx context.Context, req models.ProductCr
 }
 
 func (s *productService) GetProduct(ctx context.Context, id string) (*models.ProductResponse, error) {
-	productUUID, err := uuid.Parse(id)
+	dbProduct, err := s.getProduct(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.dbToResponse(dbProduct)
+}
+
+func (s *productService) GetProductByCode(ctx context.Context, code string) (*models.ProductResponse, error) {
+	productCode, err := uuid.Parse(code)
 	if err != nil {
-		return nil, errors.New("invalid product id")
+		return nil, ErrInvalidProductID
 	}
 
-	dbProduct, err := s.repo.Get(ctx, productUUID.String())
+	dbProduct, err := s.repo.Get(ctx, productCode.String())
 	if err != nil {
 		if errors.Is(err, repository.ErrProductNotFound) {
-			return nil, errors.New("product not found")
+			return nil, ErrProductNotFound
 		}
 		return nil, err
 	}
@@ -97,7 +123,7 @@ func (s *productService) ListProducts(ctx context.Context, limit, offset int) ([
 func (s *productService) UpdateProduct(ctx context.Context, id string, req models.ProductUpdateRequest) (*models.ProductResponse, error) {
 	productUUID, err := uuid.Parse(id)
 	if err != nil {
-		return nil, errors.New("invalid product id")
+		return nil, ErrInvalidProductID
 	}
 
 	updateParams := db.UpdateProductParams{
@@ -108,11 +134,11 @@ func (s *productService) UpdateProduct(ctx context.Context, id string, req model
 	}
 
 	if req.Name != nil {
-		updateParams.Name = *req.Name
+		updateParams.Name = pgtype.Text{String: *req.Name, Valid: true}
 	}
 	if req.ProductCode != nil {
 		var prodUuid pgtype.UUID
-		err := prodUuid.Scan(req.ProductCode)
+		err := prodUuid.Scan(*req.ProductCode)
 		if err != nil {
 			return nil, err
 		}
@@ -120,18 +146,22 @@ func (s *productService) UpdateProduct(ctx context.Context, id string, req model
 		updateParams.ProductCode = prodUuid
 	}
 	if req.CustomerCost != nil {
-		custCost, err := repository.Float64ToNumericWithPrecision(*req.CustomerCost)
-		if err != nil {
-			return nil, err
-		}
-
-		updateParams.CustomerCost = custCost
+		updateParams.CustomerCost = repository.MoneyToNumeric(*req.CustomerCost)
+	}
+	if req.Currency != nil {
+		updateParams.Currency = pgtype.Text{String: *req.Currency, Valid: true}
+	}
+	if req.TaxClass != nil {
+		updateParams.TaxClass = pgtype.Text{String: *req.TaxClass, Valid: true}
+	}
+	if req.WeightGrams != nil {
+		updateParams.WeightGrams = pgtype.Int4{Int32: int32(*req.WeightGrams), Valid: true}
 	}
 
-	dbProduct, err := s.repo.Update(ctx, updateParams)
+	dbProduct, err := s.repo.Update(ctx, updateParams, callerActor(ctx, ""), req.Reason)
 	if err != nil {
 		if errors.Is(err, repository.ErrProductNotFound) {
-			return nil, errors.New("product not found")
+			return nil, ErrProductNotFound
 		}
 		return nil, err
 	}
@@ -142,12 +172,12 @@ func (s *productService) UpdateProduct(ctx context.Context, id string, req model
 func (s *productService) DeleteProduct(ctx context.Context, id string) error {
 	productUUID, err := uuid.Parse(id)
 	if err != nil {
-		return errors.New("invalid product id")
+		return ErrInvalidProductID
 	}
 
 	if err := s.repo.Delete(ctx, productUUID.String()); err != nil {
 		if errors.Is(err, repository.ErrProductNotFound) {
-			return errors.New("product not found")
+			return ErrProductNotFound
 		}
 		return err
 	}
@@ -158,7 +188,7 @@ func (s *productService) DeleteProduct(ctx context.Context, id string) error {
 func (s *productService) GetProductsByOrder(ctx context.Context, orderID string) ([]*models.ProductResponse, error) {
 	_, err := uuid.Parse(orderID)
 	if err != nil {
-		return nil, errors.New("invalid order id")
+		return nil, ErrInvalidOrderID
 	}
 
 	dbProducts, err := s.repo.GetByOrder(ctx, orderID)
@@ -178,9 +208,89 @@ func (s *productService) GetProductsByOrder(ctx context.Context, orderID string)
 	return response, nil
 }
 
+func (s *productService) GetPriceAt(ctx context.Context, id string, at time.Time) (*models.ProductPriceResponse, error) {
+	product, err := s.getProduct(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	price, err := s.repo.GetPriceAt(ctx, product.Uuid, at)
+	if err != nil {
+		if errors.Is(err, repository.ErrPriceNotFound) {
+			return nil, ErrPriceNotFound
+		}
+		return nil, fmt.Errorf("failed to get price: %w", err)
+	}
+
+	return priceToResponse(price)
+}
+
+func (s *productService) ListPriceHistory(ctx context.Context, id string, filter models.ProductPriceFilter) ([]*models.ProductPriceResponse, error) {
+	product, err := s.getProduct(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	prices, err := s.repo.ListPriceHistory(ctx, product.Uuid, int32(filter.Limit), int32(filter.Offset))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list price history: %w", err)
+	}
+
+	response := make([]*models.ProductPriceResponse, 0, len(prices))
+	for _, p := range prices {
+		price, err := priceToResponse(p)
+		if err != nil {
+			return nil, err
+		}
+		response = append(response, price)
+	}
+
+	return response, nil
+}
+
+func (s *productService) getProduct(ctx context.Context, id string) (db.Product, error) {
+	productUUID, err := uuid.Parse(id)
+	if err != nil {
+		return db.Product{}, ErrInvalidProductID
+	}
+
+	product, err := s.repo.GetByUUID(ctx, productUUID.String())
+	if err != nil {
+		if errors.Is(err, repository.ErrProductNotFound) {
+			return db.Product{}, ErrProductNotFound
+		}
+		return db.Product{}, err
+	}
+	return product, nil
+}
+
+func priceToResponse(p db.ProductPriceHistory) (*models.ProductPriceResponse, error) {
+	cost, err := repository.NumericToMoney(p.CustomerCost)
+	if err != nil {
+		return nil, err
+	}
+
+	var effectiveTo *string
+	if p.EffectiveTo.Valid {
+		et := p.EffectiveTo.Time.Format(time.RFC3339)
+		effectiveTo = &et
+	}
+
+	return &models.ProductPriceResponse{
+		ID:            p.Uuid.String(),
+		ProductID:     p.ProductUuid.String(),
+		CustomerCost:  cost,
+		Currency:      p.Currency,
+		EffectiveFrom: p.EffectiveFrom.Time.Format(time.RFC3339),
+		EffectiveTo:   effectiveTo,
+		ChangedBy:     p.ChangedBy,
+		Reason:        p.Reason,
+	}, nil
+}
+
 // Helper function to convert DB model to response model
 func (s *productService) dbToResponse(p db.Product) (*models.ProductResponse, error) {
-	cost, err := repository.NumericToFloat64(p.CustomerCost)
+	cost, err := repository.NumericToMoney(p.CustomerCost)
 	if err != nil {
 		return nil, err
 	}
@@ -190,5 +300,8 @@ func (s *productService) dbToResponse(p db.Product) (*models.ProductResponse, er
 		Name:         p.Name,
 		ProductCode:  p.ProductCode.String(),
 		CustomerCost: cost,
+		Currency:     p.Currency,
+		TaxClass:     p.TaxClass,
+		WeightGrams:  int(p.WeightGrams),
 	}, nil
 }
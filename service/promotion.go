@@ -0,0 +1,248 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/igntnk/stocky-oms/db"
+	"github.com/igntnk/stocky-oms/models"
+	"github.com/igntnk/stocky-oms/repository"
+	"github.com/jackc/pgx/v5/pgtype"
+	"time"
+)
+
+type PromotionService interface {
+	CreatePromotion(ctx context.Context, req models.PromotionCreateRequest) (*models.PromotionResponse, error)
+	GetPromotion(ctx context.Context, id string) (*models.PromotionResponse, error)
+	ListPromotions(ctx context.Context, filter models.PromotionFilter) ([]*models.PromotionResponse, error)
+	UpdatePromotion(ctx context.Context, id string, req models.PromotionUpdateRequest) (*models.PromotionResponse, error)
+	DeletePromotion(ctx context.Context, id string) error
+}
+
+type promotionService struct {
+	repo            repository.PromotionRepository
+	defaultCurrency string
+}
+
+func NewPromotionService(repo repository.PromotionRepository, defaultCurrency string) PromotionService {
+	return &promotionService{repo: repo, defaultCurrency: defaultCurrency}
+}
+
+func (s *promotionService) CreatePromotion(ctx context.Context, req models.PromotionCreateRequest) (*models.PromotionResponse, error) {
+	params := db.CreatePromotionParams{
+		Uuid: pgtype.UUID{
+			Bytes: uuid.New(),
+			Valid: true,
+		},
+		Name:  req.Name,
+		Kind:  db.PromotionKind(req.Kind),
+		Scope: db.PromotionScope(req.Scope),
+	}
+
+	if req.Code != "" {
+		params.Code = pgtype.Text{String: req.Code, Valid: true}
+	}
+
+	switch req.Scope {
+	case models.PromotionScopeProduct:
+		err := params.ProductCode.Scan(req.ProductCode)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid product code", ErrInvalidPromotion)
+		}
+	case models.PromotionScopeOrder:
+		if req.Kind == models.PromotionKindBuyXGetY {
+			return nil, fmt.Errorf("%w: buy_x_get_y promotions apply to a product", ErrInvalidPromotion)
+		}
+	default:
+		return nil, fmt.Errorf("%w: unknown scope %q", ErrInvalidPromotion, req.Scope)
+	}
+
+	switch req.Kind {
+	case models.PromotionKindPercentage:
+		if req.Percent == nil || req.Percent.Sign() <= 0 || req.Percent.Cmp(models.MoneyFromCents(100_00)) > 0 {
+			return nil, fmt.Errorf("%w: percent must be in (0, 100]", ErrInvalidPromotion)
+		}
+		params.Percent = repository.MoneyToNumeric(*req.Percent)
+	case models.PromotionKindFixed:
+		if req.Amount == nil || req.Amount.Sign() <= 0 {
+			return nil, fmt.Errorf("%w: amount must be positive", ErrInvalidPromotion)
+		}
+		currency := req.Currency
+		if currency == "" {
+			currency = s.defaultCurrency
+		}
+		params.Amount = repository.MoneyToNumeric(*req.Amount)
+		params.Currency = pgtype.Text{String: currency, Valid: true}
+	case models.PromotionKindBuyXGetY:
+		if req.BuyQuantity <= 0 || req.FreeQuantity <= 0 {
+			return nil, fmt.Errorf("%w: buy and free quantities must be positive", ErrInvalidPromotion)
+		}
+		params.BuyQuantity = pgtype.Int4{Int32: int32(req.BuyQuantity), Valid: true}
+		params.FreeQuantity = pgtype.Int4{Int32: int32(req.FreeQuantity), Valid: true}
+	default:
+		return nil, fmt.Errorf("%w: unknown kind %q", ErrInvalidPromotion, req.Kind)
+	}
+
+	if req.UsageLimit != nil {
+		params.UsageLimit = pgtype.Int4{Int32: int32(*req.UsageLimit), Valid: true}
+	}
+	if req.ValidFrom != nil {
+		params.ValidFrom = pgtype.Timestamp{Time: *req.ValidFrom, Valid: true}
+	}
+	if req.ValidTo != nil {
+		params.ValidTo = pgtype.Timestamp{Time: *req.ValidTo, Valid: true}
+	}
+	if req.ValidFrom != nil && req.ValidTo != nil && !req.ValidTo.After(*req.ValidFrom) {
+		return nil, fmt.Errorf("%w: valid_to must be after valid_from", ErrInvalidPromotion)
+	}
+
+	promotion, err := s.repo.Create(ctx, params)
+	if err != nil {
+		if errors.Is(err, repository.ErrPromotionCodeUsed) {
+			return nil, ErrPromotionCodeUsed
+		}
+		return nil, fmt.Errorf("failed to create promotion: %w", err)
+	}
+
+	return promotionToResponse(promotion)
+}
+
+func (s *promotionService) GetPromotion(ctx context.Context, id string) (*models.PromotionResponse, error) {
+	promotionUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, ErrInvalidPromotionID
+	}
+
+	promotion, err := s.repo.Get(ctx, promotionUUID.String())
+	if err != nil {
+		if errors.Is(err, repository.ErrPromotionNotFound) {
+			return nil, ErrPromotionNotFound
+		}
+		return nil, fmt.Errorf("failed to get promotion: %w", err)
+	}
+
+	return promotionToResponse(promotion)
+}
+
+func (s *promotionService) ListPromotions(ctx context.Context, filter models.PromotionFilter) ([]*models.PromotionResponse, error) {
+	promotions, err := s.repo.List(ctx, int32(filter.Limit), int32(filter.Offset))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list promotions: %w", err)
+	}
+
+	response := make([]*models.PromotionResponse, 0, len(promotions))
+	for _, p := range promotions {
+		promotion, err := promotionToResponse(p)
+		if err != nil {
+			return nil, err
+		}
+		response = append(response, promotion)
+	}
+
+	return response, nil
+}
+
+func (s *promotionService) UpdatePromotion(ctx context.Context, id string, req models.PromotionUpdateRequest) (*models.PromotionResponse, error) {
+	promotionUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, ErrInvalidPromotionID
+	}
+
+	updateParams := db.UpdatePromotionParams{
+		Uuid: pgtype.UUID{
+			Bytes: promotionUUID,
+			Valid: true,
+		},
+	}
+
+	if req.Name != nil {
+		updateParams.Name = pgtype.Text{String: *req.Name, Valid: true}
+	}
+	if req.Active != nil {
+		updateParams.Active = pgtype.Bool{Bool: *req.Active, Valid: true}
+	}
+	if req.UsageLimit != nil {
+		updateParams.UsageLimit = pgtype.Int4{Int32: int32(*req.UsageLimit), Valid: true}
+	}
+	if req.ValidFrom != nil {
+		updateParams.ValidFrom = pgtype.Timestamp{Time: *req.ValidFrom, Valid: true}
+	}
+	if req.ValidTo != nil {
+		updateParams.ValidTo = pgtype.Timestamp{Time: *req.ValidTo, Valid: true}
+	}
+
+	promotion, err := s.repo.Update(ctx, updateParams)
+	if err != nil {
+		if errors.Is(err, repository.ErrPromotionNotFound) {
+			return nil, ErrPromotionNotFound
+		}
+		return nil, fmt.Errorf("failed to update promotion: %w", err)
+	}
+
+	return promotionToResponse(promotion)
+}
+
+func (s *promotionService) DeletePromotion(ctx context.Context, id string) error {
+	promotionUUID, err := uuid.Parse(id)
+	if err != nil {
+		return ErrInvalidPromotionID
+	}
+
+	if err := s.repo.Delete(ctx, promotionUUID.String()); err != nil {
+		if errors.Is(err, repository.ErrPromotionNotFound) {
+			return ErrPromotionNotFound
+		}
+		return fmt.Errorf("failed to delete promotion: %w", err)
+	}
+
+	return nil
+}
+
+func promotionToResponse(p db.Promotion) (*models.PromotionResponse, error) {
+	res := &models.PromotionResponse{
+		ID:           p.Uuid.String(),
+		Code:         p.Code.String,
+		Name:         p.Name,
+		Kind:         models.PromotionKind(p.Kind),
+		Scope:        models.PromotionScope(p.Scope),
+		Currency:     p.Currency.String,
+		BuyQuantity:  int(p.BuyQuantity.Int32),
+		FreeQuantity: int(p.FreeQuantity.Int32),
+		UsageCount:   int(p.UsageCount),
+		Active:       p.Active,
+		CreatedAt:    p.CreatedAt.Time.Format(time.RFC3339),
+	}
+
+	if p.ProductCode.Valid {
+		res.ProductCode = p.ProductCode.String()
+	}
+	if p.Percent.Valid {
+		percent, err := repository.NumericToMoney(p.Percent)
+		if err != nil {
+			return nil, err
+		}
+		res.Percent = &percent
+	}
+	if p.Amount.Valid {
+		amount, err := repository.NumericToMoney(p.Amount)
+		if err != nil {
+			return nil, err
+		}
+		res.Amount = &amount
+	}
+	if p.UsageLimit.Valid {
+		limit := int(p.UsageLimit.Int32)
+		res.UsageLimit = &limit
+	}
+	if p.ValidFrom.Valid {
+		validFrom := p.ValidFrom.Time.Format(time.RFC3339)
+		res.ValidFrom = &validFrom
+	}
+	if p.ValidTo.Valid {
+		validTo := p.ValidTo.Time.Format(time.RFC3339)
+		res.ValidTo = &validTo
+	}
+
+	return res, nil
+}
@@ -0,0 +1,388 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/igntnk/stocky-oms/clients"
+	"github.com/igntnk/stocky-oms/db"
+	"github.com/igntnk/stocky-oms/models"
+	"github.com/igntnk/stocky-oms/repository"
+	"github.com/jackc/pgx/v5/pgtype"
+	"math/big"
+	"time"
+)
+
+// returnStatusTransitions lists the statuses each return status may move to.
+// Rejected and refunded returns are final.
+var returnStatusTransitions = map[models.ReturnStatus][]models.ReturnStatus{
+	models.ReturnStatusRequested: {models.ReturnStatusApproved, models.ReturnStatusRejected},
+	models.ReturnStatusApproved:  {models.ReturnStatusReceived},
+	models.ReturnStatusReceived:  {models.ReturnStatusRefunded},
+}
+
+type ReturnService interface {
+	CreateReturn(ctx context.Context, orderID string, req models.ReturnCreateRequest) (*models.ReturnResponse, error)
+	GetReturn(ctx context.Context, id string) (*models.ReturnResponse, error)
+	ListReturns(ctx context.Context, filter models.ReturnFilter) ([]*models.ReturnResponse, error)
+	// ChangeReturnStatus moves the return forward. Accepted returns write their
+	// products back to SMS, repeating the request retries a failed write.
+	ChangeReturnStatus(ctx context.Context, id string, req models.ReturnStatusChangeRequest) (*models.ReturnResponse, error)
+}
+
+type returnService struct {
+	sms       clients.SMSClient
+	repo      repository.ReturnRepository
+	orderRepo repository.OrderRepository
+	payments  PaymentService
+}
+
+func NewReturnService(
+	smsClient clients.SMSClient,
+	repo repository.ReturnRepository,
+	orderRepo repository.OrderRepository,
+	payments PaymentService,
+) ReturnService {
+	return &returnService{
+		sms:       smsClient,
+		repo:      repo,
+		orderRepo: orderRepo,
+		payments:  payments,
+	}
+}
+
+func (s *returnService) CreateReturn(ctx context.Context, orderID string, req models.ReturnCreateRequest) (*models.ReturnResponse, error) {
+	orderUUID, err := uuid.Parse(orderID)
+	if err != nil {
+		return nil, ErrInvalidOrderID
+	}
+
+	order, err := s.orderRepo.Get(ctx, orderUUID.String())
+	if err != nil {
+		if errors.Is(err, repository.ErrOrderNotFound) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+
+	products, err := s.orderRepo.GetOrderProducts(ctx, order.Uuid.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order products: %w", err)
+	}
+
+	var refund models.Money
+	lines := make([]db.AddReturnLineParams, 0, len(req.Lines))
+	for _, line := range req.Lines {
+		product, ok := findProductLine(products, line.ProductID)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrOrderProductNotFound, line.ProductID)
+		}
+
+		lineRefund, err := returnRefund(product, int32(line.Amount))
+		if err != nil {
+			return nil, err
+		}
+		refund = refund.Add(lineRefund)
+
+		lines = append(lines, db.AddReturnLineParams{
+			ProductCode:  product.ProductCode,
+			Amount:       int32(line.Amount),
+			RefundAmount: repository.MoneyToNumeric(lineRefund),
+		})
+	}
+
+	ret, err := s.repo.Create(ctx, db.CreateReturnParams{
+		Uuid: pgtype.UUID{
+			Bytes: uuid.New(),
+			Valid: true,
+		},
+		OrderUuid:    order.Uuid,
+		RefundAmount: repository.MoneyToNumeric(refund),
+		Currency:     order.Currency,
+		CreatedBy:    callerActor(ctx, ""),
+		Reason:       req.Reason,
+	}, lines)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrOrderNotFound):
+			return nil, ErrOrderNotFound
+		case errors.Is(err, repository.ErrOrderNotReturnable):
+			return nil, ErrOrderNotReturnable
+		case errors.Is(err, repository.ErrReturnProductNotInOrder):
+			return nil, ErrOrderProductNotFound
+		case errors.Is(err, repository.ErrReturnExceedsOrder):
+			return nil, ErrReturnExceedsOrder
+		default:
+			return nil, fmt.Errorf("failed to create return: %w", err)
+		}
+	}
+
+	return s.buildReturnResponse(ctx, ret)
+}
+
+func (s *returnService) GetReturn(ctx context.Context, id string) (*models.ReturnResponse, error) {
+	returnUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, ErrInvalidReturnID
+	}
+
+	ret, err := s.repo.Get(ctx, returnUUID.String())
+	if err != nil {
+		if errors.Is(err, repository.ErrReturnNotFound) {
+			return nil, ErrReturnNotFound
+		}
+		return nil, fmt.Errorf("failed to get return: %w", err)
+	}
+
+	return s.buildReturnResponse(ctx, ret)
+}
+
+func (s *returnService) ListReturns(ctx context.Context, filter models.ReturnFilter) ([]*models.ReturnResponse, error) {
+	params := db.ListReturnsParams{
+		Limit:  int32(filter.Limit),
+		Offset: int32(filter.Offset),
+	}
+	if filter.Status != "" {
+		params.Status = db.NullReturnStatus{
+			ReturnStatus: db.ReturnStatus(filter.Status),
+			Valid:        true,
+		}
+	}
+	if filter.OrderID != "" {
+		orderUUID, err := uuid.Parse(filter.OrderID)
+		if err != nil {
+			return nil, ErrInvalidOrderID
+		}
+		params.OrderUuid = pgtype.UUID{
+			Bytes: orderUUID,
+			Valid: true,
+		}
+	}
+
+	returns, err := s.repo.List(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list returns: %w", err)
+	}
+
+	result := make([]*models.ReturnResponse, 0, len(returns))
+	for _, ret := range returns {
+		res, err := s.buildReturnResponse(ctx, ret)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, res)
+	}
+
+	return result, nil
+}
+
+func (s *returnService) ChangeReturnStatus(ctx context.Context, id string, req models.ReturnStatusChangeRequest) (*models.ReturnResponse, error) {
+	returnUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, ErrInvalidReturnID
+	}
+
+	ret, err := s.repo.Get(ctx, returnUUID.String())
+	if err != nil {
+		if errors.Is(err, repository.ErrReturnNotFound) {
+			return nil, ErrReturnNotFound
+		}
+		return nil, fmt.Errorf("failed to get return: %w", err)
+	}
+
+	from := models.ReturnStatus(ret.Status)
+	if from != req.Status {
+		if !canReturnTransition(from, req.Status) {
+			return nil, fmt.Errorf("%w: %s -> %s", ErrInvalidReturnTransition, from, req.Status)
+		}
+
+		ret, err = s.repo.UpdateStatus(ctx, db.UpdateReturnStatusParams{
+			Status:     db.ReturnStatus(req.Status),
+			Uuid:       ret.Uuid,
+			FromStatus: ret.Status,
+		})
+		if err != nil {
+			if errors.Is(err, repository.ErrReturnStatusChanged) {
+				return nil, ErrReturnStatusConflict
+			}
+			return nil, fmt.Errorf("failed to update return status: %w", err)
+		}
+
+		if ret.Status == db.ReturnStatusRefunded {
+			ret, err = s.refund(ctx, ret)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if ret.Status != db.ReturnStatusRequested && ret.Status != db.ReturnStatusRejected && !ret.StockReturned {
+		ret, err = s.returnStock(ctx, ret)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return s.buildReturnResponse(ctx, ret)
+}
+
+// refund pays the refund amount of a return that was just marked refunded back
+// through the order payment. The return goes back to received if the refund
+// fails, so the status is only kept once the money is returned.
+func (s *returnService) refund(ctx context.Context, ret db.OrderReturn) (db.OrderReturn, error) {
+	amount, err := repository.NumericToMoney(ret.RefundAmount)
+	if err != nil {
+		return db.OrderReturn{}, err
+	}
+
+	if amount.IsZero() {
+		return ret, nil
+	}
+
+	_, err = s.payments.RefundOrder(ctx, ret.OrderUuid.String(), amount)
+	if err == nil {
+		return ret, nil
+	}
+
+	_, revertErr := s.repo.UpdateStatus(context.WithoutCancel(ctx), db.UpdateReturnStatusParams{
+		Status:     db.ReturnStatusReceived,
+		Uuid:       ret.Uuid,
+		FromStatus: db.ReturnStatusRefunded,
+	})
+	if revertErr != nil {
+		return db.OrderReturn{}, errors.Join(err, fmt.Errorf("failed to revert return status: %w", revertErr))
+	}
+	return db.OrderReturn{}, err
+}
+
+// returnStock writes the returned products back to SMS. The return is claimed
+// first so the products are written once, and released again if SMS fails.
+func (s *returnService) returnStock(ctx context.Context, ret db.OrderReturn) (db.OrderReturn, error) {
+	claimed, err := s.repo.ClaimStock(ctx, ret.Uuid)
+	if err != nil {
+		if errors.Is(err, repository.ErrReturnStockClaimed) {
+			return ret, nil
+		}
+		return db.OrderReturn{}, fmt.Errorf("failed to claim return stock: %w", err)
+	}
+
+	// The claim must be released even if the caller went away
+	recordCtx := context.WithoutCancel(ctx)
+
+	lines, err := s.repo.ListLines(ctx, ret.Uuid)
+	if err != nil {
+		return db.OrderReturn{}, errors.Join(
+			fmt.Errorf("failed to get return lines: %w", err),
+			s.repo.ReleaseStock(recordCtx, ret.Uuid),
+		)
+	}
+
+	reqPr := make([]models.ProductWriteOffRequest, len(lines))
+	for i, line := range lines {
+		reqPr[i] = models.ProductWriteOffRequest{
+			Uuid:   line.ProductCode.String(),
+			Amount: float64(line.Amount),
+		}
+	}
+
+	_, err = s.sms.WriteOnCoupleProducts(ctx, reqPr)
+	if err != nil {
+		return db.OrderReturn{}, errors.Join(
+			fmt.Errorf("%w: %w", ErrStockReturnFailed, err),
+			s.repo.ReleaseStock(recordCtx, ret.Uuid),
+		)
+	}
+
+	return claimed, nil
+}
+
+func canReturnTransition(from, to models.ReturnStatus) bool {
+	for _, allowed := range returnStatusTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// returnRefund is the refund for amount units of the order line: their share
+// of what the line cost after its discount, with exclusive tax included
+func returnRefund(line db.GetOrderProductsRow, amount int32) (models.Money, error) {
+	price, err := repository.NumericToMoney(line.ResultPrice)
+	if err != nil {
+		return models.Money{}, err
+	}
+
+	discount, err := repository.NumericToMoney(line.Discount)
+	if err != nil {
+		return models.Money{}, err
+	}
+
+	total := price.Mul(int64(line.Amount)).Sub(discount)
+	if !line.TaxInclusive {
+		tax, err := repository.NumericToMoney(line.TaxAmount)
+		if err != nil {
+			return models.Money{}, err
+		}
+		total = total.Add(tax)
+	}
+
+	return total.MulRat(big.NewRat(int64(amount), int64(line.Amount))), nil
+}
+
+func findProductLine(products []db.GetOrderProductsRow, productCode uuid.UUID) (db.GetOrderProductsRow, bool) {
+	for _, p := range products {
+		if p.ProductCode.Bytes == productCode {
+			return p, true
+		}
+	}
+	return db.GetOrderProductsRow{}, false
+}
+
+func (s *returnService) buildReturnResponse(ctx context.Context, ret db.OrderReturn) (*models.ReturnResponse, error) {
+	lines, err := s.repo.ListLines(ctx, ret.Uuid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get return lines: %w", err)
+	}
+
+	refund, err := repository.NumericToMoney(ret.RefundAmount)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &models.ReturnResponse{
+		ID:            ret.Uuid.String(),
+		OrderID:       ret.OrderUuid.String(),
+		Status:        models.ReturnStatus(ret.Status),
+		RefundAmount:  refund,
+		Currency:      ret.Currency,
+		StockReturned: ret.StockReturned,
+		Actor:         ret.CreatedBy,
+		Reason:        ret.Reason,
+		CreatedAt:     ret.CreatedAt.Time.Format(time.RFC3339),
+		UpdatedAt:     ret.UpdatedAt.Time.Format(time.RFC3339),
+		Lines:         make([]models.ReturnLineResponse, 0, len(lines)),
+	}
+	if ret.RefundedAt.Valid {
+		refundedAt := ret.RefundedAt.Time.Format(time.RFC3339)
+		res.RefundedAt = &refundedAt
+	}
+
+	for _, line := range lines {
+		lineRefund, err := repository.NumericToMoney(line.RefundAmount)
+		if err != nil {
+			return nil, err
+		}
+
+		res.Lines = append(res.Lines, models.ReturnLineResponse{
+			ProductID:    line.ProductUuid.String(),
+			ProductCode:  line.ProductCode.String(),
+			Name:         line.ProductName,
+			Amount:       int(line.Amount),
+			RefundAmount: lineRefund,
+		})
+	}
+
+	return res, nil
+}
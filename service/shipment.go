@@ -0,0 +1,240 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/igntnk/stocky-oms/db"
+	"github.com/igntnk/stocky-oms/models"
+	"github.com/igntnk/stocky-oms/repository"
+	"github.com/jackc/pgx/v5/pgtype"
+	"time"
+)
+
+// shipmentStatusTransitions lists the statuses each shipment status may move
+// to. Delivered and cancelled shipments are final.
+var shipmentStatusTransitions = map[models.ShipmentStatus][]models.ShipmentStatus{
+	models.ShipmentStatusPending: {models.ShipmentStatusShipped, models.ShipmentStatusCancelled},
+	models.ShipmentStatusShipped: {models.ShipmentStatusDelivered},
+}
+
+type ShipmentService interface {
+	CreateShipment(ctx context.Context, orderID string, req models.ShipmentCreateRequest) (*models.ShipmentResponse, error)
+	GetShipment(ctx context.Context, id string) (*models.ShipmentResponse, error)
+	ListOrderShipments(ctx context.Context, orderID string) ([]*models.ShipmentResponse, error)
+	// ChangeShipmentStatus moves the shipment forward. The order status follows
+	// the progress of its shipments.
+	ChangeShipmentStatus(ctx context.Context, id string, req models.ShipmentStatusChangeRequest) (*models.ShipmentResponse, error)
+}
+
+type shipmentService struct {
+	repo      repository.ShipmentRepository
+	orderRepo repository.OrderRepository
+	payments  PaymentService
+}
+
+func NewShipmentService(repo repository.ShipmentRepository, orderRepo repository.OrderRepository, payments PaymentService) ShipmentService {
+	return &shipmentService{repo: repo, orderRepo: orderRepo, payments: payments}
+}
+
+func (s *shipmentService) CreateShipment(ctx context.Context, orderID string, req models.ShipmentCreateRequest) (*models.ShipmentResponse, error) {
+	orderUUID, err := uuid.Parse(orderID)
+	if err != nil {
+		return nil, ErrInvalidOrderID
+	}
+
+	lines := make([]db.AddShipmentLineParams, 0, len(req.Lines))
+	for _, line := range req.Lines {
+		lines = append(lines, db.AddShipmentLineParams{
+			ProductCode: pgtype.UUID{
+				Bytes: line.ProductID,
+				Valid: true,
+			},
+			Amount: int32(line.Amount),
+		})
+	}
+
+	shipment, err := s.repo.Create(ctx, db.CreateShipmentParams{
+		Uuid: pgtype.UUID{
+			Bytes: uuid.New(),
+			Valid: true,
+		},
+		OrderUuid: pgtype.UUID{
+			Bytes: orderUUID,
+			Valid: true,
+		},
+		Carrier:        req.Carrier,
+		TrackingNumber: req.TrackingNumber,
+	}, lines)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrOrderNotFound):
+			return nil, ErrOrderNotFound
+		case errors.Is(err, repository.ErrOrderNotShippable):
+			return nil, ErrOrderNotShippable
+		case errors.Is(err, repository.ErrShipmentProductNotInOrder):
+			return nil, ErrOrderProductNotFound
+		case errors.Is(err, repository.ErrShipmentExceedsOrder):
+			return nil, ErrShipmentExceedsOrder
+		default:
+			return nil, fmt.Errorf("failed to create shipment: %w", err)
+		}
+	}
+
+	return s.buildShipmentResponse(ctx, shipment)
+}
+
+func (s *shipmentService) GetShipment(ctx context.Context, id string) (*models.ShipmentResponse, error) {
+	shipmentUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, ErrInvalidShipmentID
+	}
+
+	shipment, err := s.repo.Get(ctx, shipmentUUID.String())
+	if err != nil {
+		if errors.Is(err, repository.ErrShipmentNotFound) {
+			return nil, ErrShipmentNotFound
+		}
+		return nil, fmt.Errorf("failed to get shipment: %w", err)
+	}
+
+	return s.buildShipmentResponse(ctx, shipment)
+}
+
+func (s *shipmentService) ListOrderShipments(ctx context.Context, orderID string) ([]*models.ShipmentResponse, error) {
+	orderUUID, err := uuid.Parse(orderID)
+	if err != nil {
+		return nil, ErrInvalidOrderID
+	}
+
+	_, err = s.orderRepo.Get(ctx, orderUUID.String())
+	if err != nil {
+		if errors.Is(err, repository.ErrOrderNotFound) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+
+	shipments, err := s.repo.ListByOrder(ctx, orderUUID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list shipments: %w", err)
+	}
+
+	result := make([]*models.ShipmentResponse, 0, len(shipments))
+	for _, shipment := range shipments {
+		res, err := s.buildShipmentResponse(ctx, shipment)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, res)
+	}
+
+	return result, nil
+}
+
+func (s *shipmentService) ChangeShipmentStatus(ctx context.Context, id string, req models.ShipmentStatusChangeRequest) (*models.ShipmentResponse, error) {
+	shipmentUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, ErrInvalidShipmentID
+	}
+
+	shipment, err := s.repo.Get(ctx, shipmentUUID.String())
+	if err != nil {
+		if errors.Is(err, repository.ErrShipmentNotFound) {
+			return nil, ErrShipmentNotFound
+		}
+		return nil, fmt.Errorf("failed to get shipment: %w", err)
+	}
+
+	from := models.ShipmentStatus(shipment.Status)
+	if !canShipmentTransition(from, req.Status) {
+		return nil, fmt.Errorf("%w: %s -> %s", ErrInvalidShipmentTransition, from, req.Status)
+	}
+
+	arg := db.UpdateShipmentStatusParams{
+		Status:     db.ShipmentStatus(req.Status),
+		Uuid:       shipment.Uuid,
+		FromStatus: shipment.Status,
+	}
+	if req.Carrier != nil {
+		arg.Carrier = pgtype.Text{String: *req.Carrier, Valid: true}
+	}
+	if req.TrackingNumber != nil {
+		arg.TrackingNumber = pgtype.Text{String: *req.TrackingNumber, Valid: true}
+	}
+
+	reason := req.Reason
+	if reason == "" {
+		reason = fmt.Sprintf("shipment %s %s", shipment.Uuid, req.Status)
+	}
+
+	// Delivering the last shipment completes the order. Its payment is captured
+	// before that is committed, a failed capture leaves the shipment shipped
+	// so delivering it again retries the capture.
+	shipment, err = s.repo.UpdateStatus(ctx, arg, callerActor(ctx, ""), reason, func(ctx context.Context, order db.Order) error {
+		_, err := s.payments.CaptureOrder(ctx, order.Uuid.String())
+		if err != nil && !errors.Is(err, ErrPaymentNotFound) {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrShipmentNotFound):
+			return nil, ErrShipmentNotFound
+		case errors.Is(err, repository.ErrShipmentStatusChanged),
+			errors.Is(err, repository.ErrOrderStatusChanged):
+			return nil, ErrShipmentStatusConflict
+		default:
+			return nil, fmt.Errorf("failed to update shipment status: %w", err)
+		}
+	}
+
+	return s.buildShipmentResponse(ctx, shipment)
+}
+
+func canShipmentTransition(from, to models.ShipmentStatus) bool {
+	for _, allowed := range shipmentStatusTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *shipmentService) buildShipmentResponse(ctx context.Context, shipment db.Shipment) (*models.ShipmentResponse, error) {
+	lines, err := s.repo.ListLines(ctx, shipment.Uuid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shipment lines: %w", err)
+	}
+
+	res := &models.ShipmentResponse{
+		ID:             shipment.Uuid.String(),
+		OrderID:        shipment.OrderUuid.String(),
+		Carrier:        shipment.Carrier,
+		TrackingNumber: shipment.TrackingNumber,
+		Status:         models.ShipmentStatus(shipment.Status),
+		CreatedAt:      shipment.CreatedAt.Time.Format(time.RFC3339),
+		Lines:          make([]models.ShipmentLineResponse, 0, len(lines)),
+	}
+	if shipment.ShippedAt.Valid {
+		shippedAt := shipment.ShippedAt.Time.Format(time.RFC3339)
+		res.ShippedAt = &shippedAt
+	}
+	if shipment.DeliveredAt.Valid {
+		deliveredAt := shipment.DeliveredAt.Time.Format(time.RFC3339)
+		res.DeliveredAt = &deliveredAt
+	}
+
+	for _, line := range lines {
+		res.Lines = append(res.Lines, models.ShipmentLineResponse{
+			ProductID:   line.ProductUuid.String(),
+			ProductCode: line.ProductCode.String(),
+			Name:        line.ProductName,
+			Amount:      int(line.Amount),
+		})
+	}
+
+	return res, nil
+}
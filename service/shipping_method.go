@@ -0,0 +1,212 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/igntnk/stocky-oms/db"
+	"github.com/igntnk/stocky-oms/models"
+	"github.com/igntnk/stocky-oms/repository"
+	"github.com/jackc/pgx/v5/pgtype"
+	"time"
+)
+
+type ShippingMethodService interface {
+	CreateShippingMethod(ctx context.Context, req models.ShippingMethodCreateRequest) (*models.ShippingMethodResponse, error)
+	GetShippingMethod(ctx context.Context, id string) (*models.ShippingMethodResponse, error)
+	ListShippingMethods(ctx context.Context, filter models.ShippingMethodFilter) ([]*models.ShippingMethodResponse, error)
+	UpdateShippingMethod(ctx context.Context, id string, req models.ShippingMethodUpdateRequest) (*models.ShippingMethodResponse, error)
+	DeleteShippingMethod(ctx context.Context, id string) error
+}
+
+type shippingMethodService struct {
+	repo            repository.ShippingMethodRepository
+	defaultCurrency string
+}
+
+func NewShippingMethodService(repo repository.ShippingMethodRepository, defaultCurrency string) ShippingMethodService {
+	return &shippingMethodService{
+		repo:            repo,
+		defaultCurrency: defaultCurrency,
+	}
+}
+
+func (s *shippingMethodService) CreateShippingMethod(ctx context.Context, req models.ShippingMethodCreateRequest) (*models.ShippingMethodResponse, error) {
+	currency := req.Currency
+	if currency == "" {
+		currency = s.defaultCurrency
+	}
+
+	active := true
+	if req.Active != nil {
+		active = *req.Active
+	}
+
+	createParams := db.CreateShippingMethodParams{
+		Uuid: pgtype.UUID{
+			Bytes: uuid.New(),
+			Valid: true,
+		},
+		Code:     req.Code,
+		Name:     req.Name,
+		Rule:     db.ShippingRule(req.Rule),
+		Currency: currency,
+		Cost:     repository.MoneyToNumeric(*req.Cost),
+		Active:   active,
+	}
+
+	// amounts of other rules don't apply and are not stored
+	switch req.Rule {
+	case models.ShippingRuleWeight:
+		createParams.CostPerKg = repository.MoneyToNumeric(*req.CostPerKg)
+	case models.ShippingRuleFreeOver:
+		createParams.FreeThreshold = repository.MoneyToNumeric(*req.FreeThreshold)
+	}
+
+	method, err := s.repo.Create(ctx, createParams)
+	if err != nil {
+		if errors.Is(err, repository.ErrShippingMethodExists) {
+			return nil, ErrShippingMethodExists
+		}
+		return nil, fmt.Errorf("failed to create shipping method: %w", err)
+	}
+
+	return shippingMethodToResponse(method)
+}
+
+func (s *shippingMethodService) GetShippingMethod(ctx context.Context, id string) (*models.ShippingMethodResponse, error) {
+	methodUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, ErrInvalidShippingMethodID
+	}
+
+	method, err := s.repo.Get(ctx, methodUUID.String())
+	if err != nil {
+		if errors.Is(err, repository.ErrShippingMethodNotFound) {
+			return nil, ErrShippingMethodNotFound
+		}
+		return nil, fmt.Errorf("failed to get shipping method: %w", err)
+	}
+
+	return shippingMethodToResponse(method)
+}
+
+func (s *shippingMethodService) ListShippingMethods(ctx context.Context, filter models.ShippingMethodFilter) ([]*models.ShippingMethodResponse, error) {
+	methods, err := s.repo.List(ctx, int32(filter.Limit), int32(filter.Offset), filter.Active)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list shipping methods: %w", err)
+	}
+
+	response := make([]*models.ShippingMethodResponse, 0, len(methods))
+	for _, m := range methods {
+		method, err := shippingMethodToResponse(m)
+		if err != nil {
+			return nil, err
+		}
+		response = append(response, method)
+	}
+
+	return response, nil
+}
+
+// UpdateShippingMethod changes the amounts of a method. Placed orders keep
+// the shipping cost they were priced with.
+func (s *shippingMethodService) UpdateShippingMethod(
+	ctx context.Context,
+	id string,
+	req models.ShippingMethodUpdateRequest,
+) (*models.ShippingMethodResponse, error) {
+	methodUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, ErrInvalidShippingMethodID
+	}
+
+	updateParams := db.UpdateShippingMethodParams{
+		Uuid: pgtype.UUID{
+			Bytes: methodUUID,
+			Valid: true,
+		},
+	}
+
+	if req.Name != nil {
+		updateParams.Name = pgtype.Text{String: *req.Name, Valid: true}
+	}
+	if req.Cost != nil {
+		updateParams.Cost = repository.MoneyToNumeric(*req.Cost)
+	}
+	if req.CostPerKg != nil {
+		updateParams.CostPerKg = repository.MoneyToNumeric(*req.CostPerKg)
+	}
+	if req.FreeThreshold != nil {
+		updateParams.FreeThreshold = repository.MoneyToNumeric(*req.FreeThreshold)
+	}
+	if req.Active != nil {
+		updateParams.Active = pgtype.Bool{Bool: *req.Active, Valid: true}
+	}
+
+	method, err := s.repo.Update(ctx, updateParams)
+	if err != nil {
+		if errors.Is(err, repository.ErrShippingMethodNotFound) {
+			return nil, ErrShippingMethodNotFound
+		}
+		return nil, fmt.Errorf("failed to update shipping method: %w", err)
+	}
+
+	return shippingMethodToResponse(method)
+}
+
+func (s *shippingMethodService) DeleteShippingMethod(ctx context.Context, id string) error {
+	methodUUID, err := uuid.Parse(id)
+	if err != nil {
+		return ErrInvalidShippingMethodID
+	}
+
+	if err := s.repo.Delete(ctx, methodUUID.String()); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrShippingMethodNotFound):
+			return ErrShippingMethodNotFound
+		case errors.Is(err, repository.ErrShippingMethodInUse):
+			return ErrShippingMethodInUse
+		}
+		return fmt.Errorf("failed to delete shipping method: %w", err)
+	}
+
+	return nil
+}
+
+func shippingMethodToResponse(m db.ShippingMethod) (*models.ShippingMethodResponse, error) {
+	cost, err := repository.NumericToMoney(m.Cost)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &models.ShippingMethodResponse{
+		ID:        m.Uuid.String(),
+		Code:      m.Code,
+		Name:      m.Name,
+		Rule:      models.ShippingRule(m.Rule),
+		Currency:  m.Currency,
+		Cost:      cost,
+		Active:    m.Active,
+		CreatedAt: m.CreatedAt.Time.Format(time.RFC3339),
+	}
+
+	if m.CostPerKg.Valid {
+		perKg, err := repository.NumericToMoney(m.CostPerKg)
+		if err != nil {
+			return nil, err
+		}
+		response.CostPerKg = &perKg
+	}
+
+	if m.FreeThreshold.Valid {
+		threshold, err := repository.NumericToMoney(m.FreeThreshold)
+		if err != nil {
+			return nil, err
+		}
+		response.FreeThreshold = &threshold
+	}
+
+	return response, nil
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/igntnk/stocky-oms/db"
+	"github.com/igntnk/stocky-oms/models"
+	"github.com/igntnk/stocky-oms/repository"
+	"github.com/jackc/pgx/v5/pgtype"
+	"time"
+)
+
+type TaxService interface {
+	CreateTaxRule(ctx context.Context, req models.TaxRuleCreateRequest) (*models.TaxRuleResponse, error)
+	GetTaxRule(ctx context.Context, id string) (*models.TaxRuleResponse, error)
+	ListTaxRules(ctx context.Context, filter models.TaxRuleFilter) ([]*models.TaxRuleResponse, error)
+	UpdateTaxRule(ctx context.Context, id string, req models.TaxRuleUpdateRequest) (*models.TaxRuleResponse, error)
+	DeleteTaxRule(ctx context.Context, id string) error
+}
+
+type taxService struct {
+	repo repository.TaxRuleRepository
+}
+
+func NewTaxService(repo repository.TaxRuleRepository) TaxService {
+	return &taxService{repo: repo}
+}
+
+func (s *taxService) CreateTaxRule(ctx context.Context, req models.TaxRuleCreateRequest) (*models.TaxRuleResponse, error) {
+	var rate models.Money
+	if req.Rate != nil {
+		rate = *req.Rate
+	}
+
+	rule, err := s.repo.Create(ctx, db.CreateTaxRuleParams{
+		Uuid: pgtype.UUID{
+			Bytes: uuid.New(),
+			Valid: true,
+		},
+		TaxClass:  req.TaxClass,
+		Region:    req.Region,
+		Rate:      repository.MoneyToNumeric(rate),
+		Inclusive: req.Inclusive,
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrTaxRuleExists) {
+			return nil, ErrTaxRuleExists
+		}
+		return nil, fmt.Errorf("failed to create tax rule: %w", err)
+	}
+
+	return taxRuleToResponse(rule)
+}
+
+func (s *taxService) GetTaxRule(ctx context.Context, id string) (*models.TaxRuleResponse, error) {
+	ruleUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, ErrInvalidTaxRuleID
+	}
+
+	rule, err := s.repo.Get(ctx, ruleUUID.String())
+	if err != nil {
+		if errors.Is(err, repository.ErrTaxRuleNotFound) {
+			return nil, ErrTaxRuleNotFound
+		}
+		return nil, fmt.Errorf("failed to get tax rule: %w", err)
+	}
+
+	return taxRuleToResponse(rule)
+}
+
+func (s *taxService) ListTaxRules(ctx context.Context, filter models.TaxRuleFilter) ([]*models.TaxRuleResponse, error) {
+	rules, err := s.repo.List(ctx, int32(filter.Limit), int32(filter.Offset))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tax rules: %w", err)
+	}
+
+	response := make([]*models.TaxRuleResponse, 0, len(rules))
+	for _, r := range rules {
+		rule, err := taxRuleToResponse(r)
+		if err != nil {
+			return nil, err
+		}
+		response = append(response, rule)
+	}
+
+	return response, nil
+}
+
+func (s *taxService) UpdateTaxRule(ctx context.Context, id string, req models.TaxRuleUpdateRequest) (*models.TaxRuleResponse, error) {
+	ruleUUID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, ErrInvalidTaxRuleID
+	}
+
+	updateParams := db.UpdateTaxRuleParams{
+		Uuid: pgtype.UUID{
+			Bytes: ruleUUID,
+			Valid: true,
+		},
+	}
+
+	if req.Rate != nil {
+		updateParams.Rate = repository.MoneyToNumeric(*req.Rate)
+	}
+	if req.Inclusive != nil {
+		updateParams.Inclusive = pgtype.Bool{Bool: *req.Inclusive, Valid: true}
+	}
+
+	rule, err := s.repo.Update(ctx, updateParams)
+	if err != nil {
+		if errors.Is(err, repository.ErrTaxRuleNotFound) {
+			return nil, ErrTaxRuleNotFound
+		}
+		return nil, fmt.Errorf("failed to update tax rule: %w", err)
+	}
+
+	return taxRuleToResponse(rule)
+}
+
+func (s *taxService) DeleteTaxRule(ctx context.Context, id string) error {
+	ruleUUID, err := uuid.Parse(id)
+	if err != nil {
+		return ErrInvalidTaxRuleID
+	}
+
+	if err := s.repo.Delete(ctx, ruleUUID.String()); err != nil {
+		if errors.Is(err, repository.ErrTaxRuleNotFound) {
+			return ErrTaxRuleNotFound
+		}
+		return fmt.Errorf("failed to delete tax rule: %w", err)
+	}
+
+	return nil
+}
+
+func taxRuleToResponse(r db.TaxRule) (*models.TaxRuleResponse, error) {
+	rate, err := repository.NumericToMoney(r.Rate)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.TaxRuleResponse{
+		ID:        r.Uuid.String(),
+		TaxClass:  r.TaxClass,
+		Region:    r.Region,
+		Rate:      rate,
+		Inclusive: r.Inclusive,
+		CreatedAt: r.CreatedAt.Time.Format(time.RFC3339),
+	}, nil
+}
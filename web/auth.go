@@ -0,0 +1,73 @@
+package web
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/igntnk/stocky-oms/auth"
+	"net/http"
+	"strings"
+)
+
+// tenantHeader names the shop an admin request acts for when the token doesn't
+const tenantHeader = "X-Tenant-ID"
+
+// authenticate rejects requests without a valid bearer token and puts the
+// identity of the caller into the request context
+func authenticate(verifier auth.Verifier) gin.HandlerFunc {
+	return func(context *gin.Context) {
+		token, _ := strings.CutPrefix(context.GetHeader("Authorization"), "Bearer ")
+
+		identity, err := verifier.Verify(token)
+		if err != nil {
+			context.Header("WWW-Authenticate", `Bearer`)
+			context.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx := auth.WithToken(context.Request.Context(), token)
+		context.Request = context.Request.WithContext(auth.WithIdentity(ctx, identity))
+		context.Next()
+	}
+}
+
+// scopeTenant puts the shop the request acts for into the request context.
+// Requests for another shop than the one of their token are forbidden, only
+// admins without a shop in their token may pick one.
+func scopeTenant(fallback string) gin.HandlerFunc {
+	return func(context *gin.Context) {
+		identity, _ := auth.FromContext(context.Request.Context())
+
+		tenant, err := auth.ResolveTenant(identity, context.GetHeader(tenantHeader), fallback)
+		if err != nil {
+			status := http.StatusBadRequest
+			if errors.Is(err, auth.ErrPermissionDenied) {
+				status = http.StatusForbidden
+			}
+			context.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+			return
+		}
+
+		context.Request = context.Request.WithContext(auth.WithTenant(context.Request.Context(), tenant))
+		context.Next()
+	}
+}
+
+// authorize rejects requests the policy doesn't allow for the caller role.
+// Requests to unknown routes pass so they get a 404.
+func authorize(policy auth.Policy) gin.HandlerFunc {
+	return func(context *gin.Context) {
+		route := context.FullPath()
+		if route == "" {
+			context.Next()
+			return
+		}
+
+		identity, _ := auth.FromContext(context.Request.Context())
+		if !policy.Allows(context.Request.Method+" "+route, identity.Role) {
+			context.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": auth.ErrPermissionDenied.Error()})
+			return
+		}
+
+		context.Next()
+	}
+}
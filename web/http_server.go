@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"github.com/gin-gonic/gin"
+	"github.com/igntnk/stocky-oms/auth"
 	"github.com/igntnk/stocky-oms/controllers"
 	"github.com/rs/zerolog"
 	"net/http"
@@ -15,11 +16,13 @@ type httpServer struct {
 	srv    http.Server
 }
 
-func New(logger zerolog.Logger, port int,
+func New(logger zerolog.Logger, port int, verifier auth.Verifier, policy auth.Policy, defaultTenant string,
 	ctrl ...controllers.Controller) (HttpServer, error) {
 
 	r := gin.New()
-	r.Use(gin.Recovery())
+	// services get the gin context, this lets them see the caller identity and tenant
+	r.ContextWithFallback = true
+	r.Use(gin.Recovery(), authenticate(verifier), scopeTenant(defaultTenant), authorize(policy))
 
 	for i := 0; i < len(ctrl); i++ {
 		ctrl[i].Register(r)
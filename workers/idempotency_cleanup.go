@@ -0,0 +1,46 @@
+package workers
+
+import (
+	"context"
+	"github.com/igntnk/stocky-oms/service"
+	"github.com/rs/zerolog"
+	"time"
+)
+
+type idempotencyCleanup struct {
+	idempotency service.IdempotencyService
+	interval    time.Duration
+	logger      zerolog.Logger
+}
+
+// NewIdempotencyCleanup creates a worker that deletes expired idempotency
+// keys every interval
+func NewIdempotencyCleanup(logger zerolog.Logger, idempotency service.IdempotencyService, interval time.Duration) Worker {
+	return &idempotencyCleanup{
+		idempotency: idempotency,
+		interval:    interval,
+		logger:      logger.With().Str("Worker", "IdempotencyCleanup").Logger(),
+	}
+}
+
+func (w *idempotencyCleanup) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		deleted, err := w.idempotency.PurgeExpired(ctx)
+		if err != nil {
+			w.logger.Error().Err(err).Msg("failed to delete expired idempotency keys")
+			continue
+		}
+		if deleted > 0 {
+			w.logger.Info().Int64("deleted", deleted).Msg("deleted expired idempotency keys")
+		}
+	}
+}
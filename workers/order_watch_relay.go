@@ -0,0 +1,51 @@
+package workers
+
+import (
+	"context"
+	"github.com/igntnk/stocky-oms/repository"
+	"github.com/igntnk/stocky-oms/service"
+	"github.com/rs/zerolog"
+	"time"
+)
+
+type orderWatchRelay struct {
+	notifier      repository.OrderNotifier
+	watch         service.OrderWatchService
+	retryInterval time.Duration
+	logger        zerolog.Logger
+}
+
+// NewOrderWatchRelay creates a worker that passes order change notifications
+// to the order watchers. A lost database connection is retried every
+// retryInterval, changes made in between are not pushed.
+func NewOrderWatchRelay(
+	logger zerolog.Logger,
+	notifier repository.OrderNotifier,
+	watch service.OrderWatchService,
+	retryInterval time.Duration,
+) Worker {
+	return &orderWatchRelay{
+		notifier:      notifier,
+		watch:         watch,
+		retryInterval: retryInterval,
+		logger:        logger.With().Str("Worker", "OrderWatchRelay").Logger(),
+	}
+}
+
+func (w *orderWatchRelay) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.retryInterval)
+	defer ticker.Stop()
+
+	for {
+		err := w.notifier.Listen(ctx, w.watch.Publish)
+		if err != nil {
+			w.logger.Error().Err(err).Msg("failed to listen for order changes")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package workers
+
+import (
+	"context"
+	"github.com/igntnk/stocky-oms/events"
+	"github.com/igntnk/stocky-oms/models"
+	"github.com/igntnk/stocky-oms/repository"
+	"github.com/rs/zerolog"
+	"time"
+)
+
+type outboxRelay struct {
+	outbox    repository.OutboxRepository
+	publisher events.Publisher
+	interval  time.Duration
+	batchSize int32
+	logger    zerolog.Logger
+}
+
+// NewOutboxRelay creates a worker that publishes pending outbox events
+// every interval. Events that failed to publish stay pending and are
+// retried on the next poll.
+func NewOutboxRelay(
+	logger zerolog.Logger,
+	outbox repository.OutboxRepository,
+	publisher events.Publisher,
+	interval time.Duration,
+	batchSize int,
+) Worker {
+	return &outboxRelay{
+		outbox:    outbox,
+		publisher: publisher,
+		interval:  interval,
+		batchSize: int32(batchSize),
+		logger:    logger.With().Str("Worker", "OutboxRelay").Logger(),
+	}
+}
+
+func (w *outboxRelay) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		w.relay(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *outboxRelay) relay(ctx context.Context) {
+	for ctx.Err() == nil {
+		published, err := w.outbox.ProcessPending(ctx, w.batchSize, func(event models.OrderEvent) error {
+			return w.publisher.Publish(ctx, event)
+		})
+		if err != nil {
+			w.logger.Error().Err(err).Int("published", published).Msg("failed to publish outbox events")
+			return
+		}
+		// drain the backlog without waiting for the next tick
+		if published < int(w.batchSize) {
+			return
+		}
+	}
+}
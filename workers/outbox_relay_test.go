@@ -0,0 +1,111 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"github.com/igntnk/stocky-oms/events"
+	"github.com/igntnk/stocky-oms/models"
+	"github.com/igntnk/stocky-oms/repository"
+	"github.com/rs/zerolog"
+	"testing"
+	"time"
+)
+
+// fakeOutbox keeps events in insertion order and follows the ProcessPending
+// contract of the repository
+type fakeOutbox struct {
+	repository.OutboxRepository
+	events    []models.OrderEvent
+	published []bool
+	lastError []string
+	// batches counts the ProcessPending calls
+	batches int
+}
+
+func newFakeOutbox(n int) *fakeOutbox {
+	o := &fakeOutbox{
+		events:    make([]models.OrderEvent, n),
+		published: make([]bool, n),
+		lastError: make([]string, n),
+	}
+	for i := range o.events {
+		o.events[i] = models.OrderEvent{ID: fmt.Sprint(i), Type: models.OrderEventCreated}
+	}
+	return o
+}
+
+func (o *fakeOutbox) ProcessPending(ctx context.Context, limit int32, publish func(event models.OrderEvent) error) (int, error) {
+	o.batches++
+
+	var published int
+	for i, event := range o.events {
+		if o.published[i] || published == int(limit) {
+			continue
+		}
+		err := publish(event)
+		if err != nil {
+			o.lastError[i] = err.Error()
+			return published, fmt.Errorf("failed to publish event: %w", err)
+		}
+		o.published[i] = true
+		published++
+	}
+	return published, nil
+}
+
+func TestOutboxRelay(t *testing.T) {
+	tests := []struct {
+		name      string
+		events    int
+		batchSize int
+		// buffer is the number of events the publisher takes before it blocks
+		buffer        int
+		wantPublished int
+		wantBatches   int
+	}{
+		{name: "nothing pending", events: 0, batchSize: 2, buffer: 10, wantBatches: 1},
+		{name: "one batch", events: 1, batchSize: 2, buffer: 10, wantPublished: 1, wantBatches: 1},
+		{name: "backlog is drained", events: 5, batchSize: 2, buffer: 10, wantPublished: 5, wantBatches: 3},
+		{name: "full batches check for more", events: 4, batchSize: 2, buffer: 10, wantPublished: 4, wantBatches: 3},
+		{name: "publish failure stops the relay", events: 5, batchSize: 2, buffer: 3, wantPublished: 3, wantBatches: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outbox := newFakeOutbox(tt.events)
+			publisher := events.NewChannelPublisher(tt.buffer)
+			relay := NewOutboxRelay(zerolog.Nop(), outbox, publisher, time.Hour, tt.batchSize).(*outboxRelay)
+
+			// a full publisher blocks until the deadline and the event fails
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+			relay.relay(ctx)
+
+			if outbox.batches != tt.wantBatches {
+				t.Errorf("batches = %d, want %d", outbox.batches, tt.wantBatches)
+			}
+
+			got := len(publisher.Events())
+			if got != tt.wantPublished {
+				t.Fatalf("published %d events, want %d", got, tt.wantPublished)
+			}
+			for i := 0; i < tt.wantPublished; i++ {
+				event := <-publisher.Events()
+				if event.ID != fmt.Sprint(i) {
+					t.Errorf("event %d = %s, want %d", i, event.ID, i)
+				}
+			}
+
+			for i := range outbox.events {
+				wantPublished := i < tt.wantPublished
+				if outbox.published[i] != wantPublished {
+					t.Errorf("event %d published = %v, want %v", i, outbox.published[i], wantPublished)
+				}
+				wantError := i == tt.wantPublished && tt.wantPublished < tt.events
+				if (outbox.lastError[i] != "") != wantError {
+					t.Errorf("event %d error = %q, want an error %v", i, outbox.lastError[i], wantError)
+				}
+			}
+		})
+	}
+}
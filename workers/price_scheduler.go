@@ -0,0 +1,68 @@
+package workers
+
+import (
+	"context"
+	"github.com/igntnk/stocky-oms/models"
+	"github.com/igntnk/stocky-oms/service"
+	"github.com/rs/zerolog"
+	"time"
+)
+
+type priceScheduler struct {
+	priceChanges service.PriceScheduleService
+	interval     time.Duration
+	logger       zerolog.Logger
+}
+
+// NewPriceScheduler creates a worker that applies due scheduled price
+// changes every interval
+func NewPriceScheduler(logger zerolog.Logger, priceChanges service.PriceScheduleService, interval time.Duration) Worker {
+	return &priceScheduler{
+		priceChanges: priceChanges,
+		interval:     interval,
+		logger:       logger.With().Str("Worker", "PriceScheduler").Logger(),
+	}
+}
+
+func (w *priceScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		changes, err := w.priceChanges.ApplyDuePriceChanges(ctx)
+		for _, change := range changes {
+			w.logChange(change)
+		}
+		if err != nil {
+			w.logger.Error().Err(err).Msg("failed to apply scheduled price changes")
+		}
+	}
+}
+
+func (w *priceScheduler) logChange(change *models.PriceChangeResponse) {
+	if change.Status == models.PriceChangeStatusFailed {
+		w.logger.Warn().
+			Str("price_change", change.ID).
+			Str("product", change.ProductID).
+			Str("error", change.Error).
+			Msg("scheduled price change failed")
+		return
+	}
+
+	event := w.logger.Info().
+		Str("price_change", change.ID).
+		Str("product", change.ProductID)
+	if change.CustomerCost != nil {
+		event = event.Str("customer_cost", change.CustomerCost.String()).Str("currency", change.Currency)
+	}
+	if change.RevertOf != "" {
+		event = event.Str("revert_of", change.RevertOf)
+	}
+	event.Msg("applied scheduled price change")
+}
@@ -0,0 +1,51 @@
+package workers
+
+import (
+	"context"
+	"github.com/igntnk/stocky-oms/service"
+	"github.com/rs/zerolog"
+	"time"
+)
+
+type reservationSweeper struct {
+	orders   service.OrderService
+	interval time.Duration
+	logger   zerolog.Logger
+}
+
+// NewReservationSweeper creates a worker that cancels TCC orders whose
+// reservations expired without a confirm. It runs on start and then
+// every interval.
+func NewReservationSweeper(logger zerolog.Logger, orders service.OrderService, interval time.Duration) Worker {
+	return &reservationSweeper{
+		orders:   orders,
+		interval: interval,
+		logger:   logger.With().Str("Worker", "ReservationSweeper").Logger(),
+	}
+}
+
+func (w *reservationSweeper) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		w.sweep(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *reservationSweeper) sweep(ctx context.Context) {
+	expired, err := w.orders.ExpireReservations(ctx)
+	if err != nil {
+		w.logger.Error().Err(err).Int("expired", expired).Msg("failed to cancel some expired reservations")
+		return
+	}
+	if expired > 0 {
+		w.logger.Info().Int("expired", expired).Msg("cancelled orders with expired reservations")
+	}
+}
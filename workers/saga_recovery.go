@@ -0,0 +1,53 @@
+package workers
+
+import (
+	"context"
+	"github.com/igntnk/stocky-oms/service"
+	"github.com/rs/zerolog"
+	"time"
+)
+
+type sagaRecovery struct {
+	orders   service.OrderService
+	interval time.Duration
+	after    time.Duration
+	logger   zerolog.Logger
+}
+
+// NewSagaRecovery creates a worker that resumes or compensates sagas
+// that stayed unfinished for longer than after. It runs on start and
+// then every interval.
+func NewSagaRecovery(logger zerolog.Logger, orders service.OrderService, interval, after time.Duration) Worker {
+	return &sagaRecovery{
+		orders:   orders,
+		interval: interval,
+		after:    after,
+		logger:   logger.With().Str("Worker", "SagaRecovery").Logger(),
+	}
+}
+
+func (w *sagaRecovery) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		w.recover(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *sagaRecovery) recover(ctx context.Context) {
+	recovered, err := w.orders.RecoverSagas(ctx, w.after)
+	if err != nil {
+		w.logger.Error().Err(err).Int("recovered", recovered).Msg("failed to recover some sagas")
+		return
+	}
+	if recovered > 0 {
+		w.logger.Info().Int("recovered", recovered).Msg("recovered unfinished sagas")
+	}
+}